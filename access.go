@@ -0,0 +1,264 @@
+//
+// access.go works out the access status of a finding aid's components from their conditions governing access.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Access statuses reported by EffectiveAccess
+const (
+	AccessOpen       = "open"
+	AccessRestricted = "restricted"
+	AccessClosed     = "closed"
+)
+
+var (
+	// restrictionNormal matches a normalized date embedded in a note (e.g. <date normal="2030-01-01">)
+	restrictionNormal = regexp.MustCompile(`\snormal\s*=\s*["'](\d{4}(?:-\d{2}(?:-\d{2})?)?)["']`)
+	// restrictionDate matches a date a restriction ends on (e.g. "until 2030-01-01", "through 2029")
+	restrictionDate = regexp.MustCompile(`(?i)\b(until|till|through|thru|before|after)\s+(\d{4}-\d{2}-\d{2}|\d{4}-\d{2}|[A-Z][a-z]+\.?\s+\d{1,2},\s+\d{4}|\d{1,2}\s+[A-Z][a-z]+\.?\s+\d{4}|\d{4})\b`)
+	// restrictionNegated matches phrases denying a restriction (e.g. "not restricted",
+	// "no known restrictions", "unrestricted", "without restriction")
+	restrictionNegated = regexp.MustCompile(`\b(?:not|no|without|un)(?:\s+(?:be|been|known|access|use|other))?\s*(?:restrict|closed|sealed|embargo)\w*`)
+	// restrictionDateLayouts are the layouts tried when parsing a restriction's date
+	restrictionDateLayouts = []string{"2006-01-02", "2006-01", "January 2, 2006", "Jan. 2, 2006", "Jan 2, 2006", "2 January 2006", "2 Jan. 2006", "2 Jan 2006", "2006"}
+)
+
+// Restriction is a condition governing access parsed from an <accessrestrict>
+type Restriction struct {
+	// Status is AccessOpen, AccessRestricted or AccessClosed while the restriction is in force
+	Status string
+	// Until is the date the restriction ends, zero if it gives none
+	Until time.Time
+	// Text is the note as plain text
+	Text string
+	Note *AccessRestrict
+	// Component is the component given the note, nil for the ArchDesc
+	Component *Component
+}
+
+// Access is the effective access status of a component as of a date
+type Access struct {
+	Status string
+	// Restriction is the restriction governing the component, nil if none is given
+	Restriction *Restriction
+	// Inherited is true when the restriction is given by an ancestor or the ArchDesc
+	Inherited bool
+}
+
+// parseRestrictionDate returns the date a restriction given by keyword and s ends
+func parseRestrictionDate(keyword, s string) (time.Time, bool) {
+	s = strings.Join(strings.Fields(s), " ")
+	for _, layout := range restrictionDateLayouts {
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			continue
+		}
+		switch strings.ToLower(keyword) {
+		case "through", "thru", "after":
+			// the restriction covers the whole of the period named
+			switch layout {
+			case "2006":
+				t = t.AddDate(1, 0, 0)
+			case "2006-01":
+				t = t.AddDate(0, 1, 0)
+			default:
+				t = t.AddDate(0, 0, 1)
+			}
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// ParseRestriction reads the status and end date of an access restriction from its
+// localtype ("open", "restricted" or "closed") or, failing that, its text (e.g. "Closed
+// until 2030-01-01.").
+// Phrases denying a restriction (e.g. "not restricted", "no restriction") are ignored.
+func ParseRestriction(note *AccessRestrict) *Restriction {
+	restriction := &Restriction{Status: AccessOpen, Note: note}
+	if note == nil {
+		return restriction
+	}
+	// the head (e.g. "Restrictions on Access") says nothing of the status
+	n := newNote(note)
+	n.Head = ""
+	src := noteText(n)
+	restriction.Text = StripMarkup(src)
+	text := strings.ToLower(restriction.Text)
+
+	keyword := ""
+	if m := restrictionNormal.FindStringSubmatch(src); m != nil {
+		if t, ok := parseRestrictionDate("until", m[1]); ok == true {
+			restriction.Until = t
+		}
+	}
+	if m := restrictionDate.FindStringSubmatch(restriction.Text); m != nil {
+		keyword = strings.ToLower(m[1])
+		if t, ok := parseRestrictionDate(m[1], m[2]); ok == true && restriction.Until.IsZero() == true {
+			restriction.Until = t
+		}
+	}
+
+	localType := strings.ToLower(strings.TrimSpace(note.LocalType))
+	text = restrictionNegated.ReplaceAllString(text, "")
+	switch {
+	case localType == AccessClosed || localType == AccessRestricted || localType == AccessOpen:
+		restriction.Status = localType
+	case strings.Contains(text, "closed") || strings.Contains(text, "sealed") || strings.Contains(text, "embargo"):
+		restriction.Status = AccessClosed
+	case strings.Contains(text, "restrict") || strings.Contains(text, "permission") || strings.Contains(text, "confidential"):
+		restriction.Status = AccessRestricted
+	case restriction.Until.IsZero() == false && keyword == "after":
+		// e.g. "open to researchers after 2030"
+		restriction.Status = AccessClosed
+	}
+	return restriction
+}
+
+// Expired returns true if the restriction gives an end date on or before asOf
+func (restriction *Restriction) Expired(asOf time.Time) bool {
+	return restriction.Until.IsZero() == false && asOf.Before(restriction.Until) == false
+}
+
+// StatusOn returns the status of the restriction as of asOf, an expired restriction is open
+func (restriction *Restriction) StatusOn(asOf time.Time) string {
+	if restriction.Expired(asOf) == true {
+		return AccessOpen
+	}
+	return restriction.Status
+}
+
+// accessRank orders the statuses from the least to the most restrictive
+var accessRank = map[string]int{AccessOpen: 0, AccessRestricted: 1, AccessClosed: 2}
+
+// restrictions parses the access restrictions in notes, nested ones included
+func restrictions(c *Component, notes []*AccessRestrict) []*Restriction {
+	found := []*Restriction{}
+	for _, note := range appendNotes([]*Note{}, 0, notes) {
+		restriction := ParseRestriction(note.Element.(*AccessRestrict))
+		restriction.Component = c
+		found = append(found, restriction)
+	}
+	return found
+}
+
+// EffectiveAccess returns the access status of component c as of asOf. The nearest
+// of c, its ancestors and the ArchDesc giving an <accessrestrict> governs, and where it
+// gives several the most restrictive one still in force applies. A nil c reports the
+// access of the collection as a whole.
+func EffectiveAccess(ead *EAD3, c *Component, asOf time.Time) *Access {
+	levels := [][]*Restriction{}
+	if c != nil {
+		levels = append(levels, restrictions(c, c.AccessRestrict()))
+		for ancestor := c.Parent; ancestor != nil; ancestor = ancestor.Parent {
+			levels = append(levels, restrictions(ancestor, ancestor.AccessRestrict()))
+		}
+	}
+	if ead != nil && ead.ArchDesc != nil {
+		levels = append(levels, restrictions(nil, ead.ArchDesc.AccessRestrict))
+	}
+	for _, level := range levels {
+		if len(level) == 0 {
+			continue
+		}
+		access := &Access{Status: AccessOpen}
+		for _, restriction := range level {
+			status := restriction.StatusOn(asOf)
+			if access.Restriction == nil || accessRank[status] > accessRank[access.Status] {
+				access.Status, access.Restriction = status, restriction
+			}
+		}
+		access.Inherited = access.Restriction.Component != c
+		return access
+	}
+	return &Access{Status: AccessOpen}
+}
+
+// ExpiredRestriction is a restriction found by ExpiredRestrictions
+type ExpiredRestriction struct {
+	// RecordID is the recordid of the finding aid, see Aggregate
+	RecordID string `json:"recordid"`
+	// ComponentID is the id of the component given the restriction, empty for the
+	// ArchDesc or a component without an id
+	ComponentID string `json:"component_id,omitempty"`
+	// Title is the title of the component or, for the ArchDesc, the finding aid
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	// Until is the date the restriction ended as YYYY-MM-DD
+	Until string `json:"until"`
+	Text  string `json:"text"`
+}
+
+// ExpiredRestrictions reports the access restrictions in records that ended on or
+// before asOf, e.g. so the notes can be revised and the materials opened
+func ExpiredRestrictions(records []*EAD3, asOf time.Time) []*ExpiredRestriction {
+	expired := []*ExpiredRestriction{}
+	add := func(recordID, title string, restriction *Restriction) {
+		if restriction.Status == AccessOpen || restriction.Expired(asOf) == false {
+			return
+		}
+		report := &ExpiredRestriction{
+			RecordID: recordID,
+			Title:    title,
+			Status:   restriction.Status,
+			Until:    restriction.Until.Format("2006-01-02"),
+			Text:     restriction.Text,
+		}
+		if restriction.Component != nil {
+			report.ComponentID = restriction.Component.ID()
+		}
+		expired = append(expired, report)
+	}
+	for i, record := range records {
+		if record == nil || record.ArchDesc == nil {
+			continue
+		}
+		recordID := facetRecordID(i, record)
+		for _, restriction := range restrictions(nil, record.ArchDesc.AccessRestrict) {
+			add(recordID, findingAidTitle(record), restriction)
+		}
+		record.Walk(func(c *Component) error {
+			for _, restriction := range restrictions(c, c.AccessRestrict()) {
+				add(recordID, c.Title(), restriction)
+			}
+			return nil
+		})
+	}
+	return expired
+}
@@ -0,0 +1,106 @@
+//
+// access_test.go tests parsing access restrictions and computing effective access.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRestriction(t *testing.T) {
+	testData := []struct {
+		localType, text, status, until string
+	}{
+		{"", "Closed until 2030-01-01.", AccessClosed, "2030-01-01"},
+		{"", "Collection is open for researchers with no restrictions.", AccessOpen, ""},
+		{"", "Restricted through 2029.", AccessRestricted, "2030-01-01"},
+		{"", "Open to researchers after January 1, 2030.", AccessClosed, "2030-01-02"},
+		{"", `Sealed until <date normal="2031-06-30">the end of June 2031</date>.`, AccessClosed, "2031-06-30"},
+		{"closed", "Please consult the archivist.", AccessClosed, ""},
+		{"", "This collection is not restricted.", AccessOpen, ""},
+		{"", "There is no restriction on access.", AccessOpen, ""},
+		{"", "There are no known restrictions on access to this collection.", AccessOpen, ""},
+		{"", "The collection is open for research.", AccessOpen, ""},
+		{"", "Open for research without restriction.", AccessOpen, ""},
+		{"", "The papers are unrestricted and not closed to researchers.", AccessOpen, ""},
+		{"", "Open for research, but student records are restricted.", AccessRestricted, ""},
+		{"", "Not to be used without permission of the donor.", AccessRestricted, ""},
+		{"", "The papers were donated in 1995.", AccessOpen, ""},
+		{"", "Accessioned on 2001-05-04 from the estate.", AccessOpen, ""},
+		{"unrestricted", "Please consult the archivist.", AccessOpen, ""},
+		{" Restricted ", "Please consult the archivist.", AccessRestricted, ""},
+	}
+	for _, td := range testData {
+		restriction := ParseRestriction(&AccessRestrict{LocalType: td.localType, P: []*P{{Value: td.text}}})
+		until := ""
+		if restriction.Until.IsZero() == false {
+			until = restriction.Until.Format("2006-01-02")
+		}
+		if restriction.Status != td.status || until != td.until {
+			t.Errorf("%q, expected %s until %q, got %s until %q", td.text, td.status, td.until, restriction.Status, until)
+		}
+	}
+}
+
+func TestEffectiveAccess(t *testing.T) {
+	record := readTestRecord(t, "testsamples/ead3/EAD3test.xml")
+	before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	files := record.Components()[0].Children
+
+	// the collection's restriction until 2025 is inherited by the first file
+	access := EffectiveAccess(record, files[0], before)
+	if access.Status != AccessRestricted || access.Inherited == false || access.Restriction.Component != nil {
+		t.Errorf("expected an inherited restriction, got %+v", access)
+	}
+	if access = EffectiveAccess(record, files[0], after); access.Status != AccessOpen {
+		t.Errorf("expected the expired restriction to leave the file open, got %+v", access)
+	}
+	// the second file has its own restriction without an end date
+	if access = EffectiveAccess(record, files[1], after); access.Status != AccessRestricted || access.Inherited == true {
+		t.Errorf("expected the file's own restriction, got %+v", access)
+	}
+	if access = EffectiveAccess(New(), nil, after); access.Status != AccessOpen || access.Restriction != nil {
+		t.Errorf("expected a record without restrictions to be open, got %+v", access)
+	}
+
+	expired := ExpiredRestrictions([]*EAD3{record}, after)
+	if len(expired) != 1 || expired[0].Until != "2025-01-01" || expired[0].ComponentID != "" {
+		t.Errorf("expected the collection's restriction to have expired, got %+v", expired)
+	}
+	if expired = ExpiredRestrictions([]*EAD3{record}, before); len(expired) != 0 {
+		t.Errorf("expected no expired restrictions in 2024, got %+v", expired)
+	}
+}
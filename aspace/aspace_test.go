@@ -0,0 +1,227 @@
+//
+// aspace_test.go tests the conversion between EAD3 and ArchivesSpace JSONModel objects.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package aspace
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/caltechlibrary/ead3"
+)
+
+func readRecord(t *testing.T, fname string) *ead3.EAD3 {
+	src, err := ioutil.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	record := ead3.New()
+	if err := xml.Unmarshal(src, &record); err != nil {
+		t.Fatalf("%s, %s", fname, err)
+	}
+	return record
+}
+
+func TestExport(t *testing.T) {
+	record := readRecord(t, "../testsamples/ead3/UMN/uarc01180.xml")
+	set, err := Export(record)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if set.Resource.URI != "/repositories/2/resources/1" || set.Resource.Title == "" {
+		t.Errorf("unexpected resource %+v", set.Resource)
+	}
+	components := 0
+	record.Walk(func(c *ead3.Component) error {
+		components++
+		return nil
+	})
+	if len(set.ArchivalObjects) != components {
+		t.Errorf("expected %d archival objects, got %d", components, len(set.ArchivalObjects))
+	}
+	if len(set.DigitalObjects) != 54 {
+		t.Errorf("expected 54 digital objects, got %d", len(set.DigitalObjects))
+	}
+	if len(set.TopContainers) == 0 {
+		t.Errorf("expected top containers")
+	}
+	// the first folder is box 1, folder 24 with a digital object
+	var o *ArchivalObject
+	for _, ao := range set.ArchivalObjects {
+		for _, instance := range ao.Instances {
+			if instance.DigitalObject != nil && o == nil {
+				o = ao
+			}
+		}
+	}
+	if o == nil || o.Parent == nil {
+		t.Fatalf("expected a nested archival object with a digital object")
+	}
+	if len(o.Instances) != 2 || o.Instances[0].SubContainer == nil {
+		t.Fatalf("expected a container and a digital object instance, got %+v", o.Instances)
+	}
+	sub := o.Instances[0].SubContainer
+	if sub.Type2 != "folder" || sub.Indicator2 != "24" {
+		t.Errorf("expected folder 24, got %+v", sub)
+	}
+	for _, tc := range set.TopContainers {
+		if tc.URI == sub.TopContainer.Ref && (tc.Type != "box" || tc.Indicator != "1") {
+			t.Errorf("expected box 1, got %+v", tc)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	record := readRecord(t, "../testsamples/ead3/NCSU/mc00019.xml")
+	set, err := Export(record)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	dname, err := ioutil.TempDir("", "aspace")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer os.RemoveAll(dname)
+	if err := set.WriteFiles(dname); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if _, err := os.Stat(path.Join(dname, "repositories/2/resources/1.json")); err != nil {
+		t.Errorf("expected resource file, %s", err)
+	}
+	set2, err := ReadFiles(dname)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(set2.ArchivalObjects) != len(set.ArchivalObjects) || len(set2.Agents) != len(set.Agents) || len(set2.Subjects) != len(set.Subjects) {
+		t.Errorf("expected the same objects read back")
+	}
+	record2, err := Import(set2)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if record2.Control.RecordID.Value != record.Control.RecordID.Value {
+		t.Errorf("expected recordid %q, got %q", record.Control.RecordID.Value, record2.Control.RecordID.Value)
+	}
+	if record2.ArchDesc.DID[0].UnitTitle.Value != record.ArchDesc.DID[0].UnitTitle.Value {
+		t.Errorf("expected title %q, got %q", record.ArchDesc.DID[0].UnitTitle.Value, record2.ArchDesc.DID[0].UnitTitle.Value)
+	}
+
+	// components keep their order, titles and containers, those without an id
+	// are given the generated ref_id
+	before, after := record.Components(), record2.Components()
+	titles := func(components []*ead3.Component) []string {
+		s := []string{}
+		ead3.Walk(components, func(c *ead3.Component) error {
+			s = append(s, c.Title())
+			for _, container := range c.Containers() {
+				s = append(s, container.LocalType+" "+container.Value)
+			}
+			return nil
+		})
+		return s
+	}
+	a, b := titles(before), titles(after)
+	if len(a) != len(b) {
+		t.Fatalf("expected %d components, got %d", len(a), len(b))
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Errorf("expected %q, got %q", a[i], b[i])
+		}
+	}
+
+	// headings come back in canonical form
+	headings := func(record *ead3.EAD3) map[string]bool {
+		m := map[string]bool{}
+		for _, ca := range record.ArchDesc.ControlAccess {
+			for _, h := range ca.Headings() {
+				m[h.Type+" "+h.Value] = true
+			}
+		}
+		return m
+	}
+	h1, h2 := headings(record), headings(record2)
+	for k := range h1 {
+		if h2[k] == false && k[0:4] != "name" {
+			t.Errorf("expected heading %q after import", k)
+		}
+	}
+
+	// notes keep their heads and paragraphs
+	n1, n2 := record.ArchDesc.Notes(), record2.ArchDesc.Notes()
+	if len(n1) != len(n2) {
+		t.Fatalf("expected %d notes, got %d", len(n1), len(n2))
+	}
+	for i := range n1 {
+		if n1[i].Name != n2[i].Name || n1[i].Label() != n2[i].Label() || len(n1[i].P) != len(n2[i].P) {
+			t.Errorf("expected note %s %q with %d paragraphs, got %s %q with %d", n1[i].Name, n1[i].Label(), len(n1[i].P), n2[i].Name, n2[i].Label(), len(n2[i].P))
+		}
+	}
+	if _, err := xml.Marshal(record2); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestNoteBlocks(t *testing.T) {
+	blocks := func(record *ead3.EAD3) (int, int) {
+		lists, chronLists := 0, 0
+		for _, note := range record.ArchDesc.Notes() {
+			lists, chronLists = lists+len(note.List), chronLists+len(note.ChronList)
+		}
+		record.Walk(func(c *ead3.Component) error {
+			for _, note := range c.Notes() {
+				lists, chronLists = lists+len(note.List), chronLists+len(note.ChronList)
+			}
+			return nil
+		})
+		return lists, chronLists
+	}
+	record := readRecord(t, "../testsamples/ead3/S.0001_valid.xml")
+	set, err := Export(record)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	record2, err := Import(set)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	lists, chronLists := blocks(record)
+	lists2, chronLists2 := blocks(record2)
+	if chronLists == 0 || chronLists2 != chronLists || lists2 != lists {
+		t.Errorf("expected %d lists and %d chronlists imported, got %d and %d", lists, chronLists, lists2, chronLists2)
+	}
+}
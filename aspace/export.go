@@ -0,0 +1,699 @@
+//
+// export.go converts EAD3 records into ArchivesSpace JSONModel objects.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package aspace
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/caltechlibrary/ead3"
+)
+
+// Exporter converts EAD3 records into sets of JSONModel objects
+type Exporter struct {
+	// RepositoryURI is the repository holding the resource, default "/repositories/2"
+	RepositoryURI string
+	// Publish is copied to the publish flag of each object
+	Publish bool
+
+	set           *Set
+	counts        map[string]int
+	topContainers map[string]*TopContainer
+	agents        map[string]*Agent
+	subjects      map[string]*Subject
+	digitalObjs   map[string]*DigitalObject
+}
+
+// NewExporter returns an Exporter for the default repository with publishing on
+func NewExporter() *Exporter {
+	return &Exporter{
+		RepositoryURI: "/repositories/2",
+		Publish:       true,
+	}
+}
+
+// agentPaths and agentTypes map the EAD3 name elements to ArchivesSpace agents, <name>
+// has no ArchivesSpace counterpart and is left out
+var (
+	agentPaths = map[string]string{
+		"persname": "/agents/people",
+		"famname":  "/agents/families",
+		"corpname": "/agents/corporate_entities",
+	}
+	agentTypes = map[string]string{
+		"persname": "agent_person",
+		"famname":  "agent_family",
+		"corpname": "agent_corporate_entity",
+	}
+)
+
+// termTypes are the ArchivesSpace term types of the EAD3 subject elements
+var termTypes = map[string]string{
+	"subject":    "topical",
+	"genreform":  "genre_form",
+	"geogname":   "geographic",
+	"occupation": "occupation",
+	"function":   "function",
+}
+
+// partTermTypes maps part localtypes (including the MARC subdivision codes) to term types
+var partTermTypes = map[string]string{
+	"topical":          "topical",
+	"geographic":       "geographic",
+	"temporal":         "temporal",
+	"chronological":    "temporal",
+	"genre_form":       "genre_form",
+	"genreform":        "genre_form",
+	"form":             "genre_form",
+	"occupation":       "occupation",
+	"function":         "function",
+	"cultural_context": "cultural_context",
+	"style_period":     "style_period",
+	"uniform_title":    "uniform_title",
+	"x":                "topical",
+	"y":                "temporal",
+	"z":                "geographic",
+	"v":                "genre_form",
+}
+
+// nextURI returns a new uri for an object stored under kind (e.g. "/subjects")
+func (x *Exporter) nextURI(kind string) string {
+	x.counts[kind]++
+	return fmt.Sprintf("%s/%d", kind, x.counts[kind])
+}
+
+// Export converts the record to a set of JSONModel objects using the default Exporter
+func Export(record *ead3.EAD3) (*Set, error) {
+	return NewExporter().Export(record)
+}
+
+// Export converts the record's ArchDesc to a resource, its components to a tree of
+// archival objects, containers to top containers, digital archival objects to digital
+// objects and its names and subjects to agents and subjects. Notes are converted through
+// their common ead3.Note view, tables have no ArchivesSpace counterpart and are left out.
+func (x *Exporter) Export(record *ead3.EAD3) (*Set, error) {
+	if record == nil || record.ArchDesc == nil {
+		return nil, fmt.Errorf("missing archdesc")
+	}
+	x.set = &Set{
+		ArchivalObjects: []*ArchivalObject{},
+		TopContainers:   []*TopContainer{},
+		Agents:          []*Agent{},
+		Subjects:        []*Subject{},
+		DigitalObjects:  []*DigitalObject{},
+	}
+	x.counts = map[string]int{}
+	x.topContainers = map[string]*TopContainer{}
+	x.agents = map[string]*Agent{}
+	x.subjects = map[string]*Subject{}
+	x.digitalObjs = map[string]*DigitalObject{}
+
+	archDesc := record.ArchDesc
+	resource := &Resource{
+		JSONModelType: "resource",
+		URI:           x.nextURI(x.RepositoryURI + "/resources"),
+		Level:         archDesc.Level,
+		Repository:    &Ref{Ref: x.RepositoryURI},
+		Publish:       x.Publish,
+	}
+	if record.Control != nil {
+		if record.Control.RecordID != nil {
+			resource.EADID = record.Control.RecordID.Value
+		}
+		if record.Control.FileDesc != nil && record.Control.FileDesc.TitleStmt != nil && record.Control.FileDesc.TitleStmt.TitleProper != nil {
+			resource.FindingAidTitle = record.Control.FileDesc.TitleStmt.TitleProper.Value
+		}
+	}
+	d := x.newDescription()
+	for _, did := range archDesc.DID {
+		if resource.Title == "" && did.UnitTitle != nil {
+			resource.Title = did.UnitTitle.Value
+		}
+		if resource.ID0 == "" && did.UnitID != nil {
+			resource.ID0 = did.UnitID.Value
+		}
+		x.describeDID(d, did, did.Container, did.DAOs())
+	}
+	for _, note := range archDesc.Notes() {
+		d.Notes = append(d.Notes, x.multipartNote(note))
+	}
+	for _, controlAccess := range archDesc.ControlAccess {
+		x.controlAccess(d, controlAccess)
+	}
+	if resource.Title == "" {
+		resource.Title = resource.FindingAidTitle
+	}
+	resource.Dates, resource.Extents, resource.Notes = d.Dates, d.Extents, d.Notes
+	resource.LangMaterials = d.LangMaterials
+	resource.LinkedAgents, resource.Subjects, resource.Instances = d.LinkedAgents, d.Subjects, d.Instances
+	x.set.Resource = resource
+
+	x.archivalObjects(record.Components(), nil, []string{})
+	return x.set, nil
+}
+
+// description collects the properties shared by resources and archival objects
+type description struct {
+	Dates         []*Date
+	Extents       []*Extent
+	LangMaterials []*LangMaterial
+	Notes         []*Note
+	LinkedAgents  []*LinkedAgent
+	Subjects      []*Ref
+	Instances     []*Instance
+	linked        map[string]bool
+}
+
+func (x *Exporter) newDescription() *description {
+	return &description{
+		Dates:        []*Date{},
+		Extents:      []*Extent{},
+		Notes:        []*Note{},
+		LinkedAgents: []*LinkedAgent{},
+		Subjects:     []*Ref{},
+		Instances:    []*Instance{},
+		linked:       map[string]bool{},
+	}
+}
+
+// archivalObjects adds the components as archival objects under parent, path holds the
+// positions leading to the components and names those without an id
+func (x *Exporter) archivalObjects(components []*ead3.Component, parent *Ref, path []string) {
+	for i, c := range components {
+		position := append(append([]string{}, path...), fmt.Sprintf("%d", i+1))
+		o := &ArchivalObject{
+			JSONModelType: "archival_object",
+			URI:           x.nextURI(x.RepositoryURI + "/archival_objects"),
+			RefID:         c.ID(),
+			Level:         c.Level(),
+			Resource:      &Ref{Ref: x.set.Resource.URI},
+			Parent:        parent,
+			Position:      i,
+			Publish:       x.Publish,
+		}
+		if o.RefID == "" {
+			o.RefID = "aspace_" + strings.Join(position, "_")
+		}
+		if o.Level == "" {
+			o.Level = "file"
+		}
+		d := x.newDescription()
+		if did := c.DID(); did != nil {
+			if did.UnitTitle != nil {
+				o.Title = did.UnitTitle.Value
+			}
+			if did.UnitID != nil {
+				o.ComponentID = did.UnitID.Value
+			}
+			x.describeDID(d, did, c.Containers(), c.DAOs())
+		} else {
+			x.instances(d, c.Containers(), c.DAOs(), "")
+		}
+		for _, note := range c.Notes() {
+			d.Notes = append(d.Notes, x.multipartNote(note))
+		}
+		for _, controlAccess := range c.ControlAccess() {
+			x.controlAccess(d, controlAccess)
+		}
+		o.Dates, o.Extents, o.Notes = d.Dates, d.Extents, d.Notes
+		o.LinkedAgents, o.Subjects, o.Instances = d.LinkedAgents, d.Subjects, d.Instances
+		x.set.ArchivalObjects = append(x.set.ArchivalObjects, o)
+		x.archivalObjects(c.Children, &Ref{Ref: o.URI}, position)
+	}
+}
+
+// describeDID adds the dates, extents, single part notes, languages, creators and
+// instances of a DID
+func (x *Exporter) describeDID(d *description, did *ead3.DID, containers []*ead3.Container, daos []*ead3.DAO) {
+	for _, u := range did.UnitDateStructured {
+		d.Dates = append(d.Dates, structuredDates(u)...)
+	}
+	for _, u := range did.UnitDate {
+		d.Dates = append(d.Dates, unitDate(u))
+	}
+	structured := did.PhysDescStructured
+	if did.PhysDescSet != nil {
+		structured = append(structured, did.PhysDescSet.PhysDescStructured...)
+	}
+	for _, p := range structured {
+		if p.Quantity == nil || p.UnitType == nil {
+			continue
+		}
+		extent := &Extent{
+			JSONModelType: "extent",
+			Portion:       "whole",
+			Number:        strings.TrimSpace(p.Quantity.Value),
+			ExtentType:    strings.TrimSpace(p.UnitType.Value),
+		}
+		if p.Coverage == "part" {
+			extent.Portion = "part"
+		}
+		if p.PhysFacet != nil {
+			extent.PhysicalDetails = p.PhysFacet.Value
+		}
+		if p.Dimensions != nil {
+			extent.Dimensions = p.Dimensions.Value
+		}
+		d.Extents = append(d.Extents, extent)
+	}
+	if did.Abstract != nil {
+		d.Notes = append(d.Notes, x.singlepartNote("abstract", did.Abstract.Label, did.Abstract.Value))
+	}
+	if did.PhysDesc != nil {
+		d.Notes = append(d.Notes, x.singlepartNote("physdesc", did.PhysDesc.Label, did.PhysDesc.Value))
+	}
+	if did.PhysLoc != nil {
+		d.Notes = append(d.Notes, x.singlepartNote("physloc", did.PhysLoc.Label, did.PhysLoc.Value))
+	}
+	if did.MaterialSpec != nil {
+		d.Notes = append(d.Notes, x.singlepartNote("materialspec", "", did.MaterialSpec.Value))
+	}
+	if did.DIDNote != nil {
+		// ArchivesSpace keeps <didnote> as a general note
+		d.Notes = append(d.Notes, x.multipartNote(&ead3.Note{Name: "odd", Head: did.DIDNote.Label, P: []*ead3.P{{Value: did.DIDNote.Value}}}))
+	}
+	if lm := did.LangMaterial; lm != nil {
+		for _, language := range lm.Languages() {
+			if language.LangCode != "" {
+				d.LangMaterials = append(d.LangMaterials, &LangMaterial{
+					JSONModelType:     "lang_material",
+					LanguageAndScript: &LanguageAndScript{JSONModelType: "language_and_script", Language: language.LangCode},
+				})
+			}
+		}
+		if lm.DescriptiveNote != nil {
+			paragraphs := []string{}
+			for _, p := range lm.DescriptiveNote.P {
+				paragraphs = append(paragraphs, p.Value)
+			}
+			d.Notes = append(d.Notes, x.singlepartNote("langmaterial", "", strings.Join(paragraphs, "\n\n")))
+		}
+	}
+	for _, heading := range did.Origination.Headings() {
+		x.linkAgent(d, heading, "creator")
+	}
+	title := ""
+	if did.UnitTitle != nil {
+		title = ead3.StripMarkup(did.UnitTitle.Value)
+	}
+	x.instances(d, containers, daos, title)
+}
+
+// certainty maps EAD3 certainty values to ArchivesSpace ones
+func certainty(s string) string {
+	switch s {
+	case "approximate", "approximately", "circa":
+		return "approximate"
+	case "inferred":
+		return "inferred"
+	case "questionable", "uncertain":
+		return "questionable"
+	}
+	return ""
+}
+
+// structuredDates converts a <unitdatestructured>
+func structuredDates(u *ead3.UnitDateStructured) []*Date {
+	dates := []*Date{}
+	dateType := "inclusive"
+	if u.UnitDateType == "bulk" {
+		dateType = "bulk"
+	}
+	if s := u.DateSingle; s != nil {
+		date := &Date{JSONModelType: "date", DateType: "single", Label: "creation", Begin: s.StandardDate, Certainty: certainty(u.Certainty)}
+		if date.Begin == "" {
+			date.Expression = strings.TrimSpace(s.Value)
+		}
+		dates = append(dates, date)
+	}
+	for _, r := range u.DateRange {
+		date := &Date{JSONModelType: "date", DateType: dateType, Label: "creation", Certainty: certainty(u.Certainty)}
+		expression := []string{}
+		if r.FromDate != nil {
+			date.Begin = r.FromDate.StandardDate
+			expression = append(expression, strings.TrimSpace(r.FromDate.Value))
+		}
+		if r.ToDate != nil {
+			date.End = r.ToDate.StandardDate
+			expression = append(expression, strings.TrimSpace(r.ToDate.Value))
+		}
+		if date.Begin == "" {
+			date.Expression = strings.Trim(strings.Join(expression, "-"), "-")
+		}
+		dates = append(dates, date)
+	}
+	return dates
+}
+
+// unitDate converts a <unitdate>, a normal attribute gives the begin and end
+func unitDate(u *ead3.UnitDate) *Date {
+	date := &Date{
+		JSONModelType: "date",
+		DateType:      "inclusive",
+		Label:         "creation",
+		Expression:    strings.TrimSpace(u.Value),
+		Certainty:     certainty(u.Certainty),
+	}
+	if u.UnitDateType == "bulk" {
+		date.DateType = "bulk"
+	}
+	if normal := strings.TrimSpace(u.Normal); normal != "" {
+		if i := strings.Index(normal, "/"); i >= 0 {
+			date.Begin, date.End = normal[0:i], normal[i+1:]
+		} else {
+			date.DateType, date.Begin = "single", normal
+		}
+	}
+	return date
+}
+
+// singlepartNote returns a note_singlepart of type holding text
+func (x *Exporter) singlepartNote(noteType, label, text string) *Note {
+	return &Note{
+		JSONModelType: "note_singlepart",
+		Type:          noteType,
+		Label:         label,
+		Content:       []string{strings.TrimSpace(text)},
+		Publish:       x.Publish,
+	}
+}
+
+// listItems returns the content of the <item> elements of a list
+func listItems(list *ead3.List) []string {
+	doc := struct {
+		Item []struct {
+			Value string `xml:",innerxml"`
+		} `xml:"item"`
+	}{}
+	items := []string{}
+	if err := xml.Unmarshal([]byte("<list>"+list.Value+"</list>"), &doc); err != nil {
+		return items
+	}
+	for _, item := range doc.Item {
+		items = append(items, strings.TrimSpace(item.Value))
+	}
+	return items
+}
+
+// multipartNote converts a narrative note, paragraphs become a note_text, a list an
+// ordered list and a chronology list a chronology
+func (x *Exporter) multipartNote(note *ead3.Note) *Note {
+	out := &Note{
+		JSONModelType: "note_multipart",
+		Type:          note.Name,
+		PersistentID:  note.ID,
+		SubNotes:      []*SubNote{},
+		Publish:       x.Publish,
+	}
+	if note.Head != "" {
+		out.Label = ead3.StripMarkup(note.Head)
+	}
+	if len(note.P) > 0 {
+		paragraphs := []string{}
+		for _, p := range note.P {
+			paragraphs = append(paragraphs, strings.TrimSpace(p.Value))
+		}
+		out.SubNotes = append(out.SubNotes, &SubNote{JSONModelType: "note_text", Content: strings.Join(paragraphs, "\n\n"), Publish: x.Publish})
+	}
+	for _, list := range note.List {
+		enumeration := ""
+		if list.ListType == "ordered" {
+			enumeration = "arabic"
+		}
+		out.SubNotes = append(out.SubNotes, &SubNote{JSONModelType: "note_orderedlist", Enumeration: enumeration, Items: listItems(list), Publish: x.Publish})
+	}
+	for _, chronList := range note.ChronList {
+		items := []*ChronologyItem{}
+		for _, chronItem := range chronList.ChronItem {
+			item := &ChronologyItem{Events: []string{}}
+			item.EventDate = chronItem.Date()
+			for _, event := range chronItem.Events() {
+				item.Events = append(item.Events, strings.TrimSpace(event.Value))
+			}
+			items = append(items, item)
+		}
+		out.SubNotes = append(out.SubNotes, &SubNote{JSONModelType: "note_chronology", ChronologyItems: items, Publish: x.Publish})
+	}
+	return out
+}
+
+// instances adds a mixed materials instance for the containers and a digital object
+// instance for each DAO, title names digital objects without a description
+func (x *Exporter) instances(d *description, containers []*ead3.Container, daos []*ead3.DAO, title string) {
+	if len(containers) > 0 {
+		top := containers[0]
+		key := top.LocalType + "\x00" + strings.TrimSpace(top.Value)
+		tc, ok := x.topContainers[key]
+		if ok == false {
+			tc = &TopContainer{
+				JSONModelType: "top_container",
+				URI:           x.nextURI(x.RepositoryURI + "/top_containers"),
+				Type:          top.LocalType,
+				Indicator:     strings.TrimSpace(top.Value),
+			}
+			x.topContainers[key] = tc
+			x.set.TopContainers = append(x.set.TopContainers, tc)
+		}
+		sub := &SubContainer{JSONModelType: "sub_container", TopContainer: &Ref{Ref: tc.URI}}
+		if len(containers) > 1 {
+			sub.Type2, sub.Indicator2 = containers[1].LocalType, strings.TrimSpace(containers[1].Value)
+		}
+		if len(containers) > 2 {
+			sub.Type3, sub.Indicator3 = containers[2].LocalType, strings.TrimSpace(containers[2].Value)
+		}
+		d.Instances = append(d.Instances, &Instance{JSONModelType: "instance", InstanceType: "mixed_materials", SubContainer: sub})
+	}
+	for _, dao := range daos {
+		href := strings.TrimSpace(dao.HRef)
+		if href == "" {
+			continue
+		}
+		o, ok := x.digitalObjs[href]
+		if ok == false {
+			o = &DigitalObject{
+				JSONModelType:   "digital_object",
+				URI:             x.nextURI(x.RepositoryURI + "/digital_objects"),
+				DigitalObjectID: href,
+				Title:           title,
+				FileVersions:    []*FileVersion{{JSONModelType: "file_version", FileURI: href, Publish: x.Publish}},
+				Notes:           []*Note{},
+				Publish:         x.Publish,
+			}
+			if s := strings.TrimSpace(dao.Identifier); s != "" {
+				o.DigitalObjectID = s
+			}
+			if s := dao.Label(); s != "" {
+				o.Title = s
+			}
+			if o.Title == "" {
+				o.Title = href
+			}
+			x.digitalObjs[href] = o
+			x.set.DigitalObjects = append(x.set.DigitalObjects, o)
+		}
+		d.Instances = append(d.Instances, &Instance{JSONModelType: "instance", InstanceType: "digital_object", DigitalObject: &Ref{Ref: o.URI}})
+	}
+}
+
+// controlAccess links the names and subjects of a <controlaccess>
+func (x *Exporter) controlAccess(d *description, controlAccess *ead3.ControlAccess) {
+	for _, heading := range controlAccess.Headings() {
+		if _, ok := agentTypes[heading.Type]; ok == true {
+			x.linkAgent(d, heading, "subject")
+			continue
+		}
+		if _, ok := termTypes[heading.Type]; ok == false {
+			continue
+		}
+		subject := x.subject(heading)
+		if d.linked["subject\x00"+subject.URI] == false {
+			d.linked["subject\x00"+subject.URI] = true
+			d.Subjects = append(d.Subjects, &Ref{Ref: subject.URI})
+		}
+	}
+}
+
+// headingKey identifies an agent or subject across the record
+func headingKey(heading *ead3.Heading) string {
+	if heading.Identifier != "" {
+		return heading.Type + "\x00" + heading.Identifier
+	}
+	return heading.Type + "\x00" + strings.ToLower(heading.Value)
+}
+
+// linkAgent links the agent named by heading to the description with role
+func (x *Exporter) linkAgent(d *description, heading *ead3.Heading, role string) {
+	jsonModelType, ok := agentTypes[heading.Type]
+	if ok == false {
+		return
+	}
+	key := headingKey(heading)
+	agent, ok := x.agents[key]
+	if ok == false {
+		agent = &Agent{
+			JSONModelType: jsonModelType,
+			URI:           x.nextURI(agentPaths[heading.Type]),
+			Names:         []*AgentName{agentName(heading)},
+			Publish:       x.Publish,
+		}
+		x.agents[key] = agent
+		x.set.Agents = append(x.set.Agents, agent)
+	}
+	if d.linked[role+"\x00"+agent.URI] == true {
+		return
+	}
+	d.linked[role+"\x00"+agent.URI] = true
+	d.LinkedAgents = append(d.LinkedAgents, &LinkedAgent{Role: role, Ref: agent.URI})
+}
+
+// agentName builds the name form of an agent from the localtypes of the heading's parts,
+// names without typed parts keep the canonical heading as their primary name
+func agentName(heading *ead3.Heading) *AgentName {
+	name := &AgentName{
+		SortName:      heading.Value,
+		Source:        heading.Source,
+		Rules:         heading.Rules,
+		AuthorityID:   heading.Identifier,
+		Authorized:    true,
+		IsDisplayName: true,
+	}
+	parts := []*ead3.Part{}
+	switch e := heading.Element.(type) {
+	case *ead3.Persname:
+		name.JSONModelType = "name_person"
+		parts = e.Part
+	case *ead3.Famname:
+		name.JSONModelType = "name_family"
+		parts = e.Part
+	case *ead3.CorpName:
+		name.JSONModelType = "name_corporate_entity"
+		parts = e.Part
+	}
+	typed := false
+	for _, part := range parts {
+		value := strings.TrimSpace(part.Value)
+		switch part.LocalType {
+		case "surname", "primaryPart":
+			if name.JSONModelType == "name_family" {
+				name.FamilyName = value
+			} else {
+				name.PrimaryName = value
+			}
+		case "forename":
+			name.RestOfName = value
+		case "secondaryPart":
+			name.SubordinateName1 = value
+		case "tertiaryPart":
+			name.SubordinateName2 = value
+		case "fullerForm":
+			name.FullerForm = value
+		case "suffix":
+			name.Suffix = value
+		case "qualifier":
+			name.Qualifier = value
+		case "existDates", "dates":
+			name.Dates = value
+		default:
+			continue
+		}
+		typed = true
+	}
+	if typed == false {
+		if name.JSONModelType == "name_family" {
+			name.FamilyName = heading.Value
+		} else {
+			name.PrimaryName = heading.Value
+		}
+	}
+	if name.JSONModelType == "name_person" {
+		name.NameOrder = "direct"
+		if name.RestOfName != "" {
+			name.NameOrder = "inverted"
+		}
+	}
+	return name
+}
+
+// subject returns the subject for heading, adding it to the set when new
+func (x *Exporter) subject(heading *ead3.Heading) *Subject {
+	key := headingKey(heading)
+	if subject, ok := x.subjects[key]; ok == true {
+		return subject
+	}
+	subject := &Subject{
+		JSONModelType: "subject",
+		URI:           x.nextURI("/subjects"),
+		Source:        heading.Source,
+		AuthorityID:   heading.Identifier,
+		Vocabulary:    "/vocabularies/1",
+		Terms:         []*Term{},
+		Publish:       x.Publish,
+	}
+	parts := []*ead3.Part{}
+	switch e := heading.Element.(type) {
+	case *ead3.Subject:
+		parts = e.Part
+	case *ead3.GenreForm:
+		parts = e.Part
+	case *ead3.GeogName:
+		parts = e.Part
+	case *ead3.Occupation:
+		parts = e.Part
+	case *ead3.Function:
+		parts = e.Part
+	}
+	terms := []string{}
+	for i, part := range parts {
+		value := strings.TrimSpace(part.Value)
+		if value == "" {
+			continue
+		}
+		termType := termTypes[heading.Type]
+		if t, ok := partTermTypes[part.LocalType]; ok == true && i > 0 {
+			termType = t
+		}
+		subject.Terms = append(subject.Terms, &Term{JSONModelType: "term", Term: value, TermType: termType, Vocabulary: subject.Vocabulary})
+		terms = append(terms, value)
+	}
+	subject.Title = strings.Join(terms, " -- ")
+	if len(subject.Terms) == 0 {
+		subject.Title = heading.Value
+		subject.Terms = append(subject.Terms, &Term{JSONModelType: "term", Term: heading.Value, TermType: termTypes[heading.Type], Vocabulary: subject.Vocabulary})
+	}
+	x.subjects[key] = subject
+	x.set.Subjects = append(x.set.Subjects, subject)
+	return subject
+}
@@ -0,0 +1,572 @@
+//
+// import.go builds EAD3 records from ArchivesSpace JSONModel objects.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package aspace
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/caltechlibrary/ead3"
+)
+
+// importer resolves the refs of a set while building a record
+type importer struct {
+	set            *Set
+	topContainers  map[string]*TopContainer
+	agents         map[string]*Agent
+	subjects       map[string]*Subject
+	digitalObjects map[string]*DigitalObject
+}
+
+// Import builds an EAD3 record from a set of JSONModel objects, the reverse of Export.
+// Archival objects become <c> elements ordered by position, refs to objects missing
+// from the set are an error.
+func Import(set *Set) (*ead3.EAD3, error) {
+	if set == nil || set.Resource == nil {
+		return nil, fmt.Errorf("missing resource")
+	}
+	imp := &importer{
+		set:            set,
+		topContainers:  map[string]*TopContainer{},
+		agents:         map[string]*Agent{},
+		subjects:       map[string]*Subject{},
+		digitalObjects: map[string]*DigitalObject{},
+	}
+	for _, o := range set.TopContainers {
+		imp.topContainers[o.URI] = o
+	}
+	for _, o := range set.Agents {
+		imp.agents[o.URI] = o
+	}
+	for _, o := range set.Subjects {
+		imp.subjects[o.URI] = o
+	}
+	for _, o := range set.DigitalObjects {
+		imp.digitalObjects[o.URI] = o
+	}
+
+	resource := set.Resource
+	record := ead3.New()
+	record.Control = &ead3.Control{
+		RecordID: &ead3.RecordID{Value: resource.EADID},
+		FileDesc: &ead3.FileDesc{
+			TitleStmt: &ead3.TitleStmt{
+				TitleProper: &ead3.TitleProper{Value: resource.FindingAidTitle},
+			},
+		},
+	}
+	if resource.EADID == "" {
+		record.Control.RecordID.Value = resource.ID0
+	}
+	if resource.FindingAidTitle == "" {
+		record.Control.FileDesc.TitleStmt.TitleProper.Value = ead3.StripMarkup(resource.Title)
+	}
+	archDesc := &ead3.ArchDesc{Level: resource.Level}
+	did := &ead3.DID{
+		UnitTitle: &ead3.UnitTitle{Value: markup(resource.Title)},
+	}
+	if resource.ID0 != "" {
+		did.UnitID = &ead3.UnitID{Value: resource.ID0}
+	}
+	archDesc.DID = []*ead3.DID{did}
+	controlAccess := new(ead3.ControlAccess)
+	if err := imp.describe(did, controlAccess, resource.Dates, resource.Extents, resource.LinkedAgents, resource.Subjects, resource.Instances); err != nil {
+		return nil, err
+	}
+	for _, lm := range resource.LangMaterials {
+		if lm.LanguageAndScript != nil && did.LangMaterial == nil {
+			did.LangMaterial = &ead3.LangMaterial{Language: []*ead3.Language{{LangCode: lm.LanguageAndScript.Language}}}
+		}
+	}
+	imp.notes(did, archDesc, resource.Notes)
+	if len(controlAccess.Headings()) > 0 {
+		archDesc.ControlAccess = []*ead3.ControlAccess{controlAccess}
+	}
+
+	children := map[string][]*ArchivalObject{}
+	for _, o := range set.ArchivalObjects {
+		parent := ""
+		if o.Parent != nil {
+			parent = o.Parent.Ref
+		}
+		children[parent] = append(children[parent], o)
+	}
+	for _, siblings := range children {
+		sort.SliceStable(siblings, func(i, j int) bool {
+			return siblings[i].Position < siblings[j].Position
+		})
+	}
+	cs, err := imp.components(children, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(cs) > 0 {
+		archDesc.Dsc = &ead3.Dsc{C: cs}
+	}
+	record.ArchDesc = archDesc
+	return record, nil
+}
+
+// components builds the <c> elements for the archival objects under parent
+func (imp *importer) components(children map[string][]*ArchivalObject, parent string) ([]*ead3.C, error) {
+	cs := []*ead3.C{}
+	for _, o := range children[parent] {
+		c := &ead3.C{ID: o.RefID, Level: o.Level}
+		did := new(ead3.DID)
+		if o.Title != "" {
+			did.UnitTitle = &ead3.UnitTitle{Value: markup(o.Title)}
+		}
+		if o.ComponentID != "" {
+			did.UnitID = &ead3.UnitID{Value: o.ComponentID}
+		}
+		controlAccess := new(ead3.ControlAccess)
+		if err := imp.describe(did, controlAccess, o.Dates, o.Extents, o.LinkedAgents, o.Subjects, o.Instances); err != nil {
+			return nil, fmt.Errorf("%s, %s", o.URI, err)
+		}
+		c.DID = did
+		imp.notes(did, c, o.Notes)
+		if len(controlAccess.Headings()) > 0 {
+			c.ControlAccess = []*ead3.ControlAccess{controlAccess}
+		}
+		var err error
+		if c.C, err = imp.components(children, o.URI); err != nil {
+			return nil, err
+		}
+		cs = append(cs, c)
+	}
+	return cs, nil
+}
+
+// describe fills in the DID and controlled access shared by resources and archival objects
+func (imp *importer) describe(did *ead3.DID, controlAccess *ead3.ControlAccess, dates []*Date, extents []*Extent, linkedAgents []*LinkedAgent, subjects []*Ref, instances []*Instance) error {
+	for _, date := range dates {
+		if date.Expression != "" || date.Begin == "" {
+			did.UnitDate = append(did.UnitDate, importUnitDate(date))
+		} else {
+			did.UnitDateStructured = append(did.UnitDateStructured, importStructuredDate(date))
+		}
+	}
+	for _, extent := range extents {
+		p := &ead3.PhysDescStructured{
+			PhysDescStructuredType: "spaceoccupied",
+			Coverage:               extent.Portion,
+			Quantity:               &ead3.Quantity{Value: extent.Number},
+			UnitType:               &ead3.UnitType{Value: extent.ExtentType},
+		}
+		if extent.PhysicalDetails != "" {
+			p.PhysFacet = &ead3.PhysFacet{Value: extent.PhysicalDetails}
+		}
+		if extent.Dimensions != "" {
+			p.Dimensions = &ead3.Dimensions{Value: extent.Dimensions}
+		}
+		did.PhysDescStructured = append(did.PhysDescStructured, p)
+	}
+	for _, linked := range linkedAgents {
+		agent, ok := imp.agents[linked.Ref]
+		if ok == false {
+			return fmt.Errorf("agent %q not found", linked.Ref)
+		}
+		if linked.Role == "creator" {
+			if did.Origination == nil {
+				did.Origination = new(ead3.Origination)
+			}
+			addAgent(agent, &did.Origination.Persname, &did.Origination.Famname, &did.Origination.CorpName)
+		} else {
+			addAgent(agent, &controlAccess.Persname, &controlAccess.Famname, &controlAccess.CorpName)
+		}
+	}
+	for _, ref := range subjects {
+		subject, ok := imp.subjects[ref.Ref]
+		if ok == false {
+			return fmt.Errorf("subject %q not found", ref.Ref)
+		}
+		addSubject(subject, controlAccess)
+	}
+	for _, instance := range instances {
+		if instance.SubContainer != nil {
+			sub := instance.SubContainer
+			if sub.TopContainer == nil {
+				continue
+			}
+			tc, ok := imp.topContainers[sub.TopContainer.Ref]
+			if ok == false {
+				return fmt.Errorf("top container %q not found", sub.TopContainer.Ref)
+			}
+			did.Container = append(did.Container, &ead3.Container{LocalType: tc.Type, Value: tc.Indicator})
+			if sub.Indicator2 != "" {
+				did.Container = append(did.Container, &ead3.Container{LocalType: sub.Type2, Value: sub.Indicator2})
+			}
+			if sub.Indicator3 != "" {
+				did.Container = append(did.Container, &ead3.Container{LocalType: sub.Type3, Value: sub.Indicator3})
+			}
+		}
+		if instance.DigitalObject != nil {
+			o, ok := imp.digitalObjects[instance.DigitalObject.Ref]
+			if ok == false {
+				return fmt.Errorf("digital object %q not found", instance.DigitalObject.Ref)
+			}
+			did.DAO = append(did.DAO, importDAO(o, did))
+		}
+	}
+	return nil
+}
+
+// importDAO returns a DAO for the digital object's first file version, the title is
+// kept as a descriptive note when it is not the unit title
+func importDAO(o *DigitalObject, did *ead3.DID) *ead3.DAO {
+	dao := &ead3.DAO{HRef: o.DigitalObjectID}
+	if len(o.FileVersions) > 0 && o.FileVersions[0].FileURI != "" {
+		dao.HRef = o.FileVersions[0].FileURI
+	}
+	if o.DigitalObjectID != dao.HRef {
+		dao.Identifier = o.DigitalObjectID
+	}
+	title := ""
+	if did.UnitTitle != nil {
+		title = ead3.StripMarkup(did.UnitTitle.Value)
+	}
+	if o.Title != "" && o.Title != title && o.Title != dao.HRef {
+		dao.DescriptiveNote = &ead3.DescriptiveNote{P: []*ead3.P{{Value: markup(o.Title)}}}
+	}
+	return dao
+}
+
+// importCertainty returns the EAD3 certainty of an ArchivesSpace date
+func importCertainty(s string) string {
+	switch s {
+	case "approximate", "inferred", "questionable":
+		return s
+	}
+	return ""
+}
+
+// importUnitDate returns a <unitdate> for a date with an expression
+func importUnitDate(date *Date) *ead3.UnitDate {
+	u := &ead3.UnitDate{Value: date.Expression, Certainty: importCertainty(date.Certainty)}
+	if date.DateType == "bulk" || date.DateType == "inclusive" {
+		u.UnitDateType = date.DateType
+	}
+	if date.Begin != "" {
+		u.Normal = date.Begin
+		if date.DateType != "single" && date.End != "" {
+			u.Normal += "/" + date.End
+		}
+	}
+	if u.Value == "" {
+		u.Value = strings.Replace(u.Normal, "/", "-", 1)
+	}
+	return u
+}
+
+// importStructuredDate returns a <unitdatestructured> for a date given by begin and end
+func importStructuredDate(date *Date) *ead3.UnitDateStructured {
+	u := &ead3.UnitDateStructured{Certainty: importCertainty(date.Certainty)}
+	if date.DateType == "single" || date.End == "" {
+		u.DateSingle = &ead3.DateSingle{StandardDate: date.Begin, Value: date.Begin}
+		return u
+	}
+	u.UnitDateType = "inclusive"
+	if date.DateType == "bulk" {
+		u.UnitDateType = "bulk"
+	}
+	u.DateRange = []*ead3.DateRange{{
+		FromDate: &ead3.FromDate{StandardDate: date.Begin, Value: date.Begin},
+		ToDate:   &ead3.ToDate{StandardDate: date.End, Value: date.End},
+	}}
+	return u
+}
+
+// namePart appends a part of localType when value is not empty
+func namePart(parts []*ead3.Part, localType, value string) []*ead3.Part {
+	if value = strings.TrimSpace(value); value != "" {
+		parts = append(parts, &ead3.Part{LocalType: localType, Value: value})
+	}
+	return parts
+}
+
+// addAgent appends the agent's display name to the matching list of names
+func addAgent(agent *Agent, persnames *[]*ead3.Persname, famnames *[]*ead3.Famname, corpNames *[]*ead3.CorpName) {
+	if len(agent.Names) == 0 {
+		return
+	}
+	name := agent.Names[0]
+	for _, n := range agent.Names {
+		if n.IsDisplayName == true {
+			name = n
+			break
+		}
+	}
+	parts := []*ead3.Part{}
+	switch agent.JSONModelType {
+	case "agent_person":
+		if name.RestOfName == "" && name.Suffix == "" && name.FullerForm == "" && name.Dates == "" && name.Qualifier == "" {
+			parts = namePart(parts, "", name.PrimaryName)
+		} else {
+			parts = namePart(parts, "surname", name.PrimaryName)
+			parts = namePart(parts, "forename", name.RestOfName)
+			parts = namePart(parts, "fullerForm", name.FullerForm)
+			parts = namePart(parts, "suffix", name.Suffix)
+			parts = namePart(parts, "existDates", name.Dates)
+			parts = namePart(parts, "qualifier", name.Qualifier)
+		}
+		*persnames = append(*persnames, &ead3.Persname{Source: name.Source, Rules: name.Rules, Identifier: name.AuthorityID, Part: parts})
+	case "agent_family":
+		if name.Dates == "" && name.Qualifier == "" {
+			parts = namePart(parts, "", name.FamilyName)
+		} else {
+			parts = namePart(parts, "surname", name.FamilyName)
+			parts = namePart(parts, "existDates", name.Dates)
+			parts = namePart(parts, "qualifier", name.Qualifier)
+		}
+		*famnames = append(*famnames, &ead3.Famname{Source: name.Source, Rules: name.Rules, Identifier: name.AuthorityID, Part: parts})
+	case "agent_corporate_entity":
+		if name.SubordinateName1 == "" && name.SubordinateName2 == "" && name.Dates == "" && name.Qualifier == "" {
+			parts = namePart(parts, "", name.PrimaryName)
+		} else {
+			parts = namePart(parts, "primaryPart", name.PrimaryName)
+			parts = namePart(parts, "secondaryPart", name.SubordinateName1)
+			parts = namePart(parts, "tertiaryPart", name.SubordinateName2)
+			parts = namePart(parts, "existDates", name.Dates)
+			parts = namePart(parts, "qualifier", name.Qualifier)
+		}
+		*corpNames = append(*corpNames, &ead3.CorpName{Source: name.Source, Rules: name.Rules, Identifier: name.AuthorityID, Part: parts})
+	}
+}
+
+// addSubject appends the subject to the controlled access, the type of its first term
+// decides the element
+func addSubject(subject *Subject, controlAccess *ead3.ControlAccess) {
+	parts := []*ead3.Part{}
+	for i, term := range subject.Terms {
+		localType := term.TermType
+		if i == 0 {
+			localType = ""
+		}
+		parts = namePart(parts, localType, term.Term)
+	}
+	if len(parts) == 0 {
+		parts = namePart(parts, "", subject.Title)
+	}
+	termType := ""
+	if len(subject.Terms) > 0 {
+		termType = subject.Terms[0].TermType
+	}
+	switch termType {
+	case "geographic":
+		controlAccess.GeogName = append(controlAccess.GeogName, &ead3.GeogName{Source: subject.Source, Identifier: subject.AuthorityID, Part: parts})
+	case "genre_form":
+		controlAccess.GenreForm = append(controlAccess.GenreForm, &ead3.GenreForm{Source: subject.Source, Identifier: subject.AuthorityID, Part: parts})
+	case "occupation":
+		controlAccess.Occupation = append(controlAccess.Occupation, &ead3.Occupation{Source: subject.Source, Identifier: subject.AuthorityID, Part: parts})
+	case "function":
+		controlAccess.Function = append(controlAccess.Function, &ead3.Function{Source: subject.Source, Identifier: subject.AuthorityID, Part: parts})
+	default:
+		controlAccess.Subject = append(controlAccess.Subject, &ead3.Subject{Source: subject.Source, Identifier: subject.AuthorityID, Part: parts})
+	}
+}
+
+// markup returns s when it is well formed mixed content, otherwise s with its
+// special characters escaped
+func markup(s string) string {
+	probe := struct {
+		Value string `xml:",innerxml"`
+	}{}
+	if err := xml.Unmarshal([]byte("<x>"+s+"</x>"), &probe); err == nil {
+		return s
+	}
+	buf := new(bytes.Buffer)
+	xml.EscapeText(buf, []byte(s))
+	return buf.String()
+}
+
+// paragraphs splits note text on blank lines into <p> elements
+func paragraphs(text string) []*ead3.P {
+	ps := []*ead3.P{}
+	for _, s := range strings.Split(strings.Replace(text, "\r\n", "\n", -1), "\n\n") {
+		if s = strings.TrimSpace(s); s != "" {
+			ps = append(ps, &ead3.P{Value: markup(s)})
+		}
+	}
+	return ps
+}
+
+// notes adds the single part notes to the DID and the multipart notes to element
+// (an *ead3.ArchDesc or *ead3.C)
+func (imp *importer) notes(did *ead3.DID, element interface{}, notes []*Note) {
+	for _, note := range notes {
+		if note.JSONModelType == "note_singlepart" {
+			text := strings.Join(note.Content, "\n\n")
+			switch note.Type {
+			case "abstract":
+				did.Abstract = &ead3.Abstract{Label: note.Label, Value: text}
+				continue
+			case "physdesc":
+				did.PhysDesc = &ead3.PhysDesc{Label: note.Label, Value: text}
+				continue
+			case "physloc":
+				did.PhysLoc = &ead3.PhysLoc{Label: note.Label, Value: text}
+				continue
+			case "materialspec":
+				did.MaterialSpec = &ead3.MaterialSpec{Value: markup(text)}
+				continue
+			case "langmaterial":
+				if did.LangMaterial == nil {
+					did.LangMaterial = new(ead3.LangMaterial)
+				}
+				did.LangMaterial.DescriptiveNote = &ead3.DescriptiveNote{P: paragraphs(text)}
+				continue
+			}
+		}
+		if setNote(element, note) == false && did.DIDNote == nil {
+			// notes the element cannot hold are kept in the didnote
+			text := []string{}
+			for _, p := range noteParagraphs(note) {
+				text = append(text, ead3.StripMarkup(p.Value))
+			}
+			did.DIDNote = &ead3.DIDNote{Label: note.Label, Value: strings.Join(text, " ")}
+		}
+	}
+}
+
+// noteParagraphs returns the text of a note as paragraphs, list items and chronology
+// events included
+func noteParagraphs(note *Note) []*ead3.P {
+	ps := []*ead3.P{}
+	for _, s := range note.Content {
+		ps = append(ps, paragraphs(s)...)
+	}
+	for _, subNote := range note.SubNotes {
+		ps = append(ps, paragraphs(subNote.Content)...)
+		for _, item := range subNote.Items {
+			ps = append(ps, &ead3.P{Value: markup(item)})
+		}
+		for _, item := range subNote.ChronologyItems {
+			ps = append(ps, &ead3.P{Value: markup(strings.TrimSpace(item.EventDate + " " + strings.Join(item.Events, " ")))})
+		}
+	}
+	return ps
+}
+
+// setNote adds the note to the field of element whose XML name is the note's type,
+// filling in the note's id, head, paragraphs, list and chronology list where the note
+// element has them. It returns false if element has no such field.
+func setNote(element interface{}, note *Note) bool {
+	v := reflect.ValueOf(element).Elem()
+	t := v.Type()
+	var field reflect.Value
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("xml")
+		if j := strings.Index(tag, ","); j >= 0 {
+			tag = tag[0:j]
+		}
+		if tag == note.Type {
+			field = v.Field(i)
+			break
+		}
+	}
+	if field.IsValid() == false {
+		return false
+	}
+	var n reflect.Value
+	switch {
+	case field.Kind() == reflect.Ptr && field.IsNil() == false:
+		// a second note of the same type joins the first
+		n = field
+	case field.Kind() == reflect.Ptr:
+		n = reflect.New(field.Type().Elem())
+		field.Set(n)
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Ptr:
+		n = reflect.New(field.Type().Elem().Elem())
+		field.Set(reflect.Append(field, n))
+	default:
+		return false
+	}
+	n = n.Elem()
+	if f := n.FieldByName("ID"); f.IsValid() && f.Kind() == reflect.String && note.PersistentID != "" {
+		f.SetString(note.PersistentID)
+	}
+	if f := n.FieldByName("Head"); f.IsValid() && note.Label != "" {
+		switch f.Kind() {
+		case reflect.String:
+			f.SetString(markup(note.Label))
+		case reflect.Ptr:
+			f.Set(reflect.ValueOf(&ead3.Head{Value: markup(note.Label)}))
+		}
+	}
+	ps := []*ead3.P{}
+	for _, s := range note.Content {
+		ps = append(ps, paragraphs(s)...)
+	}
+	list := n.FieldByName("List")
+	chronList := n.FieldByName("ChronList")
+	for _, subNote := range note.SubNotes {
+		switch subNote.JSONModelType {
+		case "note_orderedlist":
+			if list.IsValid() && list.Type() == reflect.TypeOf([]*ead3.List{}) {
+				items := []string{}
+				for _, item := range subNote.Items {
+					items = append(items, "<item>"+markup(item)+"</item>")
+				}
+				l := &ead3.List{ListType: "unordered", Value: strings.Join(items, "")}
+				if subNote.Enumeration != "" {
+					l.ListType = "ordered"
+				}
+				list.Set(reflect.Append(list, reflect.ValueOf(l)))
+				continue
+			}
+		case "note_chronology":
+			if chronList.IsValid() && chronList.Type() == reflect.TypeOf([]*ead3.ChronList{}) {
+				cl := new(ead3.ChronList)
+				for _, item := range subNote.ChronologyItems {
+					cl.ChronItem = append(cl.ChronItem, &ead3.ChronItem{
+						DateSingle: &ead3.DateSingle{Value: item.EventDate},
+						Event:      &ead3.Event{Value: markup(strings.Join(item.Events, " "))},
+					})
+				}
+				chronList.Set(reflect.Append(chronList, reflect.ValueOf(cl)))
+				continue
+			}
+		}
+		ps = append(ps, noteParagraphs(&Note{SubNotes: []*SubNote{subNote}})...)
+	}
+	if f := n.FieldByName("P"); f.IsValid() && f.Type() == reflect.TypeOf(ps) {
+		f.Set(reflect.AppendSlice(f, reflect.ValueOf(ps)))
+	}
+	return true
+}
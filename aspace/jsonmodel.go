@@ -0,0 +1,453 @@
+// Package aspace converts EAD3 records to and from ArchivesSpace JSONModel objects
+// (resource, archival_object, top_container, agent, subject and digital_object) stored
+// as JSON files, so collections can move between the two without the ArchivesSpace
+// EAD importer.
+//
+// jsonmodel.go defines the ArchivesSpace JSONModel objects exchanged with EAD3 records.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+//   - Redistributions of source code must retain the above copyright notice, this
+//     list of conditions and the following disclaimer.
+//
+//   - Redistributions in binary form must reproduce the above copyright notice,
+//     this list of conditions and the following disclaimer in the documentation
+//     and/or other materials provided with the distribution.
+//
+//   - Neither the name of epgo nor the names of its
+//     contributors may be used to endorse or promote products derived from
+//     this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package aspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Ref points to another JSONModel object by its uri
+type Ref struct {
+	Ref string `json:"ref"`
+}
+
+// Date is an ArchivesSpace date, DateType is "single", "inclusive" or "bulk"
+type Date struct {
+	JSONModelType string `json:"jsonmodel_type"`
+	DateType      string `json:"date_type"`
+	Label         string `json:"label"`
+	Expression    string `json:"expression,omitempty"`
+	Begin         string `json:"begin,omitempty"`
+	End           string `json:"end,omitempty"`
+	Certainty     string `json:"certainty,omitempty"`
+}
+
+// Extent is a quantity of materials, Portion is "whole" or "part"
+type Extent struct {
+	JSONModelType    string `json:"jsonmodel_type"`
+	Portion          string `json:"portion"`
+	Number           string `json:"number"`
+	ExtentType       string `json:"extent_type"`
+	ContainerSummary string `json:"container_summary,omitempty"`
+	PhysicalDetails  string `json:"physical_details,omitempty"`
+	Dimensions       string `json:"dimensions,omitempty"`
+}
+
+// LanguageAndScript names a language by its ISO 639-2 code
+type LanguageAndScript struct {
+	JSONModelType string `json:"jsonmodel_type"`
+	Language      string `json:"language"`
+	Script        string `json:"script,omitempty"`
+}
+
+// LangMaterial is a language of the materials
+type LangMaterial struct {
+	JSONModelType     string             `json:"jsonmodel_type"`
+	LanguageAndScript *LanguageAndScript `json:"language_and_script,omitempty"`
+}
+
+// ChronologyItem is an event_date and its events
+type ChronologyItem struct {
+	EventDate string   `json:"event_date,omitempty"`
+	Events    []string `json:"events"`
+}
+
+// SubNote is part of a multipart note. JSONModelType is "note_text" (Content),
+// "note_chronology" (Title and ChronologyItems) or "note_orderedlist" (Title,
+// Enumeration and Items).
+type SubNote struct {
+	JSONModelType   string            `json:"jsonmodel_type"`
+	Content         string            `json:"content,omitempty"`
+	Title           string            `json:"title,omitempty"`
+	Enumeration     string            `json:"enumeration,omitempty"`
+	Items           []string          `json:"-"`
+	ChronologyItems []*ChronologyItem `json:"-"`
+	Publish         bool              `json:"publish"`
+}
+
+// subNoteJSON is the stored form of a SubNote, "items" holds strings in an ordered
+// list and objects in a chronology
+type subNoteJSON struct {
+	JSONModelType string          `json:"jsonmodel_type"`
+	Content       string          `json:"content,omitempty"`
+	Title         string          `json:"title,omitempty"`
+	Enumeration   string          `json:"enumeration,omitempty"`
+	Items         json.RawMessage `json:"items,omitempty"`
+	Publish       bool            `json:"publish"`
+}
+
+// MarshalJSON writes the items of a chronology or ordered list as "items"
+func (subNote *SubNote) MarshalJSON() ([]byte, error) {
+	out := &subNoteJSON{
+		JSONModelType: subNote.JSONModelType,
+		Content:       subNote.Content,
+		Title:         subNote.Title,
+		Enumeration:   subNote.Enumeration,
+		Publish:       subNote.Publish,
+	}
+	var (
+		items []byte
+		err   error
+	)
+	switch subNote.JSONModelType {
+	case "note_chronology":
+		items, err = json.Marshal(subNote.ChronologyItems)
+	case "note_orderedlist":
+		items, err = json.Marshal(subNote.Items)
+	}
+	if err != nil {
+		return nil, err
+	}
+	out.Items = items
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON reads "items" according to the jsonmodel_type of the subnote
+func (subNote *SubNote) UnmarshalJSON(src []byte) error {
+	in := new(subNoteJSON)
+	if err := json.Unmarshal(src, in); err != nil {
+		return err
+	}
+	subNote.JSONModelType = in.JSONModelType
+	subNote.Content = in.Content
+	subNote.Title = in.Title
+	subNote.Enumeration = in.Enumeration
+	subNote.Publish = in.Publish
+	if len(in.Items) > 0 {
+		switch in.JSONModelType {
+		case "note_chronology":
+			return json.Unmarshal(in.Items, &subNote.ChronologyItems)
+		case "note_orderedlist":
+			return json.Unmarshal(in.Items, &subNote.Items)
+		}
+	}
+	return nil
+}
+
+// Note is a note on a resource or archival object. JSONModelType is "note_multipart"
+// (SubNotes) or "note_singlepart" (Content), Type is the EAD element name of the note
+// (e.g. "bioghist", "abstract").
+type Note struct {
+	JSONModelType string     `json:"jsonmodel_type"`
+	Type          string     `json:"type"`
+	Label         string     `json:"label,omitempty"`
+	PersistentID  string     `json:"persistent_id,omitempty"`
+	Content       []string   `json:"content,omitempty"`
+	SubNotes      []*SubNote `json:"subnotes,omitempty"`
+	Publish       bool       `json:"publish"`
+}
+
+// SubContainer locates an instance within a top container
+type SubContainer struct {
+	JSONModelType string `json:"jsonmodel_type"`
+	TopContainer  *Ref   `json:"top_container"`
+	Type2         string `json:"type_2,omitempty"`
+	Indicator2    string `json:"indicator_2,omitempty"`
+	Type3         string `json:"type_3,omitempty"`
+	Indicator3    string `json:"indicator_3,omitempty"`
+}
+
+// Instance links a description to its container or digital object
+type Instance struct {
+	JSONModelType string        `json:"jsonmodel_type"`
+	InstanceType  string        `json:"instance_type"`
+	SubContainer  *SubContainer `json:"sub_container,omitempty"`
+	DigitalObject *Ref          `json:"digital_object,omitempty"`
+}
+
+// LinkedAgent links a description to an agent, Role is "creator" or "subject"
+type LinkedAgent struct {
+	Role    string `json:"role"`
+	Relator string `json:"relator,omitempty"`
+	Ref     string `json:"ref"`
+}
+
+// Resource is the collection level description
+type Resource struct {
+	JSONModelType   string          `json:"jsonmodel_type"`
+	URI             string          `json:"uri"`
+	Title           string          `json:"title"`
+	ID0             string          `json:"id_0"`
+	Level           string          `json:"level"`
+	EADID           string          `json:"ead_id,omitempty"`
+	FindingAidTitle string          `json:"finding_aid_title,omitempty"`
+	Repository      *Ref            `json:"repository,omitempty"`
+	Dates           []*Date         `json:"dates"`
+	Extents         []*Extent       `json:"extents"`
+	LangMaterials   []*LangMaterial `json:"lang_materials,omitempty"`
+	Notes           []*Note         `json:"notes"`
+	LinkedAgents    []*LinkedAgent  `json:"linked_agents"`
+	Subjects        []*Ref          `json:"subjects"`
+	Instances       []*Instance     `json:"instances"`
+	Publish         bool            `json:"publish"`
+}
+
+// ArchivalObject is a component of a resource, Parent is nil for the top level
+type ArchivalObject struct {
+	JSONModelType string         `json:"jsonmodel_type"`
+	URI           string         `json:"uri"`
+	RefID         string         `json:"ref_id"`
+	ComponentID   string         `json:"component_id,omitempty"`
+	Title         string         `json:"title,omitempty"`
+	Level         string         `json:"level"`
+	Resource      *Ref           `json:"resource"`
+	Parent        *Ref           `json:"parent,omitempty"`
+	Position      int            `json:"position"`
+	Dates         []*Date        `json:"dates"`
+	Extents       []*Extent      `json:"extents"`
+	Notes         []*Note        `json:"notes"`
+	LinkedAgents  []*LinkedAgent `json:"linked_agents"`
+	Subjects      []*Ref         `json:"subjects"`
+	Instances     []*Instance    `json:"instances"`
+	Publish       bool           `json:"publish"`
+}
+
+// TopContainer is a box or other container shelved on its own
+type TopContainer struct {
+	JSONModelType string `json:"jsonmodel_type"`
+	URI           string `json:"uri"`
+	Type          string `json:"type,omitempty"`
+	Indicator     string `json:"indicator"`
+	Barcode       string `json:"barcode,omitempty"`
+}
+
+// AgentName is a name form of an agent. JSONModelType is "name_person",
+// "name_family" or "name_corporate_entity".
+type AgentName struct {
+	JSONModelType    string `json:"jsonmodel_type"`
+	PrimaryName      string `json:"primary_name,omitempty"`
+	RestOfName       string `json:"rest_of_name,omitempty"`
+	FamilyName       string `json:"family_name,omitempty"`
+	SubordinateName1 string `json:"subordinate_name_1,omitempty"`
+	SubordinateName2 string `json:"subordinate_name_2,omitempty"`
+	FullerForm       string `json:"fuller_form,omitempty"`
+	Suffix           string `json:"suffix,omitempty"`
+	Qualifier        string `json:"qualifier,omitempty"`
+	Dates            string `json:"dates,omitempty"`
+	NameOrder        string `json:"name_order,omitempty"`
+	SortName         string `json:"sort_name"`
+	Source           string `json:"source,omitempty"`
+	Rules            string `json:"rules,omitempty"`
+	AuthorityID      string `json:"authority_id,omitempty"`
+	Authorized       bool   `json:"authorized"`
+	IsDisplayName    bool   `json:"is_display_name"`
+	SortNameAutoGen  bool   `json:"sort_name_auto_generate"`
+}
+
+// Agent is a person, family or corporate entity. JSONModelType is "agent_person",
+// "agent_family" or "agent_corporate_entity".
+type Agent struct {
+	JSONModelType string       `json:"jsonmodel_type"`
+	URI           string       `json:"uri"`
+	Names         []*AgentName `json:"names"`
+	Publish       bool         `json:"publish"`
+}
+
+// Term is one term of a subject, TermType is e.g. "topical", "geographic", "genre_form"
+type Term struct {
+	JSONModelType string `json:"jsonmodel_type"`
+	Term          string `json:"term"`
+	TermType      string `json:"term_type"`
+	Vocabulary    string `json:"vocabulary"`
+}
+
+// Subject is a subject heading made of terms
+type Subject struct {
+	JSONModelType string  `json:"jsonmodel_type"`
+	URI           string  `json:"uri"`
+	Title         string  `json:"title"`
+	Source        string  `json:"source,omitempty"`
+	AuthorityID   string  `json:"authority_id,omitempty"`
+	Vocabulary    string  `json:"vocabulary"`
+	Terms         []*Term `json:"terms"`
+	Publish       bool    `json:"publish"`
+}
+
+// FileVersion is a file making up a digital object
+type FileVersion struct {
+	JSONModelType string `json:"jsonmodel_type"`
+	FileURI       string `json:"file_uri"`
+	UseStatement  string `json:"use_statement,omitempty"`
+	Publish       bool   `json:"publish"`
+}
+
+// DigitalObject is a digital surrogate or born digital object
+type DigitalObject struct {
+	JSONModelType     string         `json:"jsonmodel_type"`
+	URI               string         `json:"uri"`
+	DigitalObjectID   string         `json:"digital_object_id"`
+	Title             string         `json:"title"`
+	DigitalObjectType string         `json:"digital_object_type,omitempty"`
+	FileVersions      []*FileVersion `json:"file_versions"`
+	Notes             []*Note        `json:"notes"`
+	Publish           bool           `json:"publish"`
+}
+
+// Set holds the JSONModel objects describing one resource
+type Set struct {
+	Resource        *Resource
+	ArchivalObjects []*ArchivalObject
+	TopContainers   []*TopContainer
+	Agents          []*Agent
+	Subjects        []*Subject
+	DigitalObjects  []*DigitalObject
+}
+
+// objects returns every object in the set along with its uri
+func (set *Set) objects() map[string]interface{} {
+	objects := map[string]interface{}{}
+	if set.Resource != nil {
+		objects[set.Resource.URI] = set.Resource
+	}
+	for _, o := range set.ArchivalObjects {
+		objects[o.URI] = o
+	}
+	for _, o := range set.TopContainers {
+		objects[o.URI] = o
+	}
+	for _, o := range set.Agents {
+		objects[o.URI] = o
+	}
+	for _, o := range set.Subjects {
+		objects[o.URI] = o
+	}
+	for _, o := range set.DigitalObjects {
+		objects[o.URI] = o
+	}
+	return objects
+}
+
+// WriteFiles writes each object to dir as its uri plus ".json", e.g.
+// dir/repositories/2/resources/1.json or dir/subjects/3.json
+func (set *Set) WriteFiles(dir string) error {
+	for uri, o := range set.objects() {
+		if uri == "" || strings.Contains(uri, "..") {
+			return fmt.Errorf("invalid uri %q", uri)
+		}
+		fname := path.Join(dir, uri+".json")
+		if err := os.MkdirAll(path.Dir(fname), 0775); err != nil {
+			return err
+		}
+		src, err := json.MarshalIndent(o, "", "    ")
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(fname, src, 0664); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadFiles reads the JSONModel objects written by WriteFiles (or exported from
+// ArchivesSpace) under dir, dir must hold a single resource
+func ReadFiles(dir string) (*Set, error) {
+	set := new(Set)
+	fnames := []string{}
+	err := filepath.Walk(dir, func(fname string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() == false && path.Ext(fname) == ".json" {
+			fnames = append(fnames, fname)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(fnames)
+	for _, fname := range fnames {
+		src, err := ioutil.ReadFile(fname)
+		if err != nil {
+			return nil, err
+		}
+		if err := set.add(src); err != nil {
+			return nil, fmt.Errorf("%s, %s", fname, err)
+		}
+	}
+	if set.Resource == nil {
+		return nil, fmt.Errorf("no resource found in %s", dir)
+	}
+	return set, nil
+}
+
+// add decodes a JSONModel object according to its jsonmodel_type and adds it to the set
+func (set *Set) add(src []byte) error {
+	header := struct {
+		JSONModelType string `json:"jsonmodel_type"`
+	}{}
+	if err := json.Unmarshal(src, &header); err != nil {
+		return err
+	}
+	switch header.JSONModelType {
+	case "resource":
+		if set.Resource != nil {
+			return fmt.Errorf("more than one resource")
+		}
+		set.Resource = new(Resource)
+		return json.Unmarshal(src, set.Resource)
+	case "archival_object":
+		o := new(ArchivalObject)
+		set.ArchivalObjects = append(set.ArchivalObjects, o)
+		return json.Unmarshal(src, o)
+	case "top_container":
+		o := new(TopContainer)
+		set.TopContainers = append(set.TopContainers, o)
+		return json.Unmarshal(src, o)
+	case "agent_person", "agent_family", "agent_corporate_entity":
+		o := new(Agent)
+		set.Agents = append(set.Agents, o)
+		return json.Unmarshal(src, o)
+	case "subject":
+		o := new(Subject)
+		set.Subjects = append(set.Subjects, o)
+		return json.Unmarshal(src, o)
+	case "digital_object":
+		o := new(DigitalObject)
+		set.DigitalObjects = append(set.DigitalObjects, o)
+		return json.Unmarshal(src, o)
+	}
+	return fmt.Errorf("unsupported jsonmodel_type %q", header.JSONModelType)
+}
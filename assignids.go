@@ -0,0 +1,132 @@
+//
+// assignids.go generates ids for components that lack them.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"strings"
+)
+
+// Strategies for AssignIDs
+const (
+	// IDSequential numbers components in document order, e.g. "c1", "c2"
+	IDSequential = "sequential"
+	// IDPath uses the position of the component in the Dsc tree, e.g. "c2.1.3"
+	IDPath = "path"
+	// IDHash derives the id from the component's content and its parent's id so it
+	// does not change when siblings are added, removed or reordered, e.g. "c5f0b1c9e2a47"
+	IDHash = "hash"
+)
+
+// IDPrefix starts the ids generated by AssignIDs
+var IDPrefix = "c"
+
+// IDCollision reports a component whose generated id was already in use, it was
+// given Assigned instead
+type IDCollision struct {
+	ID       string `json:"id"`
+	Assigned string `json:"assigned"`
+	Title    string `json:"title,omitempty"`
+}
+
+// contentKey is the text hashed by the IDHash strategy
+func contentKey(c *Component, parentID string) string {
+	did := c.DID()
+	parts := []string{parentID, c.Level(), normalizeSpace(c.Title())}
+	if did != nil {
+		parts = append(parts, diffDates(did)...)
+		if did.UnitID != nil {
+			parts = append(parts, normalizeSpace(did.UnitID.Value))
+		}
+	}
+	for _, container := range c.Containers() {
+		parts = append(parts, container.LocalType+" "+normalizeSpace(container.Value))
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// AssignIDs gives an id to every component without one using strategy (IDSequential,
+// IDPath or IDHash). Existing ids are never changed and generated ids never repeat an
+// id used anywhere in the record, a generated id already in use is reported as a
+// collision and made unique by a "-2", "-3", ... suffix (sequential ids skip numbers
+// in use instead). It returns the number of ids assigned.
+func AssignIDs(ead *EAD3, strategy string) (int, []IDCollision, error) {
+	collisions := []IDCollision{}
+	switch strategy {
+	case IDSequential, IDPath, IDHash:
+	default:
+		return 0, collisions, fmt.Errorf("unknown id strategy %q", strategy)
+	}
+	taken := map[string]bool{}
+	for _, e := range ead.IDs().Elements {
+		taken[e.ID] = true
+	}
+	assigned, n := 0, 0
+	var walk func(components []*Component, parentID, prefix string)
+	walk = func(components []*Component, parentID, prefix string) {
+		for i, c := range components {
+			position := fmt.Sprintf("%s%d", prefix, i+1)
+			if c.ID() == "" {
+				id := ""
+				switch strategy {
+				case IDSequential:
+					for id == "" || taken[id] == true {
+						n++
+						id = fmt.Sprintf("%s%d", IDPrefix, n)
+					}
+				case IDPath:
+					id = IDPrefix + position
+				case IDHash:
+					id = fmt.Sprintf("%s%x", IDPrefix, sha1.Sum([]byte(contentKey(c, parentID))))[0 : len(IDPrefix)+12]
+				}
+				if taken[id] == true {
+					unique := id
+					for i := 2; taken[unique] == true; i++ {
+						unique = fmt.Sprintf("%s-%d", id, i)
+					}
+					collisions = append(collisions, IDCollision{ID: id, Assigned: unique, Title: c.Title()})
+					id = unique
+				}
+				c.SetID(id)
+				taken[id] = true
+				assigned++
+			}
+			walk(c.Children, c.ID(), position+".")
+		}
+	}
+	walk(ead.Components(), "", "")
+	return assigned, collisions, nil
+}
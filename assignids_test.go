@@ -0,0 +1,95 @@
+//
+// assignids_test.go tests generating component ids.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"testing"
+)
+
+func TestAssignIDs(t *testing.T) {
+	fname := "testsamples/ead3/NCSU/mc00019.xml"
+	record := readTestRecord(t, fname)
+	if _, _, err := AssignIDs(record, "random"); err == nil {
+		t.Errorf("expected an error for an unknown strategy")
+	}
+	record.ArchDesc.Dsc.C[1].ID = "c2"
+	n, collisions, err := AssignIDs(record, IDSequential)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if n != 42 || len(collisions) != 0 {
+		t.Errorf("expected 42 ids without collisions, got %d and %+v", n, collisions)
+	}
+	dsc := record.ArchDesc.Dsc
+	if dsc.C[0].ID != "c1" || dsc.C[0].C[0].ID != "c3" || dsc.C[1].ID != "c2" {
+		t.Errorf("expected c1, c3 and the existing c2, got %s, %s and %s", dsc.C[0].ID, dsc.C[0].C[0].ID, dsc.C[1].ID)
+	}
+	if n, _, _ := AssignIDs(record, IDSequential); n != 0 {
+		t.Errorf("expected existing ids to be kept, got %d assigned", n)
+	}
+
+	record = readTestRecord(t, fname)
+	record.ArchDesc.Dsc.C[1].ID = "c1.1"
+	n, collisions, _ = AssignIDs(record, IDPath)
+	dsc = record.ArchDesc.Dsc
+	if n != 42 || dsc.C[2].ID != "c3" || dsc.C[2].C[1].ID != "c3.2" {
+		t.Errorf("expected path ids, got %s and %s", dsc.C[2].ID, dsc.C[2].C[1].ID)
+	}
+	if len(collisions) != 1 || collisions[0].ID != "c1.1" || collisions[0].Assigned != "c1.1-2" || dsc.C[0].C[0].ID != "c1.1-2" {
+		t.Errorf("expected c1.1 to collide, got %+v", collisions)
+	}
+
+	// hashed ids survive the insertion of a new series
+	a, b := readTestRecord(t, fname), readTestRecord(t, fname)
+	b.ArchDesc.Dsc.C = append([]*C{{DID: &DID{UnitTitle: &UnitTitle{Value: "Jane Doe"}}}}, b.ArchDesc.Dsc.C...)
+	if _, collisions, _ := AssignIDs(a, IDHash); len(collisions) != 0 {
+		t.Errorf("expected no collisions, got %+v", collisions)
+	}
+	AssignIDs(b, IDHash)
+	for i, c := range a.ArchDesc.Dsc.C {
+		other := b.ArchDesc.Dsc.C[i+1]
+		if c.ID != other.ID || c.ID == "" {
+			t.Errorf("expected %q to keep its id, got %q and %q", c.DID.UnitTitle.Value, c.ID, other.ID)
+		}
+		for j, child := range c.C {
+			if child.ID != other.C[j].ID {
+				t.Errorf("expected the children of %q to keep their ids", c.DID.UnitTitle.Value)
+			}
+		}
+	}
+	if changes := Diff(a, b); len(changes) != 1 || changes[0].Kind != ChangeAdded {
+		t.Errorf("expected only the added series, got %+v", changes)
+	}
+}
@@ -0,0 +1,160 @@
+//
+// audience.go filters a finding aid down to the elements meant for an audience.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// embeddedAudienceAttr matches the audience attribute of a tag in embedded XML
+var embeddedAudienceAttr = regexp.MustCompile(`\saudience\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+
+// FilterAudience returns a copy of ead holding only what is meant for audience (e.g.
+// "external"). Components, notes, control elements and embedded markup whose audience
+// attribute names another audience are removed along with everything inside them,
+// ead itself is left unchanged.
+func FilterAudience(ead *EAD3, audience string) (*EAD3, error) {
+	clone, err := cloneRecord(ead)
+	if err != nil {
+		return nil, err
+	}
+	filterAudience(reflect.ValueOf(clone), audience, nil)
+	return clone, nil
+}
+
+// excludedAudience returns true if v is an element whose audience attribute names an
+// audience other than audience
+func excludedAudience(v reflect.Value, audience string) bool {
+	if v.Kind() != reflect.Ptr || v.IsNil() == true || v.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	f := v.Elem().FieldByName("Audience")
+	return f.IsValid() && f.Kind() == reflect.String && f.String() != "" && f.String() != audience
+}
+
+// audienceVisitor is called with the element name and value of each element removed
+// by filterAudience, the value is nil for markup embedded in text
+type audienceVisitor func(name string, element interface{})
+
+func filterAudience(v reflect.Value, audience string, removed audienceVisitor) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() == false {
+			filterAudience(v.Elem(), audience, removed)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).PkgPath != "" {
+				continue
+			}
+			f := v.Field(i)
+			switch {
+			case f.Kind() == reflect.String && strings.HasSuffix(t.Field(i).Tag.Get("xml"), ",innerxml"):
+				f.SetString(stripAudience(f.String(), audience, removed))
+			case excludedAudience(f, audience):
+				if removed != nil {
+					removed(elementName(f.Type().Elem()), f.Interface())
+				}
+				f.Set(reflect.Zero(f.Type()))
+			case f.Kind() == reflect.Slice:
+				kept := reflect.MakeSlice(f.Type(), 0, f.Len())
+				for j := 0; j < f.Len(); j++ {
+					item := f.Index(j)
+					if excludedAudience(item, audience) == true {
+						if removed != nil {
+							removed(elementName(item.Type().Elem()), item.Interface())
+						}
+						continue
+					}
+					filterAudience(item, audience, removed)
+					kept = reflect.Append(kept, item)
+				}
+				if kept.Len() == 0 {
+					kept = reflect.Zero(f.Type())
+				}
+				f.Set(kept)
+			default:
+				filterAudience(f, audience, removed)
+			}
+		}
+	}
+}
+
+// stripAudience removes the elements embedded in src whose audience attribute names
+// an audience other than audience
+func stripAudience(src string, audience string, removed audienceVisitor) string {
+	if strings.Contains(src, "audience") == false {
+		return src
+	}
+	for _, loc := range embeddedTag.FindAllStringSubmatchIndex(src, -1) {
+		a := embeddedAudienceAttr.FindStringSubmatch(src[loc[4]:loc[5]])
+		if a == nil || a[1]+a[2] == audience || a[1]+a[2] == "" {
+			continue
+		}
+		end := loc[1]
+		if strings.HasSuffix(src[loc[6]:loc[7]], "/>") == false {
+			end = closingIndex(src, loc[1], src[loc[2]:loc[3]])
+		}
+		if removed != nil {
+			removed(src[loc[2]:loc[3]], nil)
+		}
+		// the tags that follow have moved, start over with what remains
+		return stripAudience(src[0:loc[0]]+src[end:], audience, removed)
+	}
+	return src
+}
+
+// closingIndex returns the index just past the end tag of the element named name
+// whose content starts at start, the end of src if the end tag is missing
+func closingIndex(src string, start int, name string) int {
+	tags := regexp.MustCompile(`<` + regexp.QuoteMeta(name) + `(?:\s[^>]*)?>|</` + regexp.QuoteMeta(name) + `\s*>`)
+	depth := 1
+	for _, loc := range tags.FindAllStringIndex(src[start:], -1) {
+		tag := src[start+loc[0] : start+loc[1]]
+		switch {
+		case strings.HasPrefix(tag, "</"):
+			depth--
+			if depth == 0 {
+				return start + loc[1]
+			}
+		case strings.HasSuffix(tag, "/>") == false:
+			depth++
+		}
+	}
+	return len(src)
+}
@@ -0,0 +1,95 @@
+//
+// audience_test.go tests filtering a finding aid by audience.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"strings"
+	"testing"
+)
+
+// countComponents returns the number of components at every level of record
+func countComponents(record *EAD3) int {
+	count := 0
+	record.Walk(func(c *Component) error {
+		count++
+		return nil
+	})
+	return count
+}
+
+func TestFilterAudience(t *testing.T) {
+	record := readTestRecord(t, "testsamples/ead3/NCSU/mc00019.xml")
+	count := countComponents(record)
+	series := record.ArchDesc.Dsc.C
+	series[0].Audience = "internal"
+	series[1].Audience = "external"
+	series[1].ScopeContent = append(series[1].ScopeContent, &ScopeContent{
+		P: []*P{{Value: `Interviews<emph audience="internal"> (see the <emph>donor</emph> file)</emph> on tape.<lb audience='internal'/>`}},
+	})
+	record.ArchDesc.ProcessInfo = append(record.ArchDesc.ProcessInfo, &ProcessInfo{Audience: "internal", P: []*P{{Value: "Staff only"}}})
+	events := record.Control.MaintenanceHistory.MaintenanceEvent
+	events[0].Audience = "internal"
+
+	filtered, err := FilterAudience(record, "external")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if n := countComponents(filtered); n != count-4 {
+		t.Errorf("expected the internal series and its 3 files to be removed, got %d of %d components", n, count)
+	}
+	if c := filtered.ArchDesc.Dsc.C[0]; c.Audience != "external" {
+		t.Errorf("expected the external series to be kept, got %q", c.Audience)
+	}
+	p := filtered.ArchDesc.Dsc.C[0].ScopeContent[len(filtered.ArchDesc.Dsc.C[0].ScopeContent)-1].P[0].Value
+	if p != "Interviews on tape." {
+		t.Errorf("expected the embedded internal markup to be removed, got %q", p)
+	}
+	for _, note := range filtered.ArchDesc.Notes() {
+		if strings.Contains(StripMarkup(note.P[0].Value), "Staff only") == true {
+			t.Errorf("expected the internal processinfo to be removed")
+		}
+	}
+	if n := len(filtered.Control.MaintenanceHistory.MaintenanceEvent); n != len(events)-1 {
+		t.Errorf("expected %d maintenance events, got %d", len(events)-1, n)
+	}
+
+	// the master record is unchanged
+	if countComponents(record) != count || record.ArchDesc.Dsc.C[0].Audience != "internal" {
+		t.Errorf("expected the record to be left unchanged")
+	}
+	if all, _ := FilterAudience(record, "internal"); countComponents(all) != count-len(series[1:2])-len(series[1].C) {
+		t.Errorf("expected the external series to be removed from the internal view, got %d", countComponents(all))
+	}
+}
@@ -0,0 +1,232 @@
+// Package authority reconciles the names and subjects of finding aids against local
+// snapshots of authority files such as LCNAF, LCSH, AAT and FAST.
+//
+// Authorities are loaded from N-Triples or MARC 21 authority dumps into a Store. A
+// Reconciler matches the controlled access and origination headings of a record against
+// the store, scoring each candidate, fills in the identifier and source of confident
+// matches and reports the ambiguous ones for review as CSV.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+//   - Redistributions of source code must retain the above copyright notice, this
+//     list of conditions and the following disclaimer.
+//
+//   - Redistributions in binary form must reproduce the above copyright notice,
+//     this list of conditions and the following disclaimer in the documentation
+//     and/or other materials provided with the distribution.
+//
+//   - Neither the name of epgo nor the names of its
+//     contributors may be used to endorse or promote products derived from
+//     this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package authority
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/caltechlibrary/ead3"
+)
+
+// Authority is an authorized heading from a vocabulary
+type Authority struct {
+	// URI identifies the authority, e.g. http://id.loc.gov/authorities/names/n79021164
+	URI string `json:"uri"`
+	// Label is the authorized form of the heading
+	Label string `json:"label"`
+	// Variants are the see from (alternate) forms of the heading
+	Variants []string `json:"variants,omitempty"`
+	// Source is the vocabulary the authority was loaded from, e.g. lcnaf, lcsh, aat or fast
+	Source string `json:"source"`
+	// Type is the EAD3 element the authority corresponds to (persname, famname, corpname,
+	// subject, geogname or genreform), empty if not known
+	Type string `json:"type,omitempty"`
+}
+
+// Store holds authorities indexed by their labels
+type Store struct {
+	Authorities []*Authority
+	// labels and variants map keys of the labels to authorities
+	labels   map[string][]*Authority
+	variants map[string][]*Authority
+	// loose maps loose keys (without dates) of labels and variants to authorities
+	loose map[string][]*Authority
+}
+
+// NewStore returns an empty Store
+func NewStore() *Store {
+	return &Store{
+		Authorities: []*Authority{},
+		labels:      map[string][]*Authority{},
+		variants:    map[string][]*Authority{},
+		loose:       map[string][]*Authority{},
+	}
+}
+
+// Key reduces a heading to a form ignoring case and punctuation, unlike ead3.LooseKey
+// dates are kept so people of the same name can be told apart
+func Key(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func appendOnce(list []*Authority, authority *Authority) []*Authority {
+	for _, a := range list {
+		if a == authority {
+			return list
+		}
+	}
+	return append(list, authority)
+}
+
+// Add adds an authority to the store
+func (store *Store) Add(authority *Authority) {
+	if authority == nil || authority.URI == "" || authority.Label == "" {
+		return
+	}
+	store.Authorities = append(store.Authorities, authority)
+	if key := Key(authority.Label); key != "" {
+		store.labels[key] = appendOnce(store.labels[key], authority)
+	}
+	if key := ead3.LooseKey(authority.Label); key != "" {
+		store.loose[key] = appendOnce(store.loose[key], authority)
+	}
+	for _, variant := range authority.Variants {
+		if key := Key(variant); key != "" {
+			store.variants[key] = appendOnce(store.variants[key], authority)
+		}
+		if key := ead3.LooseKey(variant); key != "" {
+			store.loose[key] = appendOnce(store.loose[key], authority)
+		}
+	}
+}
+
+// Len returns the number of authorities in the store
+func (store *Store) Len() int {
+	return len(store.Authorities)
+}
+
+// Match is a candidate authority for a heading
+type Match struct {
+	Authority *Authority `json:"authority"`
+	// Confidence is between 0 and 1
+	Confidence float64 `json:"confidence"`
+	// Reason describes how the heading matched, e.g. "label", "variant" or "label without dates"
+	Reason string `json:"reason"`
+}
+
+// Confidence of the kinds of match before adjusting for type and source
+const (
+	LabelConfidence        = 1.0
+	VariantConfidence      = 0.9
+	LooseLabelConfidence   = 0.7
+	LooseVariantConfidence = 0.6
+)
+
+// sourceAliases maps the source values used in finding aids to vocabulary names
+var sourceAliases = map[string]string{
+	"naf":    "lcnaf",
+	"lcnaf":  "lcnaf",
+	"lcsh":   "lcsh",
+	"aat":    "aat",
+	"fast":   "fast",
+	"lcgft":  "lcgft",
+	"gmgpc":  "gmgpc",
+	"ulan":   "ulan",
+	"tgn":    "tgn",
+	"local":  "local",
+	"ingest": "",
+}
+
+// normalSource returns the vocabulary name for a source attribute
+func normalSource(source string) string {
+	source = strings.ToLower(strings.TrimSpace(source))
+	if s, ok := sourceAliases[source]; ok == true {
+		return s
+	}
+	return source
+}
+
+// isName reports if the element type is a name
+func isName(t string) bool {
+	return t == "persname" || t == "famname" || t == "corpname" || t == "name"
+}
+
+// adjust lowers the confidence of a candidate whose type or vocabulary disagrees with
+// the heading
+func adjust(confidence float64, heading *ead3.Heading, authority *Authority) float64 {
+	if authority.Type != "" && authority.Type != heading.Type {
+		if isName(authority.Type) != isName(heading.Type) {
+			confidence *= 0.5
+		} else {
+			confidence *= 0.8
+		}
+	}
+	if source := normalSource(heading.Source); source != "" && source != "local" && source != normalSource(authority.Source) {
+		confidence *= 0.9
+	}
+	return confidence
+}
+
+// Match returns the candidate authorities for a heading, best first
+func (store *Store) Match(heading *ead3.Heading) []*Match {
+	best := map[*Authority]*Match{}
+	consider := func(authorities []*Authority, confidence float64, reason string) {
+		for _, authority := range authorities {
+			c := adjust(confidence, heading, authority)
+			if m, ok := best[authority]; ok == false || m.Confidence < c {
+				best[authority] = &Match{Authority: authority, Confidence: c, Reason: reason}
+			}
+		}
+	}
+	for _, value := range []string{heading.Term(), heading.Value} {
+		key := Key(value)
+		if key == "" {
+			continue
+		}
+		consider(store.labels[key], LabelConfidence, "label")
+		consider(store.variants[key], VariantConfidence, "variant")
+		if loose := ead3.LooseKey(value); loose != "" {
+			for _, authority := range store.loose[loose] {
+				if ead3.LooseKey(authority.Label) == loose {
+					consider([]*Authority{authority}, LooseLabelConfidence, "label without dates")
+				} else {
+					consider([]*Authority{authority}, LooseVariantConfidence, "variant without dates")
+				}
+			}
+		}
+	}
+	matches := []*Match{}
+	for _, m := range best {
+		matches = append(matches, m)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Confidence != matches[j].Confidence {
+			return matches[i].Confidence > matches[j].Confidence
+		}
+		return matches[i].Authority.URI < matches[j].Authority.URI
+	})
+	return matches
+}
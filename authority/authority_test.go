@@ -0,0 +1,217 @@
+//
+// authority_test.go tests loading authority dumps and reconciling headings.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package authority
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/caltechlibrary/ead3"
+)
+
+func readRecord(t *testing.T, fname string) *ead3.EAD3 {
+	src, err := ioutil.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	record := ead3.New()
+	if err := xml.Unmarshal(src, &record); err != nil {
+		t.Fatalf("%s, %s", fname, err)
+	}
+	return record
+}
+
+func testStore(t *testing.T) *Store {
+	store := NewStore()
+	fp, err := os.Open("../testsamples/authority/lcsh.nt")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer fp.Close()
+	if n, err := store.LoadNTriples(fp, "lcsh"); err != nil || n != 4 {
+		t.Fatalf("expected 4 lcsh authorities, got %d, %v", n, err)
+	}
+	fp, err = os.Open("../testsamples/authority/lcnaf.mrc")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer fp.Close()
+	if n, err := store.LoadMARC(fp, "lcnaf"); err != nil || n != 5 {
+		t.Fatalf("expected 5 lcnaf authorities, got %d, %v", n, err)
+	}
+	return store
+}
+
+func TestReadNTriples(t *testing.T) {
+	src, err := ioutil.ReadFile("../testsamples/authority/lcsh.nt")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	authorities, err := ReadNTriples(bytes.NewReader(src), "lcsh")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	byURI := map[string]*Authority{}
+	for _, authority := range authorities {
+		byURI[authority.URI] = authority
+	}
+	if a := byURI["http://id.loc.gov/authorities/subjects/sh85009430"]; a == nil || a.Label != "Audiotapes" || a.Type != "subject" || len(a.Variants) != 1 {
+		t.Errorf("unexpected authority %+v", a)
+	}
+	if a := byURI["http://id.loc.gov/authorities/subjects/sh85095225"]; a == nil || a.Label != "Oral history" {
+		t.Errorf("expected English label, got %+v", a)
+	}
+	if a := byURI["http://id.loc.gov/authorities/subjects/sh85015219"]; a == nil || a.Label != "Bogotá (Colombia)" || a.Type != "geogname" {
+		t.Errorf("unexpected authority %+v", a)
+	}
+	if _, err := ReadNTriples(strings.NewReader(`<http://example.org/a> <http://www.w3.org/2004/02/skos/core#prefLabel> "unterminated .`), "local"); err == nil {
+		t.Errorf("expected an error for an unterminated literal")
+	}
+	authorities, err = ReadNTriples(strings.NewReader(`<http://example.org/a> <http://www.w3.org/2004/02/skos/core#prefLabel> "Café \"Central\""^^<http://www.w3.org/2001/XMLSchema#string> .`), "local")
+	if err != nil || len(authorities) != 1 || authorities[0].Label != `Café "Central"` {
+		t.Errorf("unexpected escaped label %v, %v", authorities, err)
+	}
+}
+
+func TestReadMARC(t *testing.T) {
+	fp, err := os.Open("../testsamples/authority/lcnaf.mrc")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer fp.Close()
+	authorities, err := ReadMARC(fp, "lcnaf")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	expected := []struct {
+		uri, label, elementType string
+	}{
+		{"http://id.loc.gov/authorities/names/n90012345", "Serow, Robert C., 1947-", "persname"},
+		{"http://id.loc.gov/authorities/names/n80012345", "North Carolina State University. Libraries", "corpname"},
+		{"http://id.loc.gov/authorities/names/n2001012345", "Dahlstein, Anna, 1901-1980", "persname"},
+		{"http://id.loc.gov/authorities/names/n2005012345", "Dahlstein, Anna, 1950-", "persname"},
+		{"http://id.loc.gov/authorities/names/n79045652", "Havana (Cuba)", "geogname"},
+	}
+	if len(authorities) != len(expected) {
+		t.Fatalf("expected %d authorities, got %d", len(expected), len(authorities))
+	}
+	for i, e := range expected {
+		a := authorities[i]
+		if a.URI != e.uri || a.Label != e.label || a.Type != e.elementType {
+			t.Errorf("expected %+v, got %+v", e, a)
+		}
+	}
+	if len(authorities[0].Variants) != 1 || authorities[0].Variants[0] != "Serow, Bob, 1947-" {
+		t.Errorf("unexpected variants %v", authorities[0].Variants)
+	}
+	if _, err := ReadMARC(strings.NewReader("00010nz  a2200073n  4500"), "lcnaf"); err == nil {
+		t.Errorf("expected an error for a truncated record")
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	reconciler := NewReconciler(testStore(t))
+	record := readRecord(t, "../testsamples/ead3/NCSU/mc00019.xml")
+	results := reconciler.Reconcile(record)
+
+	byHeading := map[string]*Result{}
+	for _, result := range results {
+		byHeading[result.Location+" "+result.Heading.Value] = result
+	}
+	serow := byHeading["archdesc/controlaccess Serow, Robert C., 1947-"]
+	if serow == nil || serow.Applied == false || serow.Best().Confidence != 1 {
+		t.Fatalf("expected Serow to be applied, %+v", serow)
+	}
+	persname := serow.Heading.Element.(*ead3.Persname)
+	if persname.Identifier != "http://id.loc.gov/authorities/names/n90012345" || persname.Source != "naf" {
+		t.Errorf("unexpected identifier %q and source %q", persname.Identifier, persname.Source)
+	}
+	if r := byHeading["archdesc/did/origination North Carolina State University. Libraries"]; r == nil || r.Applied == false {
+		t.Errorf("expected origination to be applied, %+v", r)
+	}
+	audiotapes := byHeading["archdesc/controlaccess Audiotapes"]
+	if audiotapes == nil || audiotapes.Applied == false {
+		t.Fatalf("expected Audiotapes to be applied, %+v", audiotapes)
+	}
+	if subject := audiotapes.Heading.Element.(*ead3.Subject); subject.Source != "lcsh" {
+		t.Errorf("expected source lcsh, got %q", subject.Source)
+	}
+	if r := byHeading["archdesc/controlaccess Oral histories (document genres)"]; r == nil || r.Applied == false || r.Best().Reason != "variant" {
+		t.Errorf("expected variant match, %+v", r)
+	}
+	dahlstein := byHeading["archdesc/controlaccess Dahlstein, Anna"]
+	if dahlstein == nil || dahlstein.Applied == true || dahlstein.Ambiguous == false || len(dahlstein.Matches) != 2 {
+		t.Fatalf("expected Dahlstein to be ambiguous, %+v", dahlstein)
+	}
+	if dahlstein.Heading.Element.(*ead3.Persname).Identifier != "" {
+		t.Errorf("expected no identifier for an ambiguous heading")
+	}
+
+	buf := new(bytes.Buffer)
+	if err := WriteReviewCSV(buf, results); err != nil {
+		t.Fatalf("%s", err)
+	}
+	rows, err := csv.NewReader(buf).ReadAll()
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	candidates, noMatch := 0, 0
+	for _, row := range rows[1:] {
+		if row[0] != "mc00019" {
+			t.Errorf("unexpected recordid %q", row[0])
+		}
+		if row[3] == "Dahlstein, Anna" {
+			candidates++
+		}
+		if row[9] == "no match" {
+			noMatch++
+		}
+	}
+	if candidates != 2 || noMatch == 0 {
+		t.Errorf("expected two candidates and unmatched headings, got %d, %d", candidates, noMatch)
+	}
+
+	// Applied identifiers are skipped the second time around
+	for _, result := range reconciler.Reconcile(record) {
+		if result.Heading.Value == "Serow, Robert C., 1947-" {
+			t.Errorf("expected identified heading to be skipped")
+		}
+	}
+}
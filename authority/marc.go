@@ -0,0 +1,266 @@
+//
+// marc.go reads authorities from MARC 21 authority files (ISO 2709).
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package authority
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/caltechlibrary/ead3"
+)
+
+const (
+	fieldTerminator  = 0x1E
+	recordTerminator = 0x1D
+	subfieldDelim    = 0x1F
+)
+
+// URIPrefixes maps vocabularies to the prefix of their authority URIs, the record's
+// control number is appended to form the URI when the record doesn't give one in 024
+var URIPrefixes = map[string]string{
+	"lcnaf": "http://id.loc.gov/authorities/names/",
+	"lcsh":  "http://id.loc.gov/authorities/subjects/",
+	"lcgft": "http://id.loc.gov/authorities/genreForms/",
+	"fast":  "http://id.worldcat.org/fast/",
+	"aat":   "http://vocab.getty.edu/aat/",
+	"tgn":   "http://vocab.getty.edu/tgn/",
+	"ulan":  "http://vocab.getty.edu/ulan/",
+}
+
+// headingTags maps the heading tags of an authority record to EAD3 element names, the
+// see from tracings are the same tags plus 300
+var headingTags = map[string]string{
+	"100": "persname",
+	"110": "corpname",
+	"111": "corpname",
+	"130": "subject",
+	"148": "subject",
+	"150": "subject",
+	"151": "geogname",
+	"155": "genreform",
+}
+
+// subfieldTypes maps subfield codes to the part localtypes understood by ead3.CanonicalParts
+var subfieldTypes = map[byte]string{
+	'v': "genre_form",
+	'x': "topical",
+	'y': "chronological",
+	'z': "geographic",
+	'q': "fullerForm",
+}
+
+// marcField is a variable field of a MARC record
+type marcField struct {
+	Tag        string
+	Indicators string
+	// Value is the content of a control field
+	Value     string
+	Subfields [][2]string
+}
+
+// subfield returns the first value of the subfield code
+func (field *marcField) subfield(code string) string {
+	for _, sf := range field.Subfields {
+		if sf[0] == code {
+			return sf[1]
+		}
+	}
+	return ""
+}
+
+// heading builds the heading of a 1XX or 4XX field
+func (field *marcField) heading(elementType string) string {
+	parts := []*ead3.Part{}
+	for _, sf := range field.Subfields {
+		code := sf[0][0]
+		// Skip numeric control subfields, relator terms, relationship information and $w
+		if code < 'a' || code > 'z' || code == 'e' || code == 'i' || code == 'w' {
+			continue
+		}
+		localType := string(code)
+		if t, ok := subfieldTypes[code]; ok == true {
+			localType = t
+		} else if (elementType == "corpname" || elementType == "subject") && (code == 'b' || code == 't' || code == 'p' || code == 'k') {
+			localType = "secondaryPart"
+		}
+		parts = append(parts, &ead3.Part{LocalType: localType, Value: sf[1]})
+	}
+	switch elementType {
+	case "persname", "famname", "corpname":
+		return ead3.CanonicalParts(parts, ", ")
+	}
+	return ead3.CanonicalParts(parts, "--")
+}
+
+// parseMARC parses a single ISO 2709 record
+func parseMARC(record []byte) ([]*marcField, error) {
+	if len(record) < 24 {
+		return nil, fmt.Errorf("record too short")
+	}
+	base, err := strconv.Atoi(strings.TrimSpace(string(record[12:17])))
+	if err != nil || base < 25 || base > len(record) {
+		return nil, fmt.Errorf("bad base address of data %q", record[12:17])
+	}
+	fields := []*marcField{}
+	directory := record[24 : base-1]
+	for i := 0; i+12 <= len(directory); i += 12 {
+		entry := string(directory[i : i+12])
+		length, err1 := strconv.Atoi(entry[3:7])
+		start, err2 := strconv.Atoi(entry[7:12])
+		if err1 != nil || err2 != nil || base+start+length > len(record) {
+			return nil, fmt.Errorf("bad directory entry %q", entry)
+		}
+		data := strings.TrimRight(string(record[base+start:base+start+length]), string([]byte{fieldTerminator, recordTerminator}))
+		field := &marcField{Tag: entry[0:3]}
+		if field.Tag < "010" {
+			field.Value = data
+		} else {
+			if len(data) >= 2 {
+				field.Indicators, data = data[0:2], data[2:]
+			}
+			for _, sf := range strings.Split(data, string([]byte{subfieldDelim})) {
+				if len(sf) > 0 {
+					field.Subfields = append(field.Subfields, [2]string{sf[0:1], sf[1:]})
+				}
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// marcURI returns the URI of an authority record in vocabulary source
+func marcURI(fields []*marcField, source string) string {
+	controlNumber := ""
+	for _, field := range fields {
+		switch field.Tag {
+		case "024":
+			if uri := strings.TrimSpace(field.subfield("a")); strings.HasPrefix(uri, "http") && (field.subfield("2") == "uri" || field.subfield("2") == "") {
+				return uri
+			}
+		case "010":
+			if s := strings.Join(strings.Fields(field.subfield("a")), ""); s != "" {
+				controlNumber = s
+			}
+		case "001":
+			if controlNumber == "" {
+				controlNumber = strings.TrimSpace(field.Value)
+			}
+		}
+	}
+	if controlNumber == "" {
+		return ""
+	}
+	switch source {
+	case "fast", "aat", "tgn", "ulan":
+		// e.g. fst01204623 becomes 1204623
+		controlNumber = strings.TrimLeft(strings.TrimLeft(controlNumber, "abcdefghijklmnopqrstuvwxyz"), "0")
+	}
+	return URIPrefixes[source] + controlNumber
+}
+
+// marcAuthority builds an authority from the fields of a record
+func marcAuthority(fields []*marcField, source string) *Authority {
+	authority := &Authority{Source: source, URI: marcURI(fields, source)}
+	for _, field := range fields {
+		if elementType, ok := headingTags[field.Tag]; ok == true && authority.Label == "" {
+			if field.Tag == "100" && strings.HasPrefix(field.Indicators, "3") {
+				elementType = "famname"
+			}
+			authority.Type = elementType
+			authority.Label = field.heading(elementType)
+			continue
+		}
+		if len(field.Tag) == 3 && field.Tag[0] == '4' {
+			if elementType, ok := headingTags["1"+field.Tag[1:]]; ok == true {
+				if variant := field.heading(elementType); variant != "" {
+					authority.Variants = append(authority.Variants, variant)
+				}
+			}
+		}
+	}
+	if authority.URI == "" || authority.Label == "" {
+		return nil
+	}
+	return authority
+}
+
+// ReadMARC reads authorities from a file of MARC 21 authority records in ISO 2709
+// format. The 1XX field gives the label and type and the 4XX fields the variants. The
+// URI is taken from 024 if present, otherwise it is formed from the 010 or 001 control
+// number using URIPrefixes. Records are expected to be UTF-8 encoded.
+func ReadMARC(r io.Reader, source string) ([]*Authority, error) {
+	authorities := []*Authority{}
+	in := bufio.NewReader(r)
+	for n := 1; ; n++ {
+		record, err := in.ReadBytes(recordTerminator)
+		record = []byte(strings.TrimLeft(string(record), " \t\r\n"))
+		if len(record) > 0 {
+			if record[len(record)-1] != recordTerminator {
+				return nil, fmt.Errorf("record %d, missing record terminator", n)
+			}
+			fields, e := parseMARC(record)
+			if e != nil {
+				return nil, fmt.Errorf("record %d, %s", n, e)
+			}
+			if authority := marcAuthority(fields, source); authority != nil {
+				authorities = append(authorities, authority)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return authorities, nil
+}
+
+// LoadMARC reads a MARC authority file into the store returning the number of
+// authorities added
+func (store *Store) LoadMARC(r io.Reader, source string) (int, error) {
+	authorities, err := ReadMARC(r, source)
+	if err != nil {
+		return 0, err
+	}
+	for _, authority := range authorities {
+		store.Add(authority)
+	}
+	return len(authorities), nil
+}
@@ -0,0 +1,289 @@
+//
+// ntriples.go reads authorities from N-Triples dumps such as those of id.loc.gov and FAST.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package authority
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	skosNS    = "http://www.w3.org/2004/02/skos/core#"
+	madsNS    = "http://www.loc.gov/mads/rdf/v1#"
+	rdfType   = "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"
+	rdfsLabel = "http://www.w3.org/2000/01/rdf-schema#label"
+	gvpNS     = "http://vocab.getty.edu/ontology#"
+)
+
+// labelPredicates are the predicates giving the authorized label
+var labelPredicates = map[string]bool{
+	skosNS + "prefLabel":          true,
+	madsNS + "authoritativeLabel": true,
+	rdfsLabel:                     true,
+}
+
+// variantPredicates are the predicates giving variant labels
+var variantPredicates = map[string]bool{
+	skosNS + "altLabel":     true,
+	madsNS + "variantLabel": true,
+	skosNS + "hiddenLabel":  true,
+}
+
+// rdfTypes maps the classes of an authority to EAD3 element names
+var rdfTypes = map[string]string{
+	madsNS + "PersonalName":          "persname",
+	madsNS + "FamilyName":            "famname",
+	madsNS + "CorporateName":         "corpname",
+	madsNS + "ConferenceName":        "corpname",
+	madsNS + "Topic":                 "subject",
+	madsNS + "ComplexSubject":        "subject",
+	madsNS + "Temporal":              "subject",
+	madsNS + "Geographic":            "geogname",
+	madsNS + "GenreForm":             "genreform",
+	"http://schema.org/Person":       "persname",
+	"http://schema.org/Organization": "corpname",
+	"http://schema.org/Place":        "geogname",
+	gvpNS + "Concept":                "subject",
+	gvpNS + "PhysPlaceConcept":       "geogname",
+}
+
+// triple is a statement read from an N-Triples line, Literal is true when Object is
+// a literal rather than an IRI
+type triple struct {
+	Subject   string
+	Predicate string
+	Object    string
+	Literal   bool
+	Lang      string
+}
+
+// parseIRI reads an <iri> from the start of s returning the IRI and the rest of s
+func parseIRI(s string) (string, string, error) {
+	s = strings.TrimLeft(s, " \t")
+	if strings.HasPrefix(s, "_:") {
+		i := strings.IndexAny(s, " \t")
+		if i < 0 {
+			return "", "", fmt.Errorf("unterminated blank node")
+		}
+		return s[:i], s[i:], nil
+	}
+	if strings.HasPrefix(s, "<") == false {
+		return "", "", fmt.Errorf("expected IRI")
+	}
+	i := strings.Index(s, ">")
+	if i < 0 {
+		return "", "", fmt.Errorf("unterminated IRI")
+	}
+	return s[1:i], s[i+1:], nil
+}
+
+// parseLiteral reads a "literal" with its optional language tag or datatype from the
+// start of s
+func parseLiteral(s string) (string, string, string, error) {
+	var buf strings.Builder
+	i := 1
+	for ; i < len(s); i++ {
+		c := s[i]
+		if c == '"' {
+			break
+		}
+		if c != '\\' {
+			buf.WriteByte(c)
+			continue
+		}
+		i++
+		if i >= len(s) {
+			return "", "", "", fmt.Errorf("unterminated escape")
+		}
+		switch s[i] {
+		case 't':
+			buf.WriteByte('\t')
+		case 'n':
+			buf.WriteByte('\n')
+		case 'r':
+			buf.WriteByte('\r')
+		case 'b':
+			buf.WriteByte('\b')
+		case 'f':
+			buf.WriteByte('\f')
+		case 'u', 'U':
+			n := 4
+			if s[i] == 'U' {
+				n = 8
+			}
+			if i+n >= len(s) {
+				return "", "", "", fmt.Errorf("short unicode escape")
+			}
+			r, err := strconv.ParseUint(s[i+1:i+1+n], 16, 32)
+			if err != nil {
+				return "", "", "", fmt.Errorf("bad unicode escape, %s", err)
+			}
+			buf.WriteRune(rune(r))
+			i += n
+		default:
+			buf.WriteByte(s[i])
+		}
+	}
+	if i >= len(s) {
+		return "", "", "", fmt.Errorf("unterminated literal")
+	}
+	rest := s[i+1:]
+	lang := ""
+	if strings.HasPrefix(rest, "@") {
+		j := strings.IndexAny(rest, " \t.")
+		if j < 0 {
+			j = len(rest)
+		}
+		lang, rest = rest[1:j], rest[j:]
+	} else if strings.HasPrefix(rest, "^^") {
+		_, r, err := parseIRI(rest[2:])
+		if err != nil {
+			return "", "", "", err
+		}
+		rest = r
+	}
+	return buf.String(), lang, rest, nil
+}
+
+// parseTriple parses a line of N-Triples, it returns nil for blank and comment lines
+func parseTriple(line string) (*triple, error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil, nil
+	}
+	t := new(triple)
+	var err error
+	if t.Subject, line, err = parseIRI(line); err != nil {
+		return nil, err
+	}
+	if t.Predicate, line, err = parseIRI(line); err != nil {
+		return nil, err
+	}
+	line = strings.TrimLeft(line, " \t")
+	if strings.HasPrefix(line, `"`) {
+		t.Literal = true
+		if t.Object, t.Lang, line, err = parseLiteral(line); err != nil {
+			return nil, err
+		}
+	} else if t.Object, line, err = parseIRI(line); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(line) != "." {
+		return nil, fmt.Errorf("expected . at end of statement")
+	}
+	return t, nil
+}
+
+// englishOrPlain reports if a language tag should be used for labels
+func englishOrPlain(lang string) bool {
+	lang = strings.ToLower(lang)
+	return lang == "" || lang == "en" || strings.HasPrefix(lang, "en-")
+}
+
+// ReadNTriples reads authorities from an N-Triples dump. Each IRI subject with a
+// SKOS prefLabel, MADS authoritativeLabel or rdfs:label (untagged or English) becomes an
+// authority, SKOS altLabel and MADS variantLabel values become its variants and MADS,
+// schema.org and Getty classes set its type. Source names the vocabulary, e.g. lcsh.
+func ReadNTriples(r io.Reader, source string) ([]*Authority, error) {
+	authorities := map[string]*Authority{}
+	order := []string{}
+	get := func(uri string) *Authority {
+		authority, ok := authorities[uri]
+		if ok == false {
+			authority = &Authority{URI: uri, Source: source}
+			authorities[uri] = authority
+			order = append(order, uri)
+		}
+		return authority
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		t, err := parseTriple(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("line %d, %s", lineNo, err)
+		}
+		if t == nil || strings.HasPrefix(t.Subject, "_:") {
+			continue
+		}
+		switch {
+		case t.Literal && labelPredicates[t.Predicate] && englishOrPlain(t.Lang):
+			authority := get(t.Subject)
+			// Prefer SKOS and MADS labels to rdfs:label
+			if authority.Label == "" || t.Predicate != rdfsLabel {
+				authority.Label = strings.TrimSpace(t.Object)
+			}
+		case t.Literal && variantPredicates[t.Predicate]:
+			authority := get(t.Subject)
+			authority.Variants = append(authority.Variants, strings.TrimSpace(t.Object))
+		case t.Literal == false && t.Predicate == rdfType:
+			if elementType, ok := rdfTypes[t.Object]; ok == true {
+				authority := get(t.Subject)
+				// MADS may list several classes, keep the most specific name type
+				if authority.Type == "" || authority.Type == "subject" {
+					authority.Type = elementType
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	list := []*Authority{}
+	for _, uri := range order {
+		if authority := authorities[uri]; authority.Label != "" {
+			list = append(list, authority)
+		}
+	}
+	return list, nil
+}
+
+// LoadNTriples reads an N-Triples dump into the store returning the number of
+// authorities added
+func (store *Store) LoadNTriples(r io.Reader, source string) (int, error) {
+	authorities, err := ReadNTriples(r, source)
+	if err != nil {
+		return 0, err
+	}
+	for _, authority := range authorities {
+		store.Add(authority)
+	}
+	return len(authorities), nil
+}
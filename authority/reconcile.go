@@ -0,0 +1,195 @@
+//
+// reconcile.go matches the headings of finding aids against a Store.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package authority
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/caltechlibrary/ead3"
+)
+
+// DefaultThreshold is the confidence a match needs to be applied without review
+const DefaultThreshold = 0.9
+
+// Reconciler matches headings against a store of authorities
+type Reconciler struct {
+	Store *Store
+	// Threshold is the least confidence of a match that is applied to a heading
+	Threshold float64
+	// Overwrite replaces identifiers already present on headings
+	Overwrite bool
+	// DryRun reports matches without changing the records
+	DryRun bool
+}
+
+// NewReconciler returns a Reconciler for store using DefaultThreshold
+func NewReconciler(store *Store) *Reconciler {
+	return &Reconciler{Store: store, Threshold: DefaultThreshold}
+}
+
+// Result is the outcome of reconciling a heading
+type Result struct {
+	RecordID string `json:"recordid"`
+	// Location is where the heading occurs, e.g. "archdesc/controlaccess" or
+	// "c[ref12]/did/origination"
+	Location string        `json:"location"`
+	Heading  *ead3.Heading `json:"heading"`
+	Matches  []*Match      `json:"matches,omitempty"`
+	// Applied is true when the best match's URI was set on the heading
+	Applied bool `json:"applied"`
+	// Ambiguous is true when the heading needs review, either no match reached the
+	// threshold or several matches share the best confidence
+	Ambiguous bool `json:"ambiguous"`
+}
+
+// Best returns the best match or nil if there were none
+func (result *Result) Best() *Match {
+	if len(result.Matches) == 0 {
+		return nil
+	}
+	return result.Matches[0]
+}
+
+// reconcile matches a heading and applies the best match if it is unambiguous
+func (reconciler *Reconciler) reconcile(recordID, location string, heading *ead3.Heading) *Result {
+	result := &Result{RecordID: recordID, Location: location, Heading: heading}
+	if heading == nil || (heading.Identifier != "" && reconciler.Overwrite == false) {
+		return nil
+	}
+	result.Matches = reconciler.Store.Match(heading)
+	best := result.Best()
+	if best == nil {
+		return result
+	}
+	tied := len(result.Matches) > 1 && result.Matches[1].Confidence == best.Confidence
+	if best.Confidence < reconciler.Threshold || tied {
+		result.Ambiguous = true
+		return result
+	}
+	if reconciler.DryRun == false {
+		source := best.Authority.Source
+		// Keep a source such as "naf" that already names the vocabulary
+		if normalSource(heading.Source) == normalSource(source) {
+			source = ""
+		}
+		heading.SetIdentifier(best.Authority.URI, source)
+	}
+	result.Applied = true
+	return result
+}
+
+// componentLocation describes a component for a Result's Location
+func componentLocation(c *ead3.Component) string {
+	if id := c.ID(); id != "" {
+		return fmt.Sprintf("c[%s]", id)
+	}
+	titles := []string{}
+	for _, ancestor := range c.Ancestors() {
+		titles = append(titles, ancestor.Title())
+	}
+	titles = append(titles, c.Title())
+	return fmt.Sprintf("c[%s]", strings.Join(titles, " > "))
+}
+
+// Reconcile matches the controlled access and origination headings of the record
+// (collection and components). Headings that already have an identifier are skipped
+// unless Overwrite is set. A result is returned for each heading considered.
+func (reconciler *Reconciler) Reconcile(record *ead3.EAD3) []*Result {
+	results := []*Result{}
+	if record == nil || record.ArchDesc == nil {
+		return results
+	}
+	recordID := ""
+	if record.Control != nil && record.Control.RecordID != nil {
+		recordID = strings.TrimSpace(record.Control.RecordID.Value)
+	}
+	add := func(location string, headings []*ead3.Heading) {
+		for _, heading := range headings {
+			if result := reconciler.reconcile(recordID, location, heading); result != nil {
+				results = append(results, result)
+			}
+		}
+	}
+	for _, did := range record.ArchDesc.DID {
+		add("archdesc/did/origination", did.Origination.Headings())
+	}
+	for _, controlAccess := range record.ArchDesc.ControlAccess {
+		add("archdesc/controlaccess", controlAccess.Headings())
+	}
+	record.Walk(func(c *ead3.Component) error {
+		location := componentLocation(c)
+		if did := c.DID(); did != nil {
+			add(location+"/did/origination", did.Origination.Headings())
+		}
+		for _, controlAccess := range c.ControlAccess() {
+			add(location+"/controlaccess", controlAccess.Headings())
+		}
+		return nil
+	})
+	return results
+}
+
+// WriteReviewCSV writes the results that need review as CSV, one row per candidate (or
+// a single row without a candidate when nothing matched). The columns are recordid,
+// location, type, heading, source, candidate_uri, candidate_label, candidate_source,
+// confidence and reason. Reviewers can fill in the chosen URI offline.
+func WriteReviewCSV(w io.Writer, results []*Result) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"recordid", "location", "type", "heading", "source", "candidate_uri", "candidate_label", "candidate_source", "confidence", "reason"}); err != nil {
+		return err
+	}
+	for _, result := range results {
+		if result.Applied == true {
+			continue
+		}
+		row := []string{result.RecordID, result.Location, result.Heading.Type, result.Heading.Value, result.Heading.Source}
+		if len(result.Matches) == 0 {
+			if err := out.Write(append(row, "", "", "", "", "no match")); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, m := range result.Matches {
+			if err := out.Write(append(append([]string{}, row...), m.Authority.URI, m.Authority.Label, m.Authority.Source, fmt.Sprintf("%.2f", m.Confidence), m.Reason)); err != nil {
+				return err
+			}
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
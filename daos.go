@@ -0,0 +1,99 @@
+//
+// daos.go lists the digital archival objects of finding aids.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"strings"
+)
+
+// DigitalObject is an entry in the inventory of digital archival objects kept by
+// DigitalObjects
+type DigitalObject struct {
+	// RecordID is the recordid of the finding aid, see Aggregate
+	RecordID string `json:"recordid"`
+	// Path locates the component holding the dao from the top of the Dsc, e.g.
+	// "c[series1]/c[2.3]", empty for the ArchDesc
+	Path string `json:"path,omitempty"`
+	// ComponentID is the id of the component, empty for the ArchDesc or a component
+	// without an id
+	ComponentID string `json:"component_id,omitempty"`
+	// Title is the title of the component or, for the ArchDesc, the finding aid
+	Title      string `json:"title"`
+	HRef       string `json:"href"`
+	Identifier string `json:"identifier,omitempty"`
+	// Type is the daotype, or the otherdaotype, see DAO.Type
+	Type  string `json:"type,omitempty"`
+	Label string `json:"label,omitempty"`
+	DAO   *DAO   `json:"-"`
+}
+
+// DigitalObjects lists the digital archival objects of the records, those of the
+// collection followed by those of the components in document order
+func DigitalObjects(records []*EAD3) []*DigitalObject {
+	inventory := []*DigitalObject{}
+	add := func(recordID, path, componentID, title string, daos []*DAO) {
+		for _, dao := range daos {
+			inventory = append(inventory, &DigitalObject{
+				RecordID:    recordID,
+				Path:        path,
+				ComponentID: componentID,
+				Title:       title,
+				HRef:        strings.TrimSpace(dao.HRef),
+				Identifier:  strings.TrimSpace(dao.Identifier),
+				Type:        dao.Type(),
+				Label:       dao.Label(),
+				DAO:         dao,
+			})
+		}
+	}
+	for i, record := range records {
+		if record == nil || record.ArchDesc == nil {
+			continue
+		}
+		recordID := facetRecordID(i, record)
+		for _, did := range record.ArchDesc.DID {
+			add(recordID, "", "", findingAidTitle(record), did.DAOs())
+		}
+		_, list, byComponent := flattenComponents(record)
+		for _, dc := range list {
+			path := []string{}
+			for c := dc.Component; c != nil; c = c.Parent {
+				path = append([]string{byComponent[c].path()}, path...)
+			}
+			add(recordID, strings.Join(path, "/"), dc.ID(), dc.Title(), dc.DAOs())
+		}
+	}
+	return inventory
+}
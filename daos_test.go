@@ -0,0 +1,67 @@
+//
+// daos_test.go tests the inventory of digital archival objects.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDigitalObjects(t *testing.T) {
+	record := readTestRecord(t, "testsamples/ead3/S.0001_valid.xml")
+	inventory := DigitalObjects([]*EAD3{record})
+	if len(inventory) != 2 {
+		t.Fatalf("expected the 2 daos of the daoset, got %d", len(inventory))
+	}
+	first := inventory[0]
+	if first.RecordID != "S.0001" || first.Title != "Class Notes for Care of Magical Creatures" {
+		t.Errorf("expected the component's record and title, got %+v", first)
+	}
+	if first.HRef != "http://eadiva.com/wizlib-files/salamanders.jpg" || first.Type != "derived" {
+		t.Errorf("expected the dao's href and type, got %+v", first)
+	}
+	if first.Label != "Sample portion of a scroll on the care of salamanders" {
+		t.Errorf("expected the descriptive note as label, got %q", first.Label)
+	}
+	if parts := strings.Split(first.Path, "/"); len(parts) != 2 || strings.HasPrefix(parts[0], "c[") == false {
+		t.Errorf("expected the path of a file in a series, got %q", first.Path)
+	}
+
+	record.ArchDesc.DID[0].DAO = []*DAO{{HRef: "https://example.edu/finding-aid.pdf", Identifier: "ark:/12345/fa", DOAType: "otherdaotype", OtherDAOType: "pdf"}}
+	inventory = DigitalObjects([]*EAD3{record})
+	if len(inventory) != 3 || inventory[0].Path != "" || inventory[0].Identifier != "ark:/12345/fa" || inventory[0].Type != "pdf" {
+		t.Errorf("expected the collection's dao first, got %+v", inventory[0])
+	}
+}
@@ -0,0 +1,194 @@
+//
+// dates.go reduces unit dates to spans of years for filtering and faceting.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// YearRange is an inclusive span of years, a single year has From equal to To
+type YearRange struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+var (
+	// isoYear matches the year at the start of an ISO 8601 date such as 1917, 1917-05 or 1917-05-01
+	isoYear = regexp.MustCompile(`^[+-]?(\d{4})`)
+	// textYear matches years (and decades such as 1950s) in free text dates
+	textYear = regexp.MustCompile(`\b(\d{4})(s?)\b`)
+)
+
+// Overlaps reports if the span shares any years with from through to
+func (yr YearRange) Overlaps(from, to int) bool {
+	return yr.From <= to && yr.To >= from
+}
+
+// isoYearOf returns the year of an ISO 8601 date
+func isoYearOf(s string) (int, bool) {
+	m := isoYear.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, false
+	}
+	year, err := strconv.Atoi(m[1])
+	return year, err == nil
+}
+
+// ParseNormalDate converts a normalized date (e.g. the value of unitdate@normal such as
+// "1917/1955", "1998" or "1924-03-01/1924-07") into a YearRange
+func ParseNormalDate(s string) (YearRange, bool) {
+	parts := strings.SplitN(strings.TrimSpace(s), "/", 2)
+	from, ok := isoYearOf(parts[0])
+	if ok == false {
+		return YearRange{}, false
+	}
+	to := from
+	if len(parts) == 2 {
+		if year, ok := isoYearOf(parts[1]); ok == true {
+			to = year
+		}
+	}
+	if to < from {
+		from, to = to, from
+	}
+	return YearRange{From: from, To: to}, true
+}
+
+// ParseTextDate finds the span of the years mentioned in a free text date such as
+// "1917-1955", "circa 1920s" or "1945, 1950-1952"
+func ParseTextDate(s string) (YearRange, bool) {
+	yr := YearRange{}
+	found := false
+	for _, m := range textYear.FindAllStringSubmatch(s, -1) {
+		from, _ := strconv.Atoi(m[1])
+		to := from
+		if m[2] == "s" {
+			// NOTE: 1900s is taken to be the decade, not the century
+			to = from + 9
+		}
+		if found == false || from < yr.From {
+			yr.From = from
+		}
+		if found == false || to > yr.To {
+			yr.To = to
+		}
+		found = true
+	}
+	return yr, found
+}
+
+// firstYear returns the year of the first ISO 8601 date found in values
+func firstYear(values ...string) (int, bool) {
+	for _, s := range values {
+		if year, ok := isoYearOf(s); ok == true {
+			return year, true
+		}
+	}
+	return 0, false
+}
+
+// Years returns the span of years of a unitdate, the normalized value is used when
+// present otherwise years are read from the text
+func (unitDate *UnitDate) Years() (YearRange, bool) {
+	if unitDate.Normal != "" {
+		if yr, ok := ParseNormalDate(unitDate.Normal); ok == true {
+			return yr, true
+		}
+	}
+	return ParseTextDate(unitDate.Value)
+}
+
+// Years returns the spans of years described by a unitdatestructured
+func (structured *UnitDateStructured) Years() []YearRange {
+	years := []YearRange{}
+	for _, single := range structured.Singles() {
+		if year, ok := firstYear(single.StandardDate, single.Normal); ok == true {
+			years = append(years, YearRange{From: year, To: year})
+		} else if yr, ok := ParseTextDate(single.Value); ok == true {
+			years = append(years, yr)
+		}
+	}
+	for _, dateRange := range structured.Ranges() {
+		from, to := 0, 0
+		okFrom, okTo := false, false
+		if d := dateRange.FromDate; d != nil {
+			from, okFrom = firstYear(d.StandardDate, d.NotBefore, d.NotAfter)
+			if okFrom == false {
+				if yr, ok := ParseTextDate(d.Value); ok == true {
+					from, okFrom = yr.From, true
+				}
+			}
+		}
+		if d := dateRange.ToDate; d != nil {
+			to, okTo = firstYear(d.StandardDate, d.NotAfter, d.NotBefore)
+			if okTo == false {
+				if yr, ok := ParseTextDate(d.Value); ok == true {
+					to, okTo = yr.To, true
+				}
+			}
+		}
+		switch {
+		case okFrom && okTo:
+			if to < from {
+				from, to = to, from
+			}
+			years = append(years, YearRange{From: from, To: to})
+		case okFrom:
+			years = append(years, YearRange{From: from, To: from})
+		case okTo:
+			years = append(years, YearRange{From: to, To: to})
+		}
+	}
+	return years
+}
+
+// Years returns the spans of years covered by the unit dates of a DID
+func (did *DID) Years() []YearRange {
+	years := []YearRange{}
+	if did == nil {
+		return years
+	}
+	for _, unitDate := range did.UnitDate {
+		if yr, ok := unitDate.Years(); ok == true {
+			years = append(years, yr)
+		}
+	}
+	for _, structured := range did.UnitDateStructured {
+		years = append(years, structured.Years()...)
+	}
+	return years
+}
@@ -0,0 +1,88 @@
+//
+// dates_test.go tests reducing unit dates to spans of years.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"testing"
+)
+
+func TestParseDates(t *testing.T) {
+	normal := map[string]YearRange{
+		"1917/1955":          {1917, 1955},
+		"1998":               {1998, 1998},
+		"1924-03-01/1924-07": {1924, 1924},
+		"1955/1917":          {1917, 1955},
+	}
+	for s, expected := range normal {
+		if yr, ok := ParseNormalDate(s); ok == false || yr != expected {
+			t.Errorf("ParseNormalDate(%q), expected %v, got %v", s, expected, yr)
+		}
+	}
+	if _, ok := ParseNormalDate("undated"); ok == true {
+		t.Errorf("expected undated to fail")
+	}
+	text := map[string]YearRange{
+		"2003-2004":               {2003, 2004},
+		"circa 1920s":             {1920, 1929},
+		"1945, 1950-1952":         {1945, 1952},
+		"materials pre-date 0950": {950, 950},
+	}
+	for s, expected := range text {
+		if yr, ok := ParseTextDate(s); ok == false || yr != expected {
+			t.Errorf("ParseTextDate(%q), expected %v, got %v", s, expected, yr)
+		}
+	}
+	if _, ok := ParseTextDate("undated"); ok == true {
+		t.Errorf("expected undated to fail")
+	}
+}
+
+func TestDIDYears(t *testing.T) {
+	record := readTestRecord(t, "testsamples/ead3/S.0001_valid.xml")
+	years := record.ArchDesc.DID[0].Years()
+	if len(years) == 0 {
+		t.Fatalf("expected years for S.0001")
+	}
+	found := false
+	for _, yr := range years {
+		found = found || (yr.From == 950 && yr.To == 1100)
+	}
+	if found == false {
+		t.Errorf("expected 950-1100, got %v", years)
+	}
+	if yr := (YearRange{1900, 1950}); yr.Overlaps(1950, 1960) == false || yr.Overlaps(1951, 1960) == true {
+		t.Errorf("unexpected overlap for %v", yr)
+	}
+}
@@ -0,0 +1,778 @@
+//
+// diff.go compares two versions of a finding aid.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Kinds of Change
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeMoved    = "moved"
+	ChangeModified = "modified"
+)
+
+// Change is a difference between two versions of a finding aid. Path locates the
+// collection ("archdesc", "control") or component ("c[id]", or "c[1.2]" by position
+// for components without an id). Field is empty when the whole component was added,
+// removed or moved, otherwise it names the changed field or note (e.g. "did/unittitle",
+// "scopecontent") and Old and New hold its values.
+type Change struct {
+	Kind      string `json:"kind"`
+	Path      string `json:"path"`
+	Title     string `json:"title,omitempty"`
+	Field     string `json:"field,omitempty"`
+	Old       string `json:"old,omitempty"`
+	New       string `json:"new,omitempty"`
+	OldParent string `json:"old_parent,omitempty"`
+	NewParent string `json:"new_parent,omitempty"`
+}
+
+// diffField is a named value compared between versions
+type diffField struct {
+	Name  string
+	Value string
+}
+
+// normalizeSpace collapses runs of white space
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// didFields returns the compared fields of a DID, containers and daos are passed
+// separately as components may hold them outside the DID
+func didFields(did *DID, containers []*Container, daos []*DAO) []*diffField {
+	fields := []*diffField{}
+	add := func(name string, values ...string) {
+		s := []string{}
+		for _, v := range values {
+			if v = normalizeSpace(v); v != "" {
+				s = append(s, v)
+			}
+		}
+		if len(s) > 0 {
+			fields = append(fields, &diffField{Name: name, Value: strings.Join(s, "; ")})
+		}
+	}
+	if did != nil {
+		if did.UnitTitle != nil {
+			add("did/unittitle", did.UnitTitle.Value)
+		}
+		if did.UnitID != nil {
+			add("did/unitid", did.UnitID.Value)
+		}
+		add("did/unitdate", diffDates(did)...)
+		if did.PhysDesc != nil {
+			add("did/physdesc", did.PhysDesc.Value)
+		}
+		extents := []string{}
+		for _, p := range did.PhysDescStructured {
+			if p.Quantity != nil && p.UnitType != nil {
+				extents = append(extents, p.Quantity.Value+" "+p.UnitType.Value)
+			}
+		}
+		add("did/physdescstructured", extents...)
+		if did.Abstract != nil {
+			add("did/abstract", did.Abstract.Value)
+		}
+		if did.PhysLoc != nil {
+			add("did/physloc", did.PhysLoc.Value)
+		}
+		for _, heading := range did.Origination.Headings() {
+			add("did/origination", heading.Value)
+		}
+	}
+	labels := []string{}
+	for _, container := range containers {
+		labels = append(labels, strings.TrimSpace(container.LocalType+" "+container.Value))
+	}
+	add("did/container", labels...)
+	hrefs := []string{}
+	for _, dao := range daos {
+		hrefs = append(hrefs, dao.HRef)
+	}
+	add("did/dao", hrefs...)
+	return mergeFields(fields)
+}
+
+// diffDates returns the unit dates of a DID as text
+func diffDates(did *DID) []string {
+	values := []string{}
+	for _, unitDate := range did.UnitDate {
+		values = append(values, unitDate.Value)
+	}
+	for _, structured := range did.UnitDateStructured {
+		for _, single := range structured.Singles() {
+			values = append(values, single.Value+" "+single.StandardDate)
+		}
+		for _, dateRange := range structured.Ranges() {
+			from, to := "", ""
+			if dateRange.FromDate != nil {
+				from = strings.TrimSpace(dateRange.FromDate.Value + " " + dateRange.FromDate.StandardDate)
+			}
+			if dateRange.ToDate != nil {
+				to = strings.TrimSpace(dateRange.ToDate.Value + " " + dateRange.ToDate.StandardDate)
+			}
+			values = append(values, strings.Trim(from+"-"+to, "-"))
+		}
+	}
+	return values
+}
+
+// mergeFields joins the values of repeated field names in order of first appearance
+func mergeFields(fields []*diffField) []*diffField {
+	merged := []*diffField{}
+	byName := map[string]*diffField{}
+	for _, f := range fields {
+		if prev, ok := byName[f.Name]; ok == true {
+			prev.Value += "; " + f.Value
+			continue
+		}
+		byName[f.Name] = f
+		merged = append(merged, f)
+	}
+	return merged
+}
+
+// noteText returns the content of a note as a single string
+func noteText(note *Note) string {
+	s := []string{note.Head}
+	for _, p := range note.P {
+		s = append(s, p.Value)
+	}
+	for _, list := range note.List {
+		s = append(s, list.Value)
+	}
+	for _, chronList := range note.ChronList {
+		for _, item := range chronList.ChronItem {
+			s = append(s, item.Date())
+			for _, event := range item.Events() {
+				s = append(s, event.Value)
+			}
+		}
+	}
+	for _, table := range note.Table {
+		if table.TGroup == nil {
+			continue
+		}
+		rows := []*Row{}
+		if table.TGroup.THead != nil {
+			rows = append(rows, table.TGroup.THead.Row...)
+		}
+		if table.TGroup.TBody != nil {
+			rows = append(rows, table.TGroup.TBody.Row...)
+		}
+		for _, row := range rows {
+			for _, entry := range row.Entry {
+				s = append(s, entry.Value)
+			}
+		}
+	}
+	for _, blockQuote := range note.BlockQuote {
+		s = append(s, blockQuote.Value)
+	}
+	return normalizeSpace(strings.Join(s, " "))
+}
+
+// noteFields returns the notes as fields, repeated notes are numbered (e.g. "odd[2]")
+func noteFields(notes []*Note) []*diffField {
+	fields := []*diffField{}
+	seen := map[string]int{}
+	for _, note := range notes {
+		seen[note.Name]++
+		name := note.Name
+		if seen[note.Name] > 1 {
+			name = fmt.Sprintf("%s[%d]", note.Name, seen[note.Name])
+		}
+		if value := noteText(note); value != "" {
+			fields = append(fields, &diffField{Name: name, Value: value})
+		}
+	}
+	return fields
+}
+
+// controlAccessField returns the headings of the controlled access as one field
+func controlAccessField(controlAccess []*ControlAccess) []*diffField {
+	terms := []string{}
+	for _, ca := range controlAccess {
+		for _, heading := range ca.Headings() {
+			terms = append(terms, heading.Type+": "+heading.Term())
+		}
+	}
+	if len(terms) == 0 {
+		return []*diffField{}
+	}
+	sort.Strings(terms)
+	return []*diffField{{Name: "controlaccess", Value: strings.Join(terms, "; ")}}
+}
+
+// componentDiffFields returns the compared fields of a component
+func componentDiffFields(c *Component) []*diffField {
+	fields := []*diffField{}
+	if level := c.Level(); level != "" {
+		fields = append(fields, &diffField{Name: "level", Value: level})
+	}
+	fields = append(fields, didFields(c.DID(), c.Containers(), c.DAOs())...)
+	fields = append(fields, noteFields(c.Notes())...)
+	return append(fields, controlAccessField(c.ControlAccess())...)
+}
+
+// compareFields appends the field level changes between a and b to changes
+func compareFields(changes []Change, path, title string, a, b []*diffField) []Change {
+	old := map[string]string{}
+	for _, f := range a {
+		old[f.Name] = f.Value
+	}
+	seen := map[string]bool{}
+	for _, f := range b {
+		seen[f.Name] = true
+		prev, ok := old[f.Name]
+		switch {
+		case ok == false:
+			changes = append(changes, Change{Kind: ChangeAdded, Path: path, Title: title, Field: f.Name, New: f.Value})
+		case prev != f.Value:
+			changes = append(changes, Change{Kind: ChangeModified, Path: path, Title: title, Field: f.Name, Old: prev, New: f.Value})
+		}
+	}
+	for _, f := range a {
+		if seen[f.Name] == false {
+			changes = append(changes, Change{Kind: ChangeRemoved, Path: path, Title: title, Field: f.Name, Old: f.Value})
+		}
+	}
+	return changes
+}
+
+// diffComponent is a component with the details used to align it
+type diffComponent struct {
+	*Component
+	index    int
+	rank     int
+	position string
+	fields   []*diffField
+	title    string
+	dates    string
+	boxes    string
+	match    *diffComponent
+}
+
+// path returns the Path of the component in a Change
+func (dc *diffComponent) path() string {
+	if id := dc.ID(); id != "" {
+		return "c[" + id + "]"
+	}
+	return "c[" + dc.position + "]"
+}
+
+// flattenComponents returns the top level components of a record and lists all
+// of them in document order
+func flattenComponents(record *EAD3) ([]*Component, []*diffComponent, map[*Component]*diffComponent) {
+	list := []*diffComponent{}
+	byComponent := map[*Component]*diffComponent{}
+	var walk func(components []*Component, prefix string)
+	walk = func(components []*Component, prefix string) {
+		for i, c := range components {
+			dc := &diffComponent{Component: c, index: len(list), rank: i, position: fmt.Sprintf("%s%d", prefix, i+1)}
+			dc.fields = componentDiffFields(c)
+			dc.title = titleWords(c.Title())
+			for _, f := range dc.fields {
+				switch f.Name {
+				case "did/unitdate":
+					dc.dates = f.Value
+				case "did/container":
+					dc.boxes = f.Value
+				}
+			}
+			list = append(list, dc)
+			byComponent[c] = dc
+			walk(c.Children, dc.position+".")
+		}
+	}
+	top := record.Components()
+	walk(top, "")
+	return top, list, byComponent
+}
+
+// titleWords reduces a title to lower case words without punctuation
+func titleWords(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return unicode.IsLetter(r) == false && unicode.IsNumber(r) == false
+	}), " ")
+}
+
+// wordSet returns the distinct words of s
+func wordSet(s string) map[string]bool {
+	words := map[string]bool{}
+	for _, w := range strings.Fields(s) {
+		words[w] = true
+	}
+	return words
+}
+
+// similarity scores how alike two components are from their titles, dates,
+// containers and levels, between 0 and 1
+func similarity(a, b *diffComponent) float64 {
+	score := 0.0
+	wa, wb := wordSet(a.title), wordSet(b.title)
+	if len(wa) == 0 && len(wb) == 0 {
+		score += 0.5
+	} else {
+		common := 0
+		for w := range wa {
+			if wb[w] == true {
+				common++
+			}
+		}
+		score += 0.5 * float64(common) / float64(len(wa)+len(wb)-common)
+	}
+	same := func(x, y string, weight float64) float64 {
+		switch {
+		case x == y && x != "":
+			return weight
+		case x == y:
+			return weight / 2
+		}
+		return 0
+	}
+	score += same(a.dates, b.dates, 0.2)
+	score += same(a.boxes, b.boxes, 0.2)
+	score += same(a.Level(), b.Level(), 0.1)
+	return score
+}
+
+// siblingSimilarity adds to the similarity of two components under the same parent
+// for keeping their position among their siblings and for having similar children,
+// so a retitled component without an id still pairs with itself
+func siblingSimilarity(a, b *diffComponent, byA, byB map[*Component]*diffComponent) float64 {
+	score := similarity(a, b)
+	if a.rank == b.rank {
+		score += 0.1
+	}
+	if n := len(a.Children); n > 0 && len(b.Children) > 0 {
+		if len(b.Children) > n {
+			n = len(b.Children)
+		}
+		alike := 0
+		for _, y := range b.Children {
+			for _, x := range a.Children {
+				if similarity(byA[x], byB[y]) >= similarityThreshold {
+					alike++
+					break
+				}
+			}
+		}
+		score += 0.3 * float64(alike) / float64(n)
+	}
+	return score
+}
+
+// similarityThreshold is the least similarity for components without matching
+// ids to be treated as the same component
+const similarityThreshold = 0.7
+
+// alignComponents pairs the components of a with those of b, first by id and then by
+// similarity. Components of b are visited in document order and the children of the
+// counterpart of their parent are tried first, weighing their position and children,
+// so that identical components (e.g. a "Tape Log" in each file) stay with their parents.
+// Components without ids left over are paired with those at the same position.
+func alignComponents(topA []*Component, a []*diffComponent, byA map[*Component]*diffComponent, b []*diffComponent, byB map[*Component]*diffComponent) {
+	byID := map[string]*diffComponent{}
+	for _, dc := range a {
+		if id := dc.ID(); id != "" {
+			if _, ok := byID[id]; ok == false {
+				byID[id] = dc
+			}
+		}
+	}
+	for _, dc := range b {
+		if other, ok := byID[dc.ID()]; ok == true && other.match == nil {
+			dc.match, other.match = other, dc
+		}
+	}
+	signature := func(dc *diffComponent) string {
+		return dc.title + "\x00" + dc.dates + "\x00" + dc.boxes + "\x00" + dc.Level()
+	}
+	bySignature := map[string][]*diffComponent{}
+	for _, dc := range a {
+		if dc.match == nil {
+			bySignature[signature(dc)] = append(bySignature[signature(dc)], dc)
+		}
+	}
+	pair := func(x, y *diffComponent) {
+		x.match, y.match = y, x
+	}
+	for _, y := range b {
+		if y.match != nil {
+			continue
+		}
+		var siblings []*Component
+		switch {
+		case y.Parent == nil:
+			siblings = topA
+		case byB[y.Parent].match != nil:
+			siblings = byB[y.Parent].match.Children
+		}
+		var best *diffComponent
+		bestScore := 0.0
+		for _, sibling := range siblings {
+			x := byA[sibling]
+			if x.match != nil {
+				continue
+			}
+			if score := siblingSimilarity(x, y, byA, byB); score >= similarityThreshold && score > bestScore {
+				best, bestScore = x, score
+			}
+		}
+		if best == nil {
+			for _, x := range bySignature[signature(y)] {
+				if x.match == nil {
+					best = x
+					break
+				}
+			}
+		}
+		if best == nil {
+			for _, x := range a {
+				if x.match != nil {
+					continue
+				}
+				if score := similarity(x, y); score >= similarityThreshold && score > bestScore {
+					best, bestScore = x, score
+				}
+			}
+		}
+		if best != nil {
+			pair(best, y)
+		}
+	}
+	// an edited component without an id still pairs with the one at its position
+	for _, y := range b {
+		if y.match != nil || y.ID() != "" {
+			continue
+		}
+		var siblings []*Component
+		switch {
+		case y.Parent == nil:
+			siblings = topA
+		case byB[y.Parent].match != nil:
+			siblings = byB[y.Parent].match.Children
+		}
+		if y.rank < len(siblings) {
+			x := byA[siblings[y.rank]]
+			if x.match == nil && x.ID() == "" && x.Level() == y.Level() && reflect.TypeOf(x.Element) == reflect.TypeOf(y.Element) {
+				pair(x, y)
+			}
+		}
+	}
+}
+
+// matchedRank returns the position of dc among its siblings that have a match
+func matchedRank(dc *diffComponent, siblings []*Component, byComponent map[*Component]*diffComponent) int {
+	rank := 0
+	for _, sibling := range siblings {
+		s := byComponent[sibling]
+		if s == dc {
+			return rank
+		}
+		if s.match != nil {
+			rank++
+		}
+	}
+	return rank
+}
+
+// siblingsOf returns the components sharing the parent of c
+func siblingsOf(c *Component, top []*Component) []*Component {
+	if c.Parent == nil {
+		return top
+	}
+	return c.Parent.Children
+}
+
+// Diff compares two versions of a finding aid. Components are aligned by id, falling
+// back to the similarity of their titles, dates and containers, and reported as added,
+// removed or moved (to another parent or among their siblings). The fields and notes of
+// the control, the collection and the aligned components are compared for added,
+// removed and modified values.
+func Diff(a, b *EAD3) []Change {
+	changes := []Change{}
+	if a == nil {
+		a = New()
+	}
+	if b == nil {
+		b = New()
+	}
+	changes = compareFields(changes, "control", "", controlDiffFields(a), controlDiffFields(b))
+	changes = compareFields(changes, "archdesc", "", archDescDiffFields(a.ArchDesc), archDescDiffFields(b.ArchDesc))
+
+	topA, listA, byA := flattenComponents(a)
+	topB, listB, byB := flattenComponents(b)
+	alignComponents(topA, listA, byA, listB, byB)
+	parentPath := func(c *Component, byComponent map[*Component]*diffComponent) string {
+		if c.Parent == nil {
+			return "dsc"
+		}
+		return byComponent[c.Parent].path()
+	}
+	for _, dc := range listB {
+		title := dc.Title()
+		if dc.match == nil {
+			changes = append(changes, Change{Kind: ChangeAdded, Path: dc.path(), Title: title, NewParent: parentPath(dc.Component, byB)})
+			continue
+		}
+		other := dc.match
+		moved := false
+		switch {
+		case (dc.Parent == nil) != (other.Parent == nil):
+			moved = true
+		case dc.Parent != nil && byB[dc.Parent].match != byA[other.Parent]:
+			moved = true
+		default:
+			moved = matchedRank(dc, siblingsOf(dc.Component, topB), byB) != matchedRank(other, siblingsOf(other.Component, topA), byA)
+		}
+		if moved == true {
+			changes = append(changes, Change{
+				Kind:      ChangeMoved,
+				Path:      dc.path(),
+				Title:     title,
+				OldParent: parentPath(other.Component, byA),
+				NewParent: parentPath(dc.Component, byB),
+			})
+		}
+		changes = compareFields(changes, dc.path(), title, other.fields, dc.fields)
+	}
+	for _, dc := range listA {
+		if dc.match == nil {
+			changes = append(changes, Change{Kind: ChangeRemoved, Path: dc.path(), Title: dc.Title(), OldParent: parentPath(dc.Component, byA)})
+		}
+	}
+	return changes
+}
+
+// controlDiffFields returns the compared fields of the record's control
+func controlDiffFields(record *EAD3) []*diffField {
+	fields := []*diffField{}
+	if control := record.Control; control != nil {
+		if control.RecordID != nil {
+			fields = append(fields, &diffField{Name: "recordid", Value: normalizeSpace(control.RecordID.Value)})
+		}
+		if control.FileDesc != nil && control.FileDesc.TitleStmt != nil && control.FileDesc.TitleStmt.TitleProper != nil {
+			fields = append(fields, &diffField{Name: "titleproper", Value: normalizeSpace(control.FileDesc.TitleStmt.TitleProper.Value)})
+		}
+	}
+	return fields
+}
+
+// archDescDiffFields returns the compared fields of the collection
+func archDescDiffFields(archDesc *ArchDesc) []*diffField {
+	fields := []*diffField{}
+	if archDesc == nil {
+		return fields
+	}
+	if archDesc.Level != "" {
+		fields = append(fields, &diffField{Name: "level", Value: archDesc.Level})
+	}
+	for _, did := range archDesc.DID {
+		fields = append(fields, didFields(did, did.Container, did.DAOs())...)
+	}
+	fields = mergeFields(fields)
+	fields = append(fields, noteFields(archDesc.Notes())...)
+	return append(fields, controlAccessField(archDesc.ControlAccess)...)
+}
+
+// diffOp is a run of words kept (' '), removed ('-') or added ('+')
+type diffOp struct {
+	Op   byte
+	Text string
+}
+
+// maxWordDiff limits the size of the table used to diff the words of two values,
+// larger values are shown as replaced
+const maxWordDiff = 4000000
+
+// wordDiff returns the word level differences between old and new
+func wordDiff(old, new string) []*diffOp {
+	a, b := strings.Fields(old), strings.Fields(new)
+	if len(a)*len(b) > maxWordDiff {
+		return []*diffOp{{Op: '-', Text: old}, {Op: '+', Text: new}}
+	}
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	ops := []*diffOp{}
+	push := func(op byte, word string) {
+		if n := len(ops); n > 0 && ops[n-1].Op == op {
+			ops[n-1].Text += " " + word
+			return
+		}
+		ops = append(ops, &diffOp{Op: op, Text: word})
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			push(' ', a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			push('-', a[i])
+			i++
+		default:
+			push('+', b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		push('-', a[i])
+	}
+	for ; j < len(b); j++ {
+		push('+', b[j])
+	}
+	return ops
+}
+
+// describe returns the one line summary of a change
+func (change *Change) describe() string {
+	s := change.Kind + " " + change.Path
+	if change.Title != "" {
+		s += fmt.Sprintf(" %q", change.Title)
+	}
+	if change.Field != "" {
+		return s + " " + change.Field
+	}
+	if change.Kind == ChangeMoved {
+		return s + " from " + change.OldParent + " to " + change.NewParent
+	}
+	return s
+}
+
+// WriteDiffText writes the changes as text, one line per change followed by the
+// old value ("- ") and new value ("+ ") of changed fields
+func WriteDiffText(w io.Writer, changes []Change) error {
+	for _, change := range changes {
+		if _, err := fmt.Fprintln(w, change.describe()); err != nil {
+			return err
+		}
+		if change.Old != "" {
+			if _, err := fmt.Fprintf(w, "  - %s\n", change.Old); err != nil {
+				return err
+			}
+		}
+		if change.New != "" {
+			if _, err := fmt.Fprintf(w, "  + %s\n", change.New); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteDiffJSON writes the changes as a JSON array
+func WriteDiffJSON(w io.Writer, changes []Change) error {
+	src, err := encodeJSON(changes)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// diffHTML is the table written by WriteDiffHTML, each row has the class of its kind
+var diffHTML = template.Must(template.New("diff").Parse(`<table class="ead3-diff">
+<thead><tr><th>Change</th><th>Location</th><th>Field</th><th>Difference</th></tr></thead>
+<tbody>
+{{range .}}<tr class="{{.Kind}}"><td>{{.Kind}}</td><td>{{.Path}}{{with .Title}} <span class="title">{{.}}</span>{{end}}</td><td>{{.Field}}</td><td>{{.Difference}}</td></tr>
+{{end}}</tbody>
+</table>
+`))
+
+// diffRow is a Change with its words marked up for display
+type diffRow struct {
+	*Change
+	Difference template.HTML
+}
+
+// WriteDiffHTML writes the changes as an HTML table, removed words are marked with
+// <del> and added ones with <ins>
+func WriteDiffHTML(w io.Writer, changes []Change) error {
+	rows := []*diffRow{}
+	for i := range changes {
+		change := &changes[i]
+		buf := new(strings.Builder)
+		switch {
+		case change.Kind == ChangeMoved:
+			buf.WriteString(html.EscapeString("from " + change.OldParent + " to " + change.NewParent))
+		case change.Field == "":
+		default:
+			for i, op := range wordDiff(change.Old, change.New) {
+				if i > 0 {
+					buf.WriteString(" ")
+				}
+				text := html.EscapeString(op.Text)
+				switch op.Op {
+				case '-':
+					buf.WriteString("<del>" + text + "</del>")
+				case '+':
+					buf.WriteString("<ins>" + text + "</ins>")
+				default:
+					buf.WriteString(text)
+				}
+			}
+		}
+		rows = append(rows, &diffRow{Change: change, Difference: template.HTML(buf.String())})
+	}
+	return diffHTML.Execute(w, rows)
+}
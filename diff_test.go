@@ -0,0 +1,182 @@
+//
+// diff_test.go tests comparing versions of a finding aid.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// numberComponents gives every component an id from its document order
+func numberComponents(record *EAD3) {
+	i := 0
+	record.Walk(func(c *Component) error {
+		i++
+		c.SetID(fmt.Sprintf("c%d", i))
+		return nil
+	})
+}
+
+func findChange(changes []Change, kind, path, field string) *Change {
+	for i, change := range changes {
+		if change.Kind == kind && change.Path == path && change.Field == field {
+			return &changes[i]
+		}
+	}
+	return nil
+}
+
+func TestDiff(t *testing.T) {
+	fname := "testsamples/ead3/NCSU/mc00019.xml"
+	a, b := readTestRecord(t, fname), readTestRecord(t, fname)
+	numberComponents(a)
+	numberComponents(b)
+	if changes := Diff(a, b); len(changes) != 0 {
+		t.Fatalf("expected no changes, got %+v", changes)
+	}
+
+	dsc := b.ArchDesc.Dsc
+	b.ArchDesc.BiogHist[0].P[0].Value = strings.Replace(b.ArchDesc.BiogHist[0].P[0].Value, "2003 and 2004", "2003, 2004 and 2005", 1)
+	dsc.C[0].DID.UnitTitle.Value = "Harry Allen, Junior"
+	// Sion H. Harrington's tape log moves to Charles J. McCann
+	tapeLog := dsc.C[2].C[2]
+	dsc.C[2].C = dsc.C[2].C[0:2]
+	dsc.C[3].C = append(dsc.C[3].C, tapeLog)
+	// Leigh H. Hammond and the master tape are removed
+	dsc.C = append(dsc.C[0:1], dsc.C[2:]...)
+	dsc.C = append(dsc.C, &C{ID: "new1", DID: &DID{UnitTitle: &UnitTitle{Value: "Jane Doe"}}})
+
+	changes := Diff(a, b)
+	expected := []struct {
+		kind, path, field string
+	}{
+		{ChangeModified, "archdesc", "bioghist"},
+		{ChangeModified, "c[c1]", "did/unittitle"},
+		{ChangeRemoved, "c[c5]", ""},
+		{ChangeRemoved, "c[c6]", ""},
+		{ChangeMoved, "c[c10]", ""},
+		{ChangeAdded, "c[new1]", ""},
+	}
+	for _, e := range expected {
+		if findChange(changes, e.kind, e.path, e.field) == nil {
+			t.Errorf("expected %s %s %s", e.kind, e.path, e.field)
+		}
+	}
+	if len(changes) != len(expected) {
+		t.Errorf("expected %d changes, got %d", len(expected), len(changes))
+		WriteDiffText(&testWriter{t}, changes)
+	}
+	if change := findChange(changes, ChangeMoved, "c[c10]", ""); change != nil && (change.OldParent != "c[c7]" || change.NewParent != "c[c11]") {
+		t.Errorf("expected move from c[c7] to c[c11], got %+v", change)
+	}
+}
+
+// testWriter logs what is written to it
+type testWriter struct {
+	t *testing.T
+}
+
+func (w *testWriter) Write(p []byte) (int, error) {
+	w.t.Log(string(p))
+	return len(p), nil
+}
+
+func TestDiffSimilarity(t *testing.T) {
+	fname := "testsamples/ead3/NCSU/mc00019.xml"
+	a, b := readTestRecord(t, fname), readTestRecord(t, fname)
+	// without ids the components are aligned by title, date and container
+	b.ArchDesc.Dsc.C[0].DID.UnitTitle.Value = "Harry Allen Jr"
+	changes := Diff(a, b)
+	if len(changes) != 1 || findChange(changes, ChangeModified, "c[1]", "did/unittitle") == nil {
+		t.Errorf("expected a modified title, got %+v", changes)
+	}
+
+	// a retitle sharing fewer words keeps its position and children
+	b = readTestRecord(t, fname)
+	b.ArchDesc.Dsc.C[0].DID.UnitTitle.Value = "Harry Allen, Junior"
+	changes = Diff(a, b)
+	if len(changes) != 1 || findChange(changes, ChangeModified, "c[1]", "did/unittitle") == nil {
+		t.Errorf("expected a modified title, got %+v", changes)
+		WriteDiffText(&testWriter{t}, changes)
+	}
+
+	// table cells are compared with the note
+	fname = "testsamples/ead3/S.0001_valid.xml"
+	a, b = readTestRecord(t, fname), readTestRecord(t, fname)
+	b.ArchDesc.UseRestrict[0].Table[0].TGroup.TBody.Row[0].Entry[2].Value = "Archivist"
+	changes = Diff(a, b)
+	if change := findChange(changes, ChangeModified, "archdesc", "userestrict"); len(changes) != 1 || change == nil {
+		t.Errorf("expected a modified userestrict table, got %+v", changes)
+	}
+}
+
+func TestWriteDiff(t *testing.T) {
+	fname := "testsamples/ead3/NCSU/mc00019.xml"
+	a, b := readTestRecord(t, fname), readTestRecord(t, fname)
+	b.ArchDesc.ScopeContent[0].P[0].Value = strings.Replace(b.ArchDesc.ScopeContent[0].P[0].Value, "audiocassette tapes", "audiocassette <emph>tapes</emph>", 1)
+	b.ArchDesc.Dsc.C = b.ArchDesc.Dsc.C[1:]
+	changes := Diff(a, b)
+
+	buf := new(bytes.Buffer)
+	if err := WriteDiffText(buf, changes); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if strings.Contains(buf.String(), "modified archdesc scopecontent\n  - ") == false || strings.Contains(buf.String(), `removed c[1] "Harry Allen, Jr."`) == false {
+		t.Errorf("unexpected text diff\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := WriteDiffHTML(buf, changes); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if strings.Contains(buf.String(), "<del>tapes,</del> <ins>&lt;emph&gt;tapes&lt;/emph&gt;,</ins>") == false {
+		t.Errorf("expected marked up words in\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := WriteDiffJSON(buf, changes); err != nil {
+		t.Fatalf("%s", err)
+	}
+	decoded := []Change{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(decoded) != len(changes) || decoded[0].Kind != changes[0].Kind {
+		t.Errorf("expected %d changes from JSON, got %d", len(changes), len(decoded))
+	}
+}
@@ -0,0 +1,436 @@
+//
+// Package discovery flattens finding aids into search documents for component level
+// discovery systems such as ArcLight.
+//
+// One document is made for the collection and one for each component. A component's
+// document carries its own description along with the context it inherits, the ids and
+// titles of its parents, the collection title, the repository and the nearest access
+// restriction. Documents are written as a Solr JSON update or an Elasticsearch bulk
+// request so they can be loaded without this package talking to either service.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package discovery
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/caltechlibrary/ead3"
+)
+
+// Document is the search document for a collection or one of its components
+type Document struct {
+	ID          string `json:"id"`
+	RecordID    string `json:"ead_id"`
+	ComponentID string `json:"ref,omitempty"`
+	// Level is the archdesc or component level, e.g. collection, series, file
+	Level string `json:"level,omitempty"`
+	// Component is false for the collection document
+	Component bool   `json:"component"`
+	Depth     int    `json:"depth"`
+	Title     string `json:"title,omitempty"`
+	UnitID    string `json:"unitid,omitempty"`
+	// Dates are the unit dates as written
+	Dates []string `json:"dates,omitempty"`
+	// Years lists every year covered by the normalized unit dates
+	Years      []int    `json:"years,omitempty"`
+	Extent     []string `json:"extent,omitempty"`
+	Abstract   string   `json:"abstract,omitempty"`
+	Creators   []string `json:"creators,omitempty"`
+	Names      []string `json:"names,omitempty"`
+	Subjects   []string `json:"subjects,omitempty"`
+	Places     []string `json:"places,omitempty"`
+	GenreForms []string `json:"genreforms,omitempty"`
+	Containers []string `json:"containers,omitempty"`
+	// Notes holds the text of the narrative notes other than the access and use restrictions
+	Notes []string `json:"notes,omitempty"`
+
+	// AccessRestrict is the component's own conditions governing access or, if it has
+	// none, those of its nearest parent or the collection
+	AccessRestrict []string `json:"accessrestrict,omitempty"`
+	// AccessRestrictInherited is true when AccessRestrict came from a parent
+	AccessRestrictInherited bool     `json:"accessrestrict_inherited,omitempty"`
+	UseRestrict             []string `json:"userestrict,omitempty"`
+	UseRestrictInherited    bool     `json:"userestrict_inherited,omitempty"`
+
+	// ParentIDs are the document ids of the collection and the containing components,
+	// outermost first, ParentTitles are their titles in the same order
+	ParentIDs         []string `json:"parent_ids,omitempty"`
+	ParentTitles      []string `json:"parent_titles,omitempty"`
+	CollectionID      string   `json:"collection_id,omitempty"`
+	CollectionTitle   string   `json:"collection_title,omitempty"`
+	Repository        string   `json:"repository,omitempty"`
+	HasDigitalObjects bool     `json:"has_digital_objects,omitempty"`
+}
+
+// RecordID returns the record's recordid
+func RecordID(record *ead3.EAD3) string {
+	if record.Control != nil && record.Control.RecordID != nil {
+		return strings.TrimSpace(record.Control.RecordID.Value)
+	}
+	return ""
+}
+
+// appendText appends the plain text of values skipping empty ones
+func appendText(list []string, values ...string) []string {
+	for _, value := range values {
+		if s := ead3.StripMarkup(value); s != "" {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+// noteText returns the plain text of a note's paragraphs, lists and quotations
+func noteText(note *ead3.Note) []string {
+	text := []string{}
+	for _, p := range note.P {
+		text = appendText(text, p.Value)
+	}
+	for _, list := range note.List {
+		text = appendText(text, list.Value)
+	}
+	for _, chronList := range note.ChronList {
+		for _, item := range chronList.ChronItem {
+			s := ead3.StripMarkup(item.Date())
+			for _, event := range item.Events() {
+				s = strings.TrimSpace(s + " " + ead3.StripMarkup(event.Value))
+			}
+			text = appendText(text, s)
+		}
+	}
+	for _, blockQuote := range note.BlockQuote {
+		text = appendText(text, blockQuote.Value)
+	}
+	return text
+}
+
+// addNotes adds the text of notes to doc, returning the access and use restrictions
+func (doc *Document) addNotes(notes []*ead3.Note) {
+	for _, note := range notes {
+		switch note.Name {
+		case "accessrestrict":
+			doc.AccessRestrict = append(doc.AccessRestrict, noteText(note)...)
+		case "userestrict":
+			doc.UseRestrict = append(doc.UseRestrict, noteText(note)...)
+		default:
+			doc.Notes = append(doc.Notes, noteText(note)...)
+		}
+	}
+}
+
+func (doc *Document) addOrigination(origination *ead3.Origination) {
+	for _, heading := range origination.Headings() {
+		doc.Creators = appendText(doc.Creators, heading.Value)
+	}
+}
+
+func (doc *Document) addControlAccess(controlAccess *ead3.ControlAccess) {
+	for _, heading := range controlAccess.Headings() {
+		switch heading.Type {
+		case "persname", "famname", "corpname", "name":
+			doc.Names = appendText(doc.Names, heading.Value)
+		case "geogname":
+			doc.Places = appendText(doc.Places, heading.Value)
+		case "genreform":
+			doc.GenreForms = appendText(doc.GenreForms, heading.Value)
+		default:
+			doc.Subjects = appendText(doc.Subjects, heading.Value)
+		}
+	}
+}
+
+// ContainerLabel returns a label for a container, e.g. "Box 3"
+func ContainerLabel(container *ead3.Container) string {
+	value := strings.TrimSpace(container.Value)
+	localType := strings.TrimSpace(container.LocalType)
+	if localType == "" {
+		return value
+	}
+	return strings.ToUpper(localType[0:1]) + localType[1:] + " " + value
+}
+
+func (doc *Document) addDID(did *ead3.DID) {
+	if did == nil {
+		return
+	}
+	if doc.Title == "" && did.UnitTitle != nil {
+		doc.Title = ead3.StripMarkup(did.UnitTitle.Value)
+	}
+	if doc.UnitID == "" && did.UnitID != nil {
+		doc.UnitID = strings.TrimSpace(did.UnitID.Value)
+	}
+	for _, unitDate := range did.UnitDate {
+		doc.Dates = appendText(doc.Dates, unitDate.Value)
+	}
+	years := map[int]bool{}
+	for _, year := range doc.Years {
+		years[year] = true
+	}
+	for _, yr := range did.Years() {
+		for year := yr.From; year <= yr.To; year++ {
+			years[year] = true
+		}
+	}
+	doc.Years = doc.Years[:0]
+	for year := range years {
+		doc.Years = append(doc.Years, year)
+	}
+	sort.Ints(doc.Years)
+	if did.PhysDesc != nil {
+		doc.Extent = appendText(doc.Extent, did.PhysDesc.Value)
+	}
+	for _, physDesc := range did.PhysDescStructured {
+		extent := []string{}
+		if physDesc.Quantity != nil {
+			extent = append(extent, physDesc.Quantity.Value)
+		}
+		if physDesc.UnitType != nil {
+			extent = append(extent, physDesc.UnitType.Value)
+		}
+		doc.Extent = appendText(doc.Extent, strings.Join(extent, " "))
+	}
+	if doc.Abstract == "" && did.Abstract != nil {
+		doc.Abstract = ead3.StripMarkup(did.Abstract.Value)
+	}
+	if did.DIDNote != nil {
+		doc.Notes = appendText(doc.Notes, did.DIDNote.Value)
+	}
+	doc.addOrigination(did.Origination)
+	if len(did.DAOs()) > 0 {
+		doc.HasDigitalObjects = true
+	}
+}
+
+// repositoryName returns the name of the repository given in a DID
+func repositoryName(did *ead3.DID) string {
+	if did == nil || did.Repository == nil {
+		return ""
+	}
+	names := []string{}
+	for _, name := range did.Repository.CorpName {
+		names = appendText(names, ead3.NewHeading(name).Value)
+	}
+	for _, name := range did.Repository.Persname {
+		names = appendText(names, ead3.NewHeading(name).Value)
+	}
+	for _, name := range did.Repository.Famname {
+		names = appendText(names, ead3.NewHeading(name).Value)
+	}
+	for _, name := range did.Repository.Name {
+		names = appendText(names, ead3.NewHeading(name).Value)
+	}
+	return strings.Join(names, "; ")
+}
+
+// componentKey returns the part of a component's document id following the record id,
+// the component's id if it has one or its position in the Dsc (e.g. "c2_1_4")
+func componentKey(c *ead3.Component, position []int) string {
+	if id := strings.TrimSpace(c.ID()); id != "" {
+		return id
+	}
+	parts := []string{}
+	for _, i := range position {
+		parts = append(parts, fmt.Sprintf("%d", i))
+	}
+	return "c" + strings.Join(parts, "_")
+}
+
+// Documents flattens a finding aid into a document for the collection followed by a
+// document for each component in document order
+func Documents(record *ead3.EAD3) ([]*Document, error) {
+	recordID := RecordID(record)
+	if recordID == "" {
+		return nil, fmt.Errorf("record has no recordid")
+	}
+	if record.ArchDesc == nil {
+		return nil, fmt.Errorf("%s has no archdesc", recordID)
+	}
+	collection := &Document{
+		ID:       recordID,
+		RecordID: recordID,
+		Level:    record.ArchDesc.Level,
+	}
+	for _, did := range record.ArchDesc.DID {
+		collection.addDID(did)
+		for _, container := range did.Container {
+			collection.Containers = appendText(collection.Containers, ContainerLabel(container))
+		}
+		if collection.Repository == "" {
+			collection.Repository = repositoryName(did)
+		}
+	}
+	if collection.Title == "" && record.Control != nil && record.Control.FileDesc != nil &&
+		record.Control.FileDesc.TitleStmt != nil && record.Control.FileDesc.TitleStmt.TitleProper != nil {
+		collection.Title = ead3.StripMarkup(record.Control.FileDesc.TitleStmt.TitleProper.Value)
+	}
+	for _, controlAccess := range record.ArchDesc.ControlAccess {
+		collection.addControlAccess(controlAccess)
+	}
+	collection.addNotes(record.ArchDesc.Notes())
+	collection.CollectionID = collection.ID
+	collection.CollectionTitle = collection.Title
+
+	docs := []*Document{collection}
+	var flatten func(components []*ead3.Component, parent *Document, position []int)
+	flatten = func(components []*ead3.Component, parent *Document, position []int) {
+		for i, c := range components {
+			pos := append(append([]int{}, position...), i+1)
+			doc := &Document{
+				ID:              recordID + "_" + componentKey(c, pos),
+				RecordID:        recordID,
+				ComponentID:     c.ID(),
+				Level:           c.Level(),
+				Component:       true,
+				Depth:           c.Depth,
+				ParentIDs:       append(append([]string{}, parent.ParentIDs...), parent.ID),
+				ParentTitles:    append(append([]string{}, parent.ParentTitles...), parent.Title),
+				CollectionID:    collection.ID,
+				CollectionTitle: collection.Title,
+				Repository:      collection.Repository,
+			}
+			doc.addDID(c.DID())
+			for _, controlAccess := range c.ControlAccess() {
+				doc.addControlAccess(controlAccess)
+			}
+			doc.addNotes(c.Notes())
+			for _, container := range c.Containers() {
+				doc.Containers = appendText(doc.Containers, ContainerLabel(container))
+			}
+			if len(doc.AccessRestrict) == 0 && len(parent.AccessRestrict) > 0 {
+				doc.AccessRestrict = parent.AccessRestrict
+				doc.AccessRestrictInherited = true
+			}
+			if len(doc.UseRestrict) == 0 && len(parent.UseRestrict) > 0 {
+				doc.UseRestrict = parent.UseRestrict
+				doc.UseRestrictInherited = true
+			}
+			docs = append(docs, doc)
+			flatten(c.Children, doc, pos)
+		}
+	}
+	flatten(record.Components(), collection, []int{})
+	return docs, nil
+}
+
+func encode(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
+
+// WriteSolr writes docs as a Solr JSON update, an array of documents that can be
+// posted to a core's /update handler
+func WriteSolr(w io.Writer, docs []*Document) error {
+	if _, err := io.WriteString(w, "[\n"); err != nil {
+		return err
+	}
+	for i, doc := range docs {
+		if i > 0 {
+			if _, err := io.WriteString(w, ",\n"); err != nil {
+				return err
+			}
+		}
+		src, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(src); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "\n]\n")
+	return err
+}
+
+// WriteBulk writes docs as an Elasticsearch bulk request indexing each document
+// into indexName under its id
+func WriteBulk(w io.Writer, indexName string, docs []*Document) error {
+	type action struct {
+		Index string `json:"_index,omitempty"`
+		ID    string `json:"_id"`
+	}
+	for _, doc := range docs {
+		if err := encode(w, map[string]*action{"index": {Index: indexName, ID: doc.ID}}); err != nil {
+			return err
+		}
+		if err := encode(w, doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordDocuments flattens each of the records
+func recordDocuments(records []*ead3.EAD3) ([]*Document, error) {
+	docs := []*Document{}
+	for _, record := range records {
+		d, err := Documents(record)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, d...)
+	}
+	return docs, nil
+}
+
+// WriteSolrFile writes the documents for records as a Solr JSON update to fname
+func WriteSolrFile(fname string, records ...*ead3.EAD3) error {
+	docs, err := recordDocuments(records)
+	if err != nil {
+		return err
+	}
+	buf := new(bytes.Buffer)
+	if err := WriteSolr(buf, docs); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fname, buf.Bytes(), 0664)
+}
+
+// WriteBulkFile writes the documents for records as an Elasticsearch bulk request to fname
+func WriteBulkFile(fname string, indexName string, records ...*ead3.EAD3) error {
+	docs, err := recordDocuments(records)
+	if err != nil {
+		return err
+	}
+	buf := new(bytes.Buffer)
+	if err := WriteBulk(buf, indexName, docs); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fname, buf.Bytes(), 0664)
+}
@@ -0,0 +1,177 @@
+//
+// discovery_test.go tests flattening finding aids into search documents.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package discovery
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/caltechlibrary/ead3"
+)
+
+func readRecord(t *testing.T, fname string) *ead3.EAD3 {
+	src, err := ioutil.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	record := ead3.New()
+	if err := xml.Unmarshal(src, &record); err != nil {
+		t.Fatalf("%s, %s", fname, err)
+	}
+	return record
+}
+
+func TestDocuments(t *testing.T) {
+	record := readRecord(t, "../testsamples/ead3/NCSU/mc00019.xml")
+	docs, err := Documents(record)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(docs) < 2 {
+		t.Fatalf("expected collection and component documents, got %d", len(docs))
+	}
+	collection := docs[0]
+	if collection.ID != "mc00019" || collection.Component == true || collection.Title != "GI Bill Oral Histories" {
+		t.Errorf("unexpected collection document %+v", collection)
+	}
+	if len(collection.Years) != 2 || collection.Years[0] != 2003 || collection.Years[1] != 2004 {
+		t.Errorf("expected years 2003 and 2004, got %v", collection.Years)
+	}
+	if len(collection.AccessRestrict) == 0 || collection.Repository == "" {
+		t.Errorf("expected access restriction and repository for collection, %+v", collection)
+	}
+	ids := map[string]bool{}
+	for _, doc := range docs {
+		if ids[doc.ID] == true {
+			t.Errorf("duplicate document id %q", doc.ID)
+		}
+		ids[doc.ID] = true
+	}
+
+	// The first series' first child is a tape in a cassette box
+	var child *Document
+	for _, doc := range docs {
+		if doc.Depth == 2 {
+			child = doc
+			break
+		}
+	}
+	if child == nil {
+		t.Fatalf("expected a second level component")
+	}
+	if len(child.ParentIDs) != 2 || child.ParentIDs[0] != "mc00019" || len(child.ParentTitles) != 2 {
+		t.Errorf("unexpected parents %v, %v", child.ParentIDs, child.ParentTitles)
+	}
+	if child.CollectionTitle != collection.Title || child.Repository != collection.Repository {
+		t.Errorf("expected collection context, got %q, %q", child.CollectionTitle, child.Repository)
+	}
+	if child.AccessRestrictInherited == false || len(child.AccessRestrict) != len(collection.AccessRestrict) {
+		t.Errorf("expected inherited access restriction, got %v", child.AccessRestrict)
+	}
+	if len(child.Containers) != 2 || child.Containers[0] != "Cassettebox 1" {
+		t.Errorf("unexpected containers %v", child.Containers)
+	}
+
+	record.Control.RecordID = nil
+	if _, err := Documents(record); err == nil {
+		t.Errorf("expected an error for a record without a recordid")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	record := readRecord(t, "../testsamples/ead3/S.0001_valid.xml")
+	docs, err := Documents(record)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := WriteSolr(buf, docs); err != nil {
+		t.Fatalf("%s", err)
+	}
+	solr := []*Document{}
+	if err := json.Unmarshal(buf.Bytes(), &solr); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(solr) != len(docs) {
+		t.Errorf("expected %d solr documents, got %d", len(docs), len(solr))
+	}
+
+	buf.Reset()
+	if err := WriteBulk(buf, "findingaids", docs); err != nil {
+		t.Fatalf("%s", err)
+	}
+	lines := 0
+	scanner := bufio.NewScanner(buf)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if lines%2 == 0 {
+			action := map[string]map[string]string{}
+			if err := json.Unmarshal(scanner.Bytes(), &action); err != nil {
+				t.Fatalf("line %d, %s", lines+1, err)
+			}
+			if action["index"]["_index"] != "findingaids" || action["index"]["_id"] != docs[lines/2].ID {
+				t.Errorf("line %d, unexpected action %s", lines+1, scanner.Text())
+			}
+		}
+		lines++
+	}
+	if lines != len(docs)*2 {
+		t.Errorf("expected %d lines, got %d", len(docs)*2, lines)
+	}
+
+	dname, err := ioutil.TempDir("", "discovery")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer os.RemoveAll(dname)
+	fname := path.Join(dname, "solr.json")
+	if err := WriteSolrFile(fname, record); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if src, err := ioutil.ReadFile(fname); err != nil || len(src) == 0 {
+		t.Errorf("expected %s to be written, %s", fname, err)
+	}
+	fname = path.Join(dname, "bulk.ndjson")
+	if err := WriteBulkFile(fname, "findingaids", record); err != nil {
+		t.Fatalf("%s", err)
+	}
+}
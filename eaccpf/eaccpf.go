@@ -0,0 +1,255 @@
+// Package eaccpf provides structures for EAC-CPF (Encoded Archival Context - Corporate
+// Bodies, Persons and Families) records and extracts them from the creators and subjects
+// named in EAD3 finding aids.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+//   - Redistributions of source code must retain the above copyright notice, this
+//     list of conditions and the following disclaimer.
+//
+//   - Redistributions in binary form must reproduce the above copyright notice,
+//     this list of conditions and the following disclaimer in the documentation
+//     and/or other materials provided with the distribution.
+//
+//   - Neither the name of epgo nor the names of its
+//     contributors may be used to endorse or promote products derived from
+//     this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package eaccpf
+
+import (
+	"encoding/xml"
+)
+
+const (
+	// NameSpace is the EAC-CPF 2010 namespace
+	NameSpace = "urn:isbn:1-931666-33-4"
+	// XLinkNameSpace is the namespace of the xlink attributes of relations and sources
+	XLinkNameSpace = "http://www.w3.org/1999/xlink"
+)
+
+// EACCPF is an EAC-CPF record
+type EACCPF struct {
+	XMLName        xml.Name        `xml:"urn:isbn:1-931666-33-4 eac-cpf" json:"-"`
+	Control        *Control        `xml:"control" json:"control"`
+	CPFDescription *CPFDescription `xml:"cpfDescription" json:"cpfDescription"`
+}
+
+// Control holds the information about the maintenance of the record
+type Control struct {
+	XMLName             xml.Name             `xml:"control" json:"-"`
+	RecordID            string               `xml:"recordId" json:"recordId"`
+	OtherRecordID       []*OtherRecordID     `xml:"otherRecordId,omitempty" json:"otherRecordId,omitempty"`
+	MaintenanceStatus   string               `xml:"maintenanceStatus" json:"maintenanceStatus"`
+	MaintenanceAgency   *MaintenanceAgency   `xml:"maintenanceAgency" json:"maintenanceAgency"`
+	LanguageDeclaration *LanguageDeclaration `xml:"languageDeclaration,omitempty" json:"languageDeclaration,omitempty"`
+	MaintenanceHistory  *MaintenanceHistory  `xml:"maintenanceHistory" json:"maintenanceHistory"`
+	Sources             *Sources             `xml:"sources,omitempty" json:"sources,omitempty"`
+}
+
+// OtherRecordID is an alternate identifier for the record
+type OtherRecordID struct {
+	XMLName   xml.Name `xml:"otherRecordId" json:"-"`
+	LocalType string   `xml:"localType,attr,omitempty" json:"localType,omitempty"`
+	Value     string   `xml:",chardata" json:"value"`
+}
+
+// MaintenanceAgency is the institution responsible for the record
+type MaintenanceAgency struct {
+	XMLName    xml.Name `xml:"maintenanceAgency" json:"-"`
+	AgencyCode string   `xml:"agencyCode,omitempty" json:"agencyCode,omitempty"`
+	AgencyName string   `xml:"agencyName" json:"agencyName"`
+}
+
+// LanguageDeclaration is the language and script of the record
+type LanguageDeclaration struct {
+	XMLName  xml.Name  `xml:"languageDeclaration" json:"-"`
+	Language *Language `xml:"language" json:"language"`
+	Script   *Script   `xml:"script" json:"script"`
+}
+
+// Language is a language given by its ISO 639-2b code
+type Language struct {
+	XMLName      xml.Name `xml:"language" json:"-"`
+	LanguageCode string   `xml:"languageCode,attr" json:"languageCode"`
+	Value        string   `xml:",chardata" json:"value"`
+}
+
+// Script is a script given by its ISO 15924 code
+type Script struct {
+	XMLName    xml.Name `xml:"script" json:"-"`
+	ScriptCode string   `xml:"scriptCode,attr" json:"scriptCode"`
+	Value      string   `xml:",chardata" json:"value"`
+}
+
+// MaintenanceHistory lists the events in the life of the record
+type MaintenanceHistory struct {
+	XMLName          xml.Name            `xml:"maintenanceHistory" json:"-"`
+	MaintenanceEvent []*MaintenanceEvent `xml:"maintenanceEvent" json:"maintenanceEvent"`
+}
+
+// MaintenanceEvent is a creation, revision or other event in the life of the record
+type MaintenanceEvent struct {
+	XMLName          xml.Name       `xml:"maintenanceEvent" json:"-"`
+	EventType        string         `xml:"eventType" json:"eventType"`
+	EventDateTime    *EventDateTime `xml:"eventDateTime" json:"eventDateTime"`
+	AgentType        string         `xml:"agentType" json:"agentType"`
+	Agent            string         `xml:"agent" json:"agent"`
+	EventDescription string         `xml:"eventDescription,omitempty" json:"eventDescription,omitempty"`
+}
+
+// EventDateTime is the date and time of a maintenance event
+type EventDateTime struct {
+	XMLName          xml.Name `xml:"eventDateTime" json:"-"`
+	StandardDateTime string   `xml:"standardDateTime,attr,omitempty" json:"standardDateTime,omitempty"`
+	Value            string   `xml:",chardata" json:"value"`
+}
+
+// Sources lists the sources used to describe the entity
+type Sources struct {
+	XMLName xml.Name  `xml:"sources" json:"-"`
+	Source  []*Source `xml:"source" json:"source"`
+}
+
+// Source is a source used to describe the entity, e.g. a finding aid
+type Source struct {
+	XMLName     xml.Name `xml:"source" json:"-"`
+	Href        string   `xml:"http://www.w3.org/1999/xlink href,attr,omitempty" json:"href,omitempty"`
+	Type        string   `xml:"http://www.w3.org/1999/xlink type,attr,omitempty" json:"type,omitempty"`
+	SourceEntry string   `xml:"sourceEntry,omitempty" json:"sourceEntry,omitempty"`
+}
+
+// CPFDescription describes the corporate body, person or family
+type CPFDescription struct {
+	XMLName     xml.Name     `xml:"cpfDescription" json:"-"`
+	Identity    *Identity    `xml:"identity" json:"identity"`
+	Description *Description `xml:"description,omitempty" json:"description,omitempty"`
+	Relations   *Relations   `xml:"relations,omitempty" json:"relations,omitempty"`
+}
+
+// Entity types
+const (
+	Person        = "person"
+	CorporateBody = "corporateBody"
+	Family        = "family"
+)
+
+// Identity names the entity
+type Identity struct {
+	XMLName    xml.Name     `xml:"identity" json:"-"`
+	EntityID   []string     `xml:"entityId,omitempty" json:"entityId,omitempty"`
+	EntityType string       `xml:"entityType" json:"entityType"`
+	NameEntry  []*NameEntry `xml:"nameEntry" json:"nameEntry"`
+}
+
+// NameEntry is a name of the entity made of parts
+type NameEntry struct {
+	XMLName        xml.Name `xml:"nameEntry" json:"-"`
+	Part           []*Part  `xml:"part" json:"part"`
+	AuthorizedForm []string `xml:"authorizedForm,omitempty" json:"authorizedForm,omitempty"`
+}
+
+// Part is a part of a name
+type Part struct {
+	XMLName   xml.Name `xml:"part" json:"-"`
+	LocalType string   `xml:"localType,attr,omitempty" json:"localType,omitempty"`
+	Value     string   `xml:",chardata" json:"value"`
+}
+
+// Description holds the dates of existence and history of the entity
+type Description struct {
+	XMLName    xml.Name    `xml:"description" json:"-"`
+	ExistDates *ExistDates `xml:"existDates,omitempty" json:"existDates,omitempty"`
+	BiogHist   []*BiogHist `xml:"biogHist,omitempty" json:"biogHist,omitempty"`
+}
+
+// ExistDates are the dates of existence of the entity
+type ExistDates struct {
+	XMLName xml.Name `xml:"existDates" json:"-"`
+	Date    *Date    `xml:"date" json:"date"`
+}
+
+// Date is a date expression with an optional normalized form
+type Date struct {
+	XMLName      xml.Name `xml:"date" json:"-"`
+	StandardDate string   `xml:"standardDate,attr,omitempty" json:"standardDate,omitempty"`
+	Value        string   `xml:",chardata" json:"value"`
+}
+
+// BiogHist is a biographical or historical note
+type BiogHist struct {
+	XMLName   xml.Name   `xml:"biogHist" json:"-"`
+	Abstract  string     `xml:"abstract,omitempty" json:"abstract,omitempty"`
+	P         []string   `xml:"p,omitempty" json:"p,omitempty"`
+	ChronList *ChronList `xml:"chronList,omitempty" json:"chronList,omitempty"`
+}
+
+// ChronList is a chronology of events
+type ChronList struct {
+	XMLName   xml.Name     `xml:"chronList" json:"-"`
+	ChronItem []*ChronItem `xml:"chronItem" json:"chronItem"`
+}
+
+// ChronItem is a dated event
+type ChronItem struct {
+	XMLName xml.Name `xml:"chronItem" json:"-"`
+	Date    *Date    `xml:"date" json:"date"`
+	Event   string   `xml:"event" json:"event"`
+}
+
+// Relations links the entity to other entities and to archival resources
+type Relations struct {
+	XMLName          xml.Name            `xml:"relations" json:"-"`
+	CPFRelation      []*CPFRelation      `xml:"cpfRelation,omitempty" json:"cpfRelation,omitempty"`
+	ResourceRelation []*ResourceRelation `xml:"resourceRelation,omitempty" json:"resourceRelation,omitempty"`
+}
+
+// CPFRelation links the entity to another corporate body, person or family
+type CPFRelation struct {
+	XMLName         xml.Name `xml:"cpfRelation" json:"-"`
+	CPFRelationType string   `xml:"cpfRelationType,attr,omitempty" json:"cpfRelationType,omitempty"`
+	Href            string   `xml:"http://www.w3.org/1999/xlink href,attr,omitempty" json:"href,omitempty"`
+	Type            string   `xml:"http://www.w3.org/1999/xlink type,attr,omitempty" json:"type,omitempty"`
+	RelationEntry   string   `xml:"relationEntry,omitempty" json:"relationEntry,omitempty"`
+}
+
+// Resource relation types
+const (
+	CreatorOf = "creatorOf"
+	SubjectOf = "subjectOf"
+	Other     = "other"
+)
+
+// ResourceRelation links the entity to an archival resource such as a finding aid
+type ResourceRelation struct {
+	XMLName              xml.Name `xml:"resourceRelation" json:"-"`
+	ResourceRelationType string   `xml:"resourceRelationType,attr,omitempty" json:"resourceRelationType,omitempty"`
+	Href                 string   `xml:"http://www.w3.org/1999/xlink href,attr,omitempty" json:"href,omitempty"`
+	Type                 string   `xml:"http://www.w3.org/1999/xlink type,attr,omitempty" json:"type,omitempty"`
+	RelationEntry        string   `xml:"relationEntry,omitempty" json:"relationEntry,omitempty"`
+}
+
+// ToXML renders the record as an indented XML document
+func (cpf *EACCPF) ToXML() ([]byte, error) {
+	src, err := xml.MarshalIndent(cpf, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(src, '\n')...), nil
+}
@@ -0,0 +1,158 @@
+//
+// eaccpf_test.go tests extracting EAC-CPF records from finding aids.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package eaccpf
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caltechlibrary/ead3"
+)
+
+func readRecord(t *testing.T, fname string) *ead3.EAD3 {
+	src, err := ioutil.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	record := ead3.New()
+	if err := xml.Unmarshal(src, &record); err != nil {
+		t.Fatalf("%s, %s", fname, err)
+	}
+	return record
+}
+
+func TestExtract(t *testing.T) {
+	x := NewExtractor("Caltech Archives")
+	x.Date = time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, fname := range []string{
+		"../testsamples/ead3/NCSU/mc00019.xml",
+		"../testsamples/ead3/NCSU/mc00246.xml",
+		"../testsamples/ead3/NCSU/mc00312.xml",
+	} {
+		if err := x.Add(readRecord(t, fname)); err != nil {
+			t.Fatalf("%s, %s", fname, err)
+		}
+	}
+	cpfs := x.Records()
+	byID := map[string]*EACCPF{}
+	for _, cpf := range cpfs {
+		if _, ok := byID[cpf.Control.RecordID]; ok == true {
+			t.Errorf("duplicate recordId %q", cpf.Control.RecordID)
+		}
+		byID[cpf.Control.RecordID] = cpf
+		if cpf.Control.MaintenanceAgency.AgencyName != "Caltech Archives" {
+			t.Errorf("unexpected agency %q", cpf.Control.MaintenanceAgency.AgencyName)
+		}
+	}
+
+	creator := byID["cpf-north-carolina-state-university-libraries"]
+	if creator == nil {
+		t.Fatalf("expected a record for the creator of mc00019")
+	}
+	if creator.CPFDescription.Identity.EntityType != CorporateBody {
+		t.Errorf("expected corporate body, got %q", creator.CPFDescription.Identity.EntityType)
+	}
+	if description := creator.CPFDescription.Description; description == nil || len(description.BiogHist) == 0 || len(description.BiogHist[0].P) == 0 {
+		t.Errorf("expected biogHist seeded from the finding aid")
+	}
+	relations := creator.CPFDescription.Relations.ResourceRelation
+	if len(relations) == 0 || relations[0].ResourceRelationType != CreatorOf || relations[0].Href != "mc00019" || relations[0].RelationEntry != "GI Bill Oral Histories" {
+		t.Errorf("unexpected relations %+v", relations[0])
+	}
+
+	// Cornell University is a subject of two of the finding aids
+	cornell := byID["cpf-cornell-university"]
+	if cornell == nil {
+		t.Fatalf("expected a record for Cornell University")
+	}
+	hrefs := []string{}
+	for _, relation := range cornell.CPFDescription.Relations.ResourceRelation {
+		if relation.ResourceRelationType != SubjectOf {
+			t.Errorf("expected subjectOf, got %q", relation.ResourceRelationType)
+		}
+		hrefs = append(hrefs, relation.Href)
+	}
+	if strings.Join(hrefs, " ") != "mc00246 mc00312" {
+		t.Errorf("expected relations to mc00246 and mc00312, got %v", hrefs)
+	}
+	if cornell.CPFDescription.Description != nil {
+		t.Errorf("expected no description for a subject")
+	}
+
+	serow := byID["cpf-serow-robert-c-1947"]
+	if serow == nil || serow.CPFDescription.Identity.EntityType != Person {
+		t.Fatalf("expected a person record for Serow")
+	}
+	if existDates := serow.CPFDescription.Description.ExistDates; existDates == nil || existDates.Date.Value != "1947-" {
+		t.Errorf("expected exist dates 1947-, got %+v", existDates)
+	}
+	src, err := serow.ToXML()
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	for _, s := range []string{`<eac-cpf xmlns="urn:isbn:1-931666-33-4">`, `<entityType>person</entityType>`, `standardDateTime="2017-03-01T12:00:00Z"`, `xlink:href="mc00019"`} {
+		if strings.Contains(string(src), s) == false {
+			t.Errorf("expected %s in\n%s", s, src)
+		}
+	}
+	cpf := new(EACCPF)
+	if err := xml.Unmarshal(src, &cpf); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if cpf.Control.RecordID != serow.Control.RecordID || cpf.CPFDescription.Relations.ResourceRelation[0].Href != "mc00019" {
+		t.Errorf("expected record to read back, got %+v", cpf.Control)
+	}
+
+	dname, err := ioutil.TempDir("", "eaccpf")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer os.RemoveAll(dname)
+	if err := WriteFiles(dname, cpfs); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if _, err := os.Stat(path.Join(dname, "cpf-cornell-university.xml")); err != nil {
+		t.Errorf("%s", err)
+	}
+
+	if _, err := Extract([]*ead3.EAD3{ead3.New()}); err == nil {
+		t.Errorf("expected an error for a record without a recordid")
+	}
+}
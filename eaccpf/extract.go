@@ -0,0 +1,373 @@
+//
+// extract.go builds EAC-CPF records for the names in EAD3 finding aids.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package eaccpf
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/caltechlibrary/ead3"
+)
+
+// Extractor builds one EAC-CPF record per distinct person, family or corporate body
+// named in the origination or controlled access of a corpus of finding aids
+type Extractor struct {
+	AgencyName string
+	AgencyCode string
+	// RecordIDPrefix starts the recordId of each EAC-CPF record, default "cpf-"
+	RecordIDPrefix string
+	// Date is recorded as the creation date of the records, the current time if zero
+	Date time.Time
+
+	cpfs      map[string]*EACCPF
+	order     []string
+	recordIDs map[string]bool
+}
+
+// NewExtractor returns an Extractor for records maintained by agencyName
+func NewExtractor(agencyName string) *Extractor {
+	return &Extractor{
+		AgencyName:     agencyName,
+		RecordIDPrefix: "cpf-",
+		cpfs:           map[string]*EACCPF{},
+		order:          []string{},
+		recordIDs:      map[string]bool{},
+	}
+}
+
+// entityTypes maps the EAD3 name elements to EAC-CPF entity types, <name> is left out
+// as its type is unknown
+var entityTypes = map[string]string{
+	"persname": Person,
+	"famname":  Family,
+	"corpname": CorporateBody,
+}
+
+// normalize reduces a name to lower case letters and digits separated by spaces
+func normalize(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// entityKey identifies an entity across finding aids, by its authority identifier when
+// known otherwise by its type and normalized name
+func entityKey(entityType string, heading *ead3.Heading) string {
+	if identifier := strings.TrimSpace(heading.Identifier); identifier != "" {
+		return "id " + identifier
+	}
+	return entityType + " " + normalize(heading.Value)
+}
+
+// newRecordID returns an unused recordId made from the name
+func (x *Extractor) newRecordID(name string) string {
+	slug := strings.Replace(normalize(name), " ", "-", -1)
+	if len(slug) > 60 {
+		slug = strings.TrimRight(slug[0:60], "-")
+	}
+	recordID := x.RecordIDPrefix + slug
+	for i := 2; x.recordIDs[recordID] == true; i++ {
+		recordID = fmt.Sprintf("%s%s-%d", x.RecordIDPrefix, slug, i)
+	}
+	x.recordIDs[recordID] = true
+	return recordID
+}
+
+// nameParts returns the parts of the heading's name element
+func nameParts(heading *ead3.Heading) []*ead3.Part {
+	switch e := heading.Element.(type) {
+	case *ead3.Persname:
+		return e.Part
+	case *ead3.Famname:
+		return e.Part
+	case *ead3.CorpName:
+		return e.Part
+	}
+	return []*ead3.Part{}
+}
+
+// newCPF returns a new EAC-CPF record for the heading
+func (x *Extractor) newCPF(entityType string, heading *ead3.Heading) *EACCPF {
+	date := x.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	nameEntry := &NameEntry{}
+	existDates := ""
+	for _, part := range nameParts(heading) {
+		value := ead3.StripMarkup(part.Value)
+		if value == "" {
+			continue
+		}
+		nameEntry.Part = append(nameEntry.Part, &Part{LocalType: part.LocalType, Value: value})
+		switch part.LocalType {
+		case "existDates", "dates", "d":
+			existDates = value
+		}
+	}
+	if source := strings.TrimSpace(heading.Source); source != "" {
+		nameEntry.AuthorizedForm = []string{source}
+	}
+	identity := &Identity{EntityType: entityType, NameEntry: []*NameEntry{nameEntry}}
+	if identifier := strings.TrimSpace(heading.Identifier); identifier != "" {
+		identity.EntityID = []string{identifier}
+	}
+	cpf := &EACCPF{
+		Control: &Control{
+			RecordID:          x.newRecordID(heading.Value),
+			MaintenanceStatus: "new",
+			MaintenanceAgency: &MaintenanceAgency{AgencyCode: x.AgencyCode, AgencyName: x.AgencyName},
+			MaintenanceHistory: &MaintenanceHistory{
+				MaintenanceEvent: []*MaintenanceEvent{
+					{
+						EventType: "created",
+						EventDateTime: &EventDateTime{
+							StandardDateTime: date.Format(time.RFC3339),
+							Value:            date.Format("2006-01-02"),
+						},
+						AgentType:        "machine",
+						Agent:            "github.com/caltechlibrary/ead3/eaccpf",
+						EventDescription: "Extracted from EAD3 finding aids",
+					},
+				},
+			},
+		},
+		CPFDescription: &CPFDescription{
+			Identity: identity,
+		},
+	}
+	if existDates != "" {
+		cpf.CPFDescription.Description = &Description{ExistDates: &ExistDates{Date: &Date{Value: existDates}}}
+	}
+	return cpf
+}
+
+// biogHists converts the biographical/historical notes of an archival description
+func biogHists(archDesc *ead3.ArchDesc) []*BiogHist {
+	list := []*BiogHist{}
+	for _, note := range archDesc.Notes() {
+		if note.Name != "bioghist" {
+			continue
+		}
+		biogHist := &BiogHist{}
+		for _, p := range note.P {
+			if s := ead3.StripMarkup(p.Value); s != "" {
+				biogHist.P = append(biogHist.P, s)
+			}
+		}
+		chronList := &ChronList{}
+		for _, list := range note.ChronList {
+			for _, item := range list.ChronItem {
+				chronItem := &ChronItem{Date: &Date{Value: ead3.StripMarkup(item.Date())}}
+				events := []string{}
+				for _, event := range item.Events() {
+					events = append(events, ead3.StripMarkup(event.Value))
+				}
+				chronItem.Event = strings.Join(events, " ")
+				chronList.ChronItem = append(chronList.ChronItem, chronItem)
+			}
+		}
+		if len(chronList.ChronItem) > 0 {
+			biogHist.ChronList = chronList
+		}
+		if len(biogHist.P) > 0 || biogHist.ChronList != nil {
+			list = append(list, biogHist)
+		}
+	}
+	return list
+}
+
+// addRelation links cpf to the finding aid unless it is already linked the same way
+func (cpf *EACCPF) addRelation(relationType, href, title string) {
+	description := cpf.CPFDescription
+	if description.Relations == nil {
+		description.Relations = &Relations{}
+	}
+	for _, relation := range description.Relations.ResourceRelation {
+		if relation.ResourceRelationType == relationType && relation.Href == href {
+			return
+		}
+	}
+	description.Relations.ResourceRelation = append(description.Relations.ResourceRelation, &ResourceRelation{
+		ResourceRelationType: relationType,
+		Href:                 href,
+		Type:                 "simple",
+		RelationEntry:        title,
+	})
+	if cpf.Control.Sources == nil {
+		cpf.Control.Sources = &Sources{}
+	}
+	for _, source := range cpf.Control.Sources.Source {
+		if source.Href == href {
+			return
+		}
+	}
+	cpf.Control.Sources.Source = append(cpf.Control.Sources.Source, &Source{Href: href, Type: "simple", SourceEntry: title})
+}
+
+// entity returns the EAC-CPF record for heading creating it if needed, nil for headings
+// that aren't persons, families or corporate bodies
+func (x *Extractor) entity(heading *ead3.Heading) *EACCPF {
+	entityType, ok := entityTypes[heading.Type]
+	if ok == false || heading.Value == "" {
+		return nil
+	}
+	key := entityKey(entityType, heading)
+	cpf, ok := x.cpfs[key]
+	if ok == false {
+		cpf = x.newCPF(entityType, heading)
+		x.cpfs[key] = cpf
+		x.order = append(x.order, key)
+	}
+	return cpf
+}
+
+// Add extracts the names of a finding aid. The creators in the archdesc origination
+// are related as creatorOf and, when they have none yet, their biogHist is seeded from
+// the archdesc's bioghist. Names in component origination are also creatorOf and names
+// in controlled access are subjectOf. The relations point back to the finding aid by its
+// recordid (or recordid@instanceurl when given).
+func (x *Extractor) Add(record *ead3.EAD3) error {
+	if record.Control == nil || record.Control.RecordID == nil || strings.TrimSpace(record.Control.RecordID.Value) == "" {
+		return fmt.Errorf("record has no recordid")
+	}
+	if record.ArchDesc == nil {
+		return fmt.Errorf("%s has no archdesc", record.Control.RecordID.Value)
+	}
+	href := strings.TrimSpace(record.Control.RecordID.Value)
+	if s := strings.TrimSpace(record.Control.RecordID.InstanceURL); s != "" {
+		href = s
+	}
+	if x.AgencyName == "" && record.Control.MaintenanceAgency != nil {
+		x.AgencyName = strings.TrimSpace(record.Control.MaintenanceAgency.AgencyName)
+	}
+	title := ""
+	for _, did := range record.ArchDesc.DID {
+		if title == "" && did.UnitTitle != nil {
+			title = ead3.StripMarkup(did.UnitTitle.Value)
+		}
+	}
+
+	archDesc := record.ArchDesc
+	for _, did := range archDesc.DID {
+		for _, heading := range did.Origination.Headings() {
+			if cpf := x.entity(heading); cpf != nil {
+				cpf.addRelation(CreatorOf, href, title)
+				description := cpf.CPFDescription.Description
+				if description == nil {
+					description = &Description{}
+					cpf.CPFDescription.Description = description
+				}
+				if len(description.BiogHist) == 0 {
+					description.BiogHist = biogHists(archDesc)
+				}
+			}
+		}
+	}
+	for _, controlAccess := range archDesc.ControlAccess {
+		for _, heading := range controlAccess.Headings() {
+			if cpf := x.entity(heading); cpf != nil {
+				cpf.addRelation(SubjectOf, href, title)
+			}
+		}
+	}
+	return record.Walk(func(c *ead3.Component) error {
+		if did := c.DID(); did != nil {
+			for _, heading := range did.Origination.Headings() {
+				if cpf := x.entity(heading); cpf != nil {
+					cpf.addRelation(CreatorOf, href, title)
+				}
+			}
+		}
+		for _, controlAccess := range c.ControlAccess() {
+			for _, heading := range controlAccess.Headings() {
+				if cpf := x.entity(heading); cpf != nil {
+					cpf.addRelation(SubjectOf, href, title)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Records returns the EAC-CPF records in the order the names were first found
+func (x *Extractor) Records() []*EACCPF {
+	list := []*EACCPF{}
+	for _, key := range x.order {
+		cpf := x.cpfs[key]
+		if cpf.Control.MaintenanceAgency.AgencyName == "" {
+			cpf.Control.MaintenanceAgency.AgencyName = x.AgencyName
+		}
+		if description := cpf.CPFDescription.Description; description != nil && description.ExistDates == nil && len(description.BiogHist) == 0 {
+			cpf.CPFDescription.Description = nil
+		}
+		list = append(list, cpf)
+	}
+	return list
+}
+
+// Extract returns the EAC-CPF records for the names in a corpus of finding aids, the
+// maintenance agency is taken from the first record giving one
+func Extract(records []*ead3.EAD3) ([]*EACCPF, error) {
+	x := NewExtractor("")
+	for _, record := range records {
+		if err := x.Add(record); err != nil {
+			return nil, err
+		}
+	}
+	return x.Records(), nil
+}
+
+// WriteFiles writes each record to dir as its recordId plus ".xml"
+func WriteFiles(dir string, cpfs []*EACCPF) error {
+	for _, cpf := range cpfs {
+		src, err := cpf.ToXML()
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path.Join(dir, cpf.Control.RecordID+".xml"), src, 0664); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -45,10 +45,10 @@ import "encoding/xml"
 // EAD3 document container
 type EAD3 struct {
 	XMLName         xml.Name  `xml:"ead" json:"-"`
-	XMLNameSpace    string    `xml:"xmlns,attr" json:"-"`
+	XMLNameSpace    string    `xml:"xmlns,attr" json:"xmlns,omitempty"`
 	Audience        string    `xml:"audience,attr,omitempty" json:"audience,omitempty"`
-	Control         *Control  `xml:"control" json:"control"`
-	ArchDesc        *ArchDesc `xml:"archdesc" json:"archdesc"`
+	Control         *Control  `xml:"control" json:"control,omitempty"`
+	ArchDesc        *ArchDesc `xml:"archdesc" json:"archdesc,omitempty"`
 	RelatedEncoding string    `xml:"relatedencoding,attr,omitempty" json:"relatedencoding,omitempty"`
 	DateEncoding    string    `xml:"dateencoding,attr,omitempty" json:"dateencoding,omitempty"`
 	LangEncoding    string    `xml:"langencoding,attr,omitempty" json:"langencoding,omitempty"`
@@ -56,8 +56,9 @@ type EAD3 struct {
 
 // P provides for unparsed embedded markup of paragraph elements
 type P struct {
-	XMLName xml.Name `xml:"p" json:"-"`
-	Value   string   `xml:",innerxml" json:"text"`
+	XMLName  xml.Name `xml:"p" json:"-"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Value    string   `xml:",innerxml" json:"text"`
 }
 
 // Control structure for initial element of an EAD
@@ -68,19 +69,20 @@ type Control struct {
 	LangEncoding    string   `xml:"langencoding,attr,omitempty" json:"langencoding,omitempty"`
 	RelatedEncoding string   `xml:"relatedencoding,attr,omitempty" json:"relatedencoding,omitempty"`
 	ScriptEncoding  string   `xml:"scriptencoding,attr,omitempty" json:"scriptencoding,omitempty"`
+	Audience        string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 
-	RecordID              *RecordID              `xml:"recordid" json:"recordid"`
-	OtherRecordID         *OtherRecordID         `xml:"otherrecordid,omitemtpy" json:"otherrecordid,omitempty"`
+	RecordID              *RecordID              `xml:"recordid" json:"recordid,omitempty"`
+	OtherRecordID         *OtherRecordID         `xml:"otherrecordid,omitempty" json:"otherrecordid,omitempty"`
 	Representation        *Representation        `xml:"representation,omitempty" json:"representation,omitempty"`
-	FileDesc              *FileDesc              `xml:"filedesc" json:"filedesc"`
-	PublicationStatus     *PublicationStatus     `xml:"publicationstatus,omitempty" json:"publicationstatus"`
-	MaintenanceStatus     *MaintenanceStatus     `xml:"maintenancestatus" json:"maintenancestatus"`
-	MaintenanceAgency     *MaintenanceAgency     `xml:"maintenanceagency" json:"maintenanceagency"`
-	LanguageDeclaration   *LanguageDeclaration   `xml:"languagedeclaration" json:"languagedeclaration"`
-	ConventionDeclaration *ConventionDeclaration `xml:"conventiondeclaration" json:"conventiondeclaration"`
+	FileDesc              *FileDesc              `xml:"filedesc" json:"filedesc,omitempty"`
+	PublicationStatus     *PublicationStatus     `xml:"publicationstatus,omitempty" json:"publicationstatus,omitempty"`
+	MaintenanceStatus     *MaintenanceStatus     `xml:"maintenancestatus" json:"maintenancestatus,omitempty"`
+	MaintenanceAgency     *MaintenanceAgency     `xml:"maintenanceagency" json:"maintenanceagency,omitempty"`
+	LanguageDeclaration   *LanguageDeclaration   `xml:"languagedeclaration" json:"languagedeclaration,omitempty"`
+	ConventionDeclaration *ConventionDeclaration `xml:"conventiondeclaration" json:"conventiondeclaration,omitempty"`
 	LocalTypeDeclaration  *LocalTypeDeclaration  `xml:"localtypedeclaration,omitempty" json:"localtypedeclaration,omitempty"`
 	LocalControl          []*LocalControl        `xml:"localcontrol,omitempty" json:"localcontrol,omitempty"`
-	MaintenanceHistory    *MaintenanceHistory    `xml:"maintenancehistory" json:"maintenancehistory"`
+	MaintenanceHistory    *MaintenanceHistory    `xml:"maintenancehistory" json:"maintenancehistory,omitempty"`
 	Sources               *Sources               `xml:"sources,omitempty" json:"sources,omitempty"`
 }
 
@@ -88,6 +90,7 @@ type RecordID struct {
 	XMLName     xml.Name `xml:"recordid" json:"-"`
 	LocalType   string   `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
 	InstanceURL string   `xml:"instanceurl,attr,omitempty" json:"instanceurl,omitempty"`
+	Audience    string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value       string   `xml:",chardata" json:"value"`
 }
 
@@ -95,6 +98,7 @@ type OtherRecordID struct {
 	XMLName     xml.Name `xml:"otherrecordid" json:"-"`
 	LocalType   string   `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
 	InstanceURL string   `xml:"instanceurl,attr,omitempty" json:"instanceurl,omitempty"`
+	Audience    string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value       string   `xml:",chardata" json:"value"`
 }
 
@@ -104,13 +108,15 @@ type Representation struct {
 	LocalType string   `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
 	LinkTitle string   `xml:"linktitle,attr,omitempty" json:"linktitle,omitempty"`
 	Show      string   `xml:"show,attr,omitempty" json:"show,omitempty"`
+	Audience  string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value     string   `xml:",chardata" json:"value"`
 }
 
 // FileDesc describes file system contents
 type FileDesc struct {
 	XMLName         xml.Name         `xml:"filedesc" json:"-"`
-	TitleStmt       *TitleStmt       `xml:"titlestmt" json:"titlestmt"`
+	Audience        string           `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	TitleStmt       *TitleStmt       `xml:"titlestmt" json:"titlestmt,omitempty"`
 	EditionStmt     *EditionStmt     `xml:"editionstmt,omitempty" json:"editionstmt,omitempty"`
 	PublicationStmt *PublicationStmt `xml:"publicationstmt,omitempty" json:"publicationstmt,omitempty"`
 	NoteStmt        *NoteStmt        `xml:"notestmt,omitempty" json:"notestmt,omitempty"`
@@ -120,7 +126,8 @@ type FileDesc struct {
 // TitleStmt provides structure relating to titling and authorship
 type TitleStmt struct {
 	XMLName     xml.Name     `xml:"titlestmt" json:"-"`
-	TitleProper *TitleProper `xml:"titleproper" json:"titleproper"`
+	Audience    string       `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	TitleProper *TitleProper `xml:"titleproper" json:"titleproper,omitempty"`
 	Subtitle    *Subtitle    `xml:"subtitle,omitempty" json:"subtitle,omitempty"`
 	Author      *Author      `xml:"author,omitempty" json:"author,omitempty"`
 	Sponsor     *Sponsor     `xml:"sponsor,omitempty" json:"sponsor,omitempty"`
@@ -130,6 +137,7 @@ type TitleStmt struct {
 type TitleProper struct {
 	XMLName        xml.Name `xml:"titleproper" json:"-"`
 	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	Audience       string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value          string   `xml:",chardata" json:"value,omitempty"`
 }
 
@@ -137,6 +145,7 @@ type TitleProper struct {
 type Subtitle struct {
 	XMLName        xml.Name `xml:"subtitle" json:"-"`
 	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	Audience       string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value          string   `xml:",chardata" json:"value,omitempty"`
 }
 
@@ -144,6 +153,7 @@ type Subtitle struct {
 type Author struct {
 	XMLName        xml.Name `xml:"author" json:"-"`
 	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	Audience       string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value          string   `xml:",chardata" json:"value,omitempty"`
 }
 
@@ -151,6 +161,7 @@ type Author struct {
 type Sponsor struct {
 	XMLName        xml.Name `xml:"sponsor" json:"-"`
 	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	Audience       string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value          string   `xml:",chardata" json:"value,omitempty"`
 }
 
@@ -158,33 +169,38 @@ type Sponsor struct {
 type Publisher struct {
 	XMLName        xml.Name `xml:"publisher" json:"-"`
 	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	Audience       string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value          string   `xml:",chardata" json:"value,omitempty"`
 }
 
 // EditionStmt provides information an about specific editions of work
 type EditionStmt struct {
-	XMLName xml.Name `xml:"editionstmt" json:"-"`
-	Edition string   `xml:"edition,omitempty" json:"edition,omitempty"`
-	P       []*P     `xml:"p" json:"p"`
-	Date    *Date    `xml:"date,omitempty" json:"date,omitempty"`
+	XMLName  xml.Name `xml:"editionstmt" json:"-"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Edition  string   `xml:"edition,omitempty" json:"edition,omitempty"`
+	P        []*P     `xml:"p" json:"p,omitempty"`
+	Date     *Date    `xml:"date,omitempty" json:"date,omitempty"`
 }
 
 // NoteStmt provides information an about a work
 type NoteStmt struct {
 	XMLName     xml.Name     `xml:"notestmt" json:"-"`
+	Audience    string       `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	ControlNote *ControlNote `xml:"controlnote,omitempty" json:"controlnote,omitempty"`
 	Date        *Date        `xml:"date,omitempty" json:"date,omitempty"`
 }
 
 // ControlNote provides specific about processing
 type ControlNote struct {
-	XMLName xml.Name `xml:"controlnote" json:"-"`
-	P       []*P     `xml:"p" json:"p"`
+	XMLName  xml.Name `xml:"controlnote" json:"-"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	P        []*P     `xml:"p" json:"p,omitempty"`
 }
 
 // PublicationStmt provides information on the publication nature of content
 type PublicationStmt struct {
 	XMLName   xml.Name   `xml:"publicationstmt" json:"-"`
+	Audience  string     `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Publisher *Publisher `xml:"publisher,omitempty" json:"publisher,omitempty"`
 	P         []*P       `xml:"p,omitempty" json:"p,omitempty"`
 	Date      *Date      `xml:"date,omitempty" json:"date,omitempty"`
@@ -193,48 +209,55 @@ type PublicationStmt struct {
 
 type Address struct {
 	XMLName     xml.Name `xml:"address" json:"-"`
+	Audience    string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	AddressLine []string `xml:"addressline,omitempty" json:"addressline,omitempty"`
 }
 
 // SeriesStmt provides informaiton on a series
 type SeriesStmt struct {
 	XMLName     xml.Name `xml:"seriesstmt" json:"-"`
-	TitleProper string   `xml:"titleproper" json:"titleproper"`
+	Audience    string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	TitleProper string   `xml:"titleproper" json:"titleproper,omitempty"`
 	Num         string   `xml:"num,omitempty" json:"num,omitempty"`
 }
 
 // PublicationStatus provides an descriptive publication status
 type PublicationStatus struct {
-	XMLName xml.Name `xml:"publicationstatus" json:"-"`
-	Value   string   `xml:"value,attr" json:"value"`
-	Text    string   `xml:",chardata" json:"text"`
+	XMLName  xml.Name `xml:"publicationstatus" json:"-"`
+	Value    string   `xml:"value,attr" json:"value,omitempty"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Text     string   `xml:",chardata" json:"text"`
 }
 
 // MaintenanceStatus provides an descriptive meantenance status
 type MaintenanceStatus struct {
-	XMLName xml.Name `xml:"maintenancestatus" json:"-"`
-	Value   string   `xml:"value,attr" json:"value"`
-	Text    string   `xml:",chardata" json:"text"`
+	XMLName  xml.Name `xml:"maintenancestatus" json:"-"`
+	Value    string   `xml:"value,attr" json:"value,omitempty"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Text     string   `xml:",chardata" json:"text"`
 }
 
 // MaintenanceAgency provides content related organziation performing maintenance
 type MaintenanceAgency struct {
 	XMLName         xml.Name         `xml:"maintenanceagency" json:"-"`
+	Audience        string           `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	AgencyCode      string           `xml:"agencycode,omitempty" json:"agencycode,omitempty"`
-	AgencyName      string           `xml:"agencyname,	omitempty" json:"agencyname,omitempty"`
+	AgencyName      string           `xml:"agencyname" json:"agencyname,omitempty"`
 	OtherAgencyCode *OtherAgencyCode `xml:"otheragencycode,omitempty" json:"otheragencycode,omitempty"`
 }
 
 type OtherAgencyCode struct {
 	XMLName   xml.Name `xml:"otheragencycode" json:"-"`
 	LocalType string   `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	Audience  string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value     string   `xml:",chardata" json:"value,omitempty"`
 }
 
 // ConventionDeclaration provides ciation declarations
 type ConventionDeclaration struct {
 	XMLName         xml.Name         `xml:"conventiondeclaration" json:"-"`
-	Citation        *Citation        `xml:"citation" json:"citation"`
+	Audience        string           `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Citation        *Citation        `xml:"citation" json:"citation,omitempty"`
 	Abbr            string           `xml:"abbr,omitempty" json:"abbr,omitempty"`
 	Descriptivenote *DescriptiveNote `xml:"descriptivenote,omitempty" json:"descriptivenote,omitempty"`
 }
@@ -242,7 +265,8 @@ type ConventionDeclaration struct {
 // LocalTypeDeclaration provides ciation declarations
 type LocalTypeDeclaration struct {
 	XMLName         xml.Name         `xml:"localtypedeclaration" json:"-"`
-	Citation        *Citation        `xml:"citation" json:"citation"`
+	Audience        string           `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Citation        *Citation        `xml:"citation" json:"citation,omitempty"`
 	Abbr            string           `xml:"abbr,omitempty" json:"abbr,omitempty"`
 	Descriptivenote *DescriptiveNote `xml:"descriptivenote,omitempty" json:"descriptivenote,omitempty"`
 }
@@ -251,6 +275,7 @@ type LocalTypeDeclaration struct {
 type LocalControl struct {
 	XMLName   xml.Name `xml:"localcontrol" json:"-"`
 	LocalType string   `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	Audience  string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Term      string   `xml:"term,omitempty" json:"term,omitempty"`
 }
 
@@ -263,12 +288,14 @@ type Citation struct {
 	Actuate              string   `xml:"actuate,attr,omitempty" json:"actuate,omitempty"`
 	Show                 string   `xml:"show,attr,omitempty" json:"show,omitempty"`
 	//	LocalType            string   `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
-	Value string `xml:",chardata" json:"value"`
+	Audience string `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Value    string `xml:",chardata" json:"value"`
 }
 
 // LanguageDeclaration describes relevant language implications
 type LanguageDeclaration struct {
 	XMLName  xml.Name  `xml:"languagedeclaration" json:"-"`
+	Audience string    `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Language *Language `xml:"language,omitempty" json:"language,omitempty"`
 	Script   *Script   `xml:"script,omitempty" json:"script,omitempty"`
 }
@@ -278,6 +305,7 @@ type Language struct {
 	XMLName        xml.Name `xml:"language" json:"-"`
 	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
 	LangCode       string   `xml:"langcode,attr,omitempty" json:"langcode,omitempty"`
+	Audience       string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value          string   `xml:",chardata" json:"value"`
 }
 
@@ -285,94 +313,103 @@ type Language struct {
 type Script struct {
 	XMLName    xml.Name `xml:"script" json:"-"`
 	ScriptCode string   `xml:"scriptcode,attr" json:"scriptcode,omitempty"`
+	Audience   string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value      string   `xml:",chardata" json:"value,omitempty"`
 }
 
 // MaintenanceHistory provides a collection of maintenance events
 type MaintenanceHistory struct {
 	XMLName          xml.Name            `xml:"maintenancehistory" json:"-"`
-	MaintenanceEvent []*MaintenanceEvent `xml:"maintenanceevent" json:"maintenanceevent"`
+	Audience         string              `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	MaintenanceEvent []*MaintenanceEvent `xml:"maintenanceevent" json:"maintenanceevent,omitempty"`
 }
 
 // MaintenanceEvent describes activities related to processing content
 type MaintenanceEvent struct {
 	XMLName          xml.Name       `xml:"maintenanceevent" json:"-"`
-	EventType        *EventType     `xml:"eventtype" json:"eventtype"`
-	EventDateTime    *EventDateTime `xml:"eventdatetime" json:"eventdatetime"`
-	AgentType        *AgentType     `xml:"agenttype" json:"agenttype"`
-	Agent            string         `xml:"agent" json:"agent"`
+	Audience         string         `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	EventType        *EventType     `xml:"eventtype" json:"eventtype,omitempty"`
+	EventDateTime    *EventDateTime `xml:"eventdatetime" json:"eventdatetime,omitempty"`
+	AgentType        *AgentType     `xml:"agenttype" json:"agenttype,omitempty"`
+	Agent            string         `xml:"agent" json:"agent,omitempty"`
 	EventDescription string         `xml:"eventdescription,omitempty" json:"eventdescription,omitempty"`
 }
 
 // EventDateTime describes a date time of event
 type EventDateTime struct {
 	XMLName          xml.Name `xml:"eventdatetime" json:"-"`
-	StandardDateTime string   `xml:"standarddatetime,attr,omitempty" json:"standarddatetime,emitempty"`
+	StandardDateTime string   `xml:"standarddatetime,attr,omitempty" json:"standarddatetime,omitempty"`
+	Audience         string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value            string   `xml:",chardata" json:"value"`
 }
 
 // EventType describes the type of maintenance event
 type EventType struct {
-	XMLName xml.Name `xml:"eventtype" json:"-"`
-	Value   string   `xml:"value,attr" json:"eventtype"`
+	XMLName  xml.Name `xml:"eventtype" json:"-"`
+	Value    string   `xml:"value,attr" json:"value,omitempty"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 }
 
 // AgentType describes of the acting parties in the maintenance event
 type AgentType struct {
-	XMLName xml.Name `xml:"agenttype" json:"-"`
-	Value   string   `xml:"value,attr" json:"agenttype"`
+	XMLName  xml.Name `xml:"agenttype" json:"-"`
+	Value    string   `xml:"value,attr" json:"value,omitempty"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 }
 
 // ArchDesc provides an Archival Description of the content
 type ArchDesc struct {
-	XMLName           xml.Name           `xml:"archdesc" json:"-"`
-	XMLNameSpace      string             `xml:"xmlns,attr,omitempty", json:"-"`
-	LocalType         string             `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
-	Level             string             `xml:"level,attr" json:"level,omitempty"`
-	RelatedEncoding   string             `xml:"relatedencoding,attr,omitempty" json:"relatedencoding,omitempty"`
-	DID               []*DID             `xml:"did,omitempty" json:"did,omitempty"`
-	Bibliography      *Bibliography      `xml:"bibliography,omitempty" json:"bibliography,omitempty"`
-	BiogHist          *BiogHist          `xml:"bioghist" json:"bioghist"`
-	ScopeContent      *ScopeContent      `xml:"scopecontent" json:"scopecontent"`
-	Arrangement       *Arrangement       `xml:"arrangement" json:"arrangement"`
-	ControlAccess     []*ControlAccess   `xml:"controlaccess" json:"controlaccess"`
-	RelatedMaterial   *RelatedMaterial   `xml:"relatedmaterial" json:"relatedmaterial"`
-	Relations         *Relations         `xml:"relations,omitempty" json:"relation,omitempty"`
-	AccessRestrict    *AccessRestrict    `xml:"accessrestrict" json:"accessrestrict"`
-	UseRestrict       *UseRestrict       `xml:"userestrict" json:"userestrict"`
-	AcqInfo           *AcqInfo           `xml:"acqinfo" json:"acqinfo"`
-	ProcessInfo       *ProcessInfo       `xml:"processinfo" json:"processinfo"`
-	AltFormAvail      *AltFormAvail      `xml:"altformavail,omitempty" json:"altformavail,omitempty"`
-	Appraisal         *Appraisal         `xml:"appraisal" json:"appraisal"`
-	CustodHist        *CustodHist        `xml:"custodhist" json:"custodhist"`
-	FilePlan          *FilePlan          `xml:"fileplan" json:"fileplan"`
-	Accruals          *Accruals          `xml:"accruals" json:"accruals"`
-	LegalStatus       *LegalStatus       `xml:"legalstatus" json:"legalstatus"`
-	Odd               *Odd               `xml:"odd" json:"odd"`
-	OriginalsLoc      *OriginalsLoc      `xml:"originalsloc,omitempty" json:"originalsloc,omitempty"`
-	PreferCite        *PreferCite        `xml:"prefercite" json:"prefercite"`
-	OtherFindAID      *OtherFindAID      `xml:"otherfindaid" json:"otherfindaid"`
-	PhysTech          *PhysTech          `xml:"phystech" json:"phystech"`
-	SeparatedMaterial *SeparatedMaterial `xml:"separatedmaterial" json:"separatedmaterial"`
-	Dsc               *Dsc               `xml:"dsc" json:"dsc"`
+	XMLName           xml.Name             `xml:"archdesc" json:"-"`
+	XMLNameSpace      string               `xml:"xmlns,attr,omitempty" json:"-"`
+	LocalType         string               `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	Level             string               `xml:"level,attr" json:"level,omitempty"`
+	RelatedEncoding   string               `xml:"relatedencoding,attr,omitempty" json:"relatedencoding,omitempty"`
+	Audience          string               `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	DID               []*DID               `xml:"did,omitempty" json:"did,omitempty"`
+	Bibliography      []*Bibliography      `xml:"bibliography,omitempty" json:"bibliography,omitempty"`
+	BiogHist          []*BiogHist          `xml:"bioghist,omitempty" json:"bioghist,omitempty"`
+	ScopeContent      []*ScopeContent      `xml:"scopecontent,omitempty" json:"scopecontent,omitempty"`
+	Arrangement       []*Arrangement       `xml:"arrangement,omitempty" json:"arrangement,omitempty"`
+	ControlAccess     []*ControlAccess     `xml:"controlaccess" json:"controlaccess,omitempty"`
+	RelatedMaterial   []*RelatedMaterial   `xml:"relatedmaterial,omitempty" json:"relatedmaterial,omitempty"`
+	Relations         []*Relations         `xml:"relations,omitempty" json:"relations,omitempty"`
+	AccessRestrict    []*AccessRestrict    `xml:"accessrestrict,omitempty" json:"accessrestrict,omitempty"`
+	UseRestrict       []*UseRestrict       `xml:"userestrict,omitempty" json:"userestrict,omitempty"`
+	AcqInfo           []*AcqInfo           `xml:"acqinfo,omitempty" json:"acqinfo,omitempty"`
+	ProcessInfo       []*ProcessInfo       `xml:"processinfo,omitempty" json:"processinfo,omitempty"`
+	AltFormAvail      []*AltFormAvail      `xml:"altformavail,omitempty" json:"altformavail,omitempty"`
+	Appraisal         []*Appraisal         `xml:"appraisal,omitempty" json:"appraisal,omitempty"`
+	CustodHist        []*CustodHist        `xml:"custodhist,omitempty" json:"custodhist,omitempty"`
+	FilePlan          []*FilePlan          `xml:"fileplan,omitempty" json:"fileplan,omitempty"`
+	Accruals          []*Accruals          `xml:"accruals,omitempty" json:"accruals,omitempty"`
+	LegalStatus       []*LegalStatus       `xml:"legalstatus,omitempty" json:"legalstatus,omitempty"`
+	Odd               []*Odd               `xml:"odd,omitempty" json:"odd,omitempty"`
+	OriginalsLoc      []*OriginalsLoc      `xml:"originalsloc,omitempty" json:"originalsloc,omitempty"`
+	PreferCite        []*PreferCite        `xml:"prefercite,omitempty" json:"prefercite,omitempty"`
+	OtherFindAID      []*OtherFindAID      `xml:"otherfindaid,omitempty" json:"otherfindaid,omitempty"`
+	PhysTech          []*PhysTech          `xml:"phystech,omitempty" json:"phystech,omitempty"`
+	SeparatedMaterial []*SeparatedMaterial `xml:"separatedmaterial,omitempty" json:"separatedmaterial,omitempty"`
+	Index             []*Index             `xml:"index,omitempty" json:"index,omitempty"`
+	Dsc               *Dsc                 `xml:"dsc" json:"dsc,omitempty"`
 }
 
 // DID  Descriptive Identification of the Unit
 type DID struct {
 	XMLName            xml.Name              `xml:"did" json:"-"`
-	XMLNameSpace       string                `xml:"xmlns,attr,omitempty", json:"-"`
+	XMLNameSpace       string                `xml:"xmlns,attr,omitempty" json:"-"`
 	ID                 string                `xml:"id,attr,omitempty" json:"id,omitempty"`
 	Lang               string                `xml:"lang,attr,omitempty" json:"lang,omitempty"`
 	Script             string                `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Audience           string                `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Head               *Head                 `xml:"head,omitempty" json:"head,omitempty"`
-	Repository         *Repository           `xml:"repository" json:"repository"`
-	Origination        *Origination          `xml:"origination" json:"origination"`
-	UnitTitle          *UnitTitle            `xml:"unittitle" json:"unittitle"`
+	Repository         *Repository           `xml:"repository" json:"repository,omitempty"`
+	Origination        *Origination          `xml:"origination" json:"origination,omitempty"`
+	UnitTitle          *UnitTitle            `xml:"unittitle" json:"unittitle,omitempty"`
 	UnitDateStructured []*UnitDateStructured `xml:"unitdatestructured,omitempty" json:"unitdatestructured,omitempty"`
-	UnitDate           []*UnitDate           `xml:"unitdate,omitempty" json:"unitdates,omitempty"`
+	UnitDate           []*UnitDate           `xml:"unitdate,omitempty" json:"unitdate,omitempty"`
 	PhysDesc           *PhysDesc             `xml:"physdesc,omitempty" json:"physdesc,omitempty"`
 	PhysDescSet        *PhysDescSet          `xml:"physdescset,omitempty" json:"physdescset,omitempty"`
-	PhysDescStructured []*PhysDescStructured `xml:"physdescstructured" json:"physdescstructured"`
+	PhysDescStructured []*PhysDescStructured `xml:"physdescstructured" json:"physdescstructured,omitempty"`
 	UnitID             *UnitID               `xml:"unitid,omitempty" json:"unitid,omitempty"`
 	Abstract           *Abstract             `xml:"abstract,omitempty" json:"abstract,omitempty"`
 	DIDNote            *DIDNote              `xml:"didnote,omitempty" json:"didnote,omitempty"`
@@ -381,27 +418,62 @@ type DID struct {
 	PhysLoc            *PhysLoc              `xml:"physloc,omitempty" json:"physloc,omitempty"`
 	Container          []*Container          `xml:"container,omitempty" json:"container,omitempty"`
 	DAO                []*DAO                `xml:"dao,omitempty" json:"dao,omitempty"`
+	DAOSet             []*DAOSet             `xml:"daoset,omitempty" json:"daoset,omitempty"`
 }
 
 type Head struct {
 	XMLName   xml.Name `xml:"head" json:"-"`
 	AltRender string   `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
 	ID        string   `xml:"id,attr,omitempty" json:"id,omitempty"`
+	Audience  string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value     string   `xml:",innerxml" json:"value,omitempty"`
 }
 
 type MaterialSpec struct {
-	XMLName xml.Name `xml:"materialspec" json:"-"`
-	Value   string   `xml:",innerxml" json:"value,omitempty"`
+	XMLName  xml.Name `xml:"materialspec" json:"-"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Value    string   `xml:",innerxml" json:"value,omitempty"`
 }
 
 // DAO digital archival object
 type DAO struct {
 	XMLName         xml.Name         `xml:"dao" json:"-"`
+	ID              string           `xml:"id,attr,omitempty" json:"id,omitempty"`
 	HRef            string           `xml:"href,attr,omitempty" json:"href,omitempty"`
 	DOAType         string           `xml:"daotype,attr,omitempty" json:"daotype,omitempty"`
+	OtherDAOType    string           `xml:"otherdaotype,attr,omitempty" json:"otherdaotype,omitempty"`
+	Identifier      string           `xml:"identifier,attr,omitempty" json:"identifier,omitempty"`
+	LinkTitle       string           `xml:"linktitle,attr,omitempty" json:"linktitle,omitempty"`
+	LinkRole        string           `xml:"linkrole,attr,omitempty" json:"linkrole,omitempty"`
+	ArcRole         string           `xml:"arcrole,attr,omitempty" json:"arcrole,omitempty"`
+	LocalType       string           `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	Coverage        string           `xml:"coverage,attr,omitempty" json:"coverage,omitempty"`
+	EntityRef       string           `xml:"entityref,attr,omitempty" json:"entityref,omitempty"`
+	XPointer        string           `xml:"xpointer,attr,omitempty" json:"xpointer,omitempty"`
 	Show            string           `xml:"show,attr,omitempty" json:"show,omitempty"`
 	Actuate         string           `xml:"actuate,attr,omitempty" json:"actuate,omitempty"`
+	EncodingAnalog  string           `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender       string           `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience        string           `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang            string           `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script          string           `xml:"script,attr,omitempty" json:"script,omitempty"`
+	DescriptiveNote *DescriptiveNote `xml:"descriptivenote,omitempty" json:"descriptivenote,omitempty"`
+}
+
+// DAOSet groups the digital archival objects making up one resource, such as the
+// files of a multi-file digital object
+type DAOSet struct {
+	XMLName         xml.Name         `xml:"daoset" json:"-"`
+	ID              string           `xml:"id,attr,omitempty" json:"id,omitempty"`
+	Coverage        string           `xml:"coverage,attr,omitempty" json:"coverage,omitempty"`
+	Label           string           `xml:"label,attr,omitempty" json:"label,omitempty"`
+	LocalType       string           `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog  string           `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender       string           `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience        string           `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang            string           `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script          string           `xml:"script,attr,omitempty" json:"script,omitempty"`
+	DAO             []*DAO           `xml:"dao" json:"dao,omitempty"`
 	DescriptiveNote *DescriptiveNote `xml:"descriptivenote,omitempty" json:"descriptivenote,omitempty"`
 }
 
@@ -410,6 +482,7 @@ type Container struct {
 	XMLName     xml.Name `xml:"container" json:"-"`
 	ContainerID string   `xml:"containerid,attr,omitempty" json:"containerid,omitempty"`
 	LocalType   string   `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	Audience    string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value       string   `xml:",chardata" json:"value,omitempty"`
 }
 
@@ -418,25 +491,35 @@ type Repository struct {
 	XMLName        xml.Name    `xml:"repository" json:"-"`
 	Label          string      `xml:"label,attr,omitempty" json:"label,omitempty"`
 	EncodingAnalog string      `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	Audience       string      `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Persname       []*Persname `xml:"persname,omitempty" json:"persname,omitempty"`
 	Famname        []*Famname  `xml:"famname,omitempty" json:"famname,omitempty"`
 	CorpName       []*CorpName `xml:"corpname,omitempty" json:"corpname,omitempty"`
+	Name           []*Name     `xml:"name,omitempty" json:"name,omitempty"`
 }
 
 // CorpName - Corpus name
 type CorpName struct {
 	XMLName        xml.Name `xml:"corpname" json:"-"`
+	ID             string   `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string   `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	Lang           string   `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string   `xml:"script,attr,omitempty" json:"script,omitempty"`
+	AltRender      string   `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Identifier     string   `xml:"identifier,attr,omitempty" json:"identifier,omitempty"`
 	Source         string   `xml:"source,attr,omitempty" json:"source,omitempty"`
 	Rules          string   `xml:"rules,attr,omitempty" json:"rules,omitempty"`
 	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
 	Normal         string   `xml:"normal,attr,omitempty" json:"normal,omitempty"`
-	Part           []*Part  `xml:"part" json:"part"`
+	Part           []*Part  `xml:"part" json:"part,omitempty"`
 }
 
 // Part describes the part of a corpus or other organization
 type Part struct {
 	XMLName   xml.Name `xml:"part" json:"-"`
 	LocalType string   `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	Audience  string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value     string   `xml:",chardata" json:"value"`
 }
 
@@ -445,70 +528,164 @@ type Origination struct {
 	XMLName        xml.Name    `xml:"origination" json:"-"`
 	Label          string      `xml:"label,attr,omitempty" json:"label,omitempty"`
 	EncodingAnalog string      `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	Audience       string      `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Persname       []*Persname `xml:"persname,omitempty" json:"persname,omitempty"`
 	Famname        []*Famname  `xml:"famname,omitempty" json:"famname,omitempty"`
 	CorpName       []*CorpName `xml:"corpname,omitempty" json:"corpname,omitempty"`
+	Name           []*Name     `xml:"name,omitempty" json:"name,omitempty"`
 }
 
 // Persname is a person's name(s)
 type Persname struct {
 	XMLName        xml.Name `xml:"persname" json:"-"`
+	ID             string   `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string   `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	Lang           string   `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string   `xml:"script,attr,omitempty" json:"script,omitempty"`
+	AltRender      string   `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Identifier     string   `xml:"identifier,attr,omitempty" json:"identifier,omitempty"`
 	Source         string   `xml:"source,attr,omitempty" json:"source,omitempty"`
 	Relator        string   `xml:"relator,attr,omitempty" json:"relator,omitempty"`
 	Rules          string   `xml:"rules,attr,omitempty" json:"rules,omitempty"`
 	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
-	Part           []*Part  `xml:"part" json:"part"`
+	Normal         string   `xml:"normal,attr,omitempty" json:"normal,omitempty"`
+	Part           []*Part  `xml:"part" json:"part,omitempty"`
 }
 
 // Famname is a person's family name(s)
 type Famname struct {
 	XMLName        xml.Name `xml:"famname" json:"-"`
+	ID             string   `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string   `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	Lang           string   `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string   `xml:"script,attr,omitempty" json:"script,omitempty"`
+	AltRender      string   `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Identifier     string   `xml:"identifier,attr,omitempty" json:"identifier,omitempty"`
 	Source         string   `xml:"source,attr,omitempty" json:"source,omitempty"`
 	Relator        string   `xml:"relator,attr,omitempty" json:"relator,omitempty"`
 	Rules          string   `xml:"rules,attr,omitempty" json:"rules,omitempty"`
 	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
 	Normal         string   `xml:"normal,attr,omitempty" json:"normal,omitempty"`
-	Part           []*Part  `xml:"part" json:"part"`
+	Part           []*Part  `xml:"part" json:"part,omitempty"`
 }
 
 // Subject describes the subject of contents
 type Subject struct {
 	XMLName        xml.Name `xml:"subject" json:"-"`
+	ID             string   `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string   `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	Lang           string   `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string   `xml:"script,attr,omitempty" json:"script,omitempty"`
+	AltRender      string   `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Identifier     string   `xml:"identifier,attr,omitempty" json:"identifier,omitempty"`
 	Source         string   `xml:"source,attr,omitempty" json:"source,omitempty"`
 	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
 	Normal         string   `xml:"normal,attr,omitempty" json:"normal,omitempty"`
 	Rules          string   `xml:"rules,attr,omitempty" json:"rules,omitempty"`
-	Part           []*Part  `xml:"part" json:"part"`
+	Part           []*Part  `xml:"part" json:"part,omitempty"`
 }
 
 // GenreForm describe the genre of the contents
 type GenreForm struct {
 	XMLName        xml.Name `xml:"genreform" json:"-"`
-	Source         string   `xml:"source,attr,omitempty" json:"source"`
+	ID             string   `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string   `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	Lang           string   `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string   `xml:"script,attr,omitempty" json:"script,omitempty"`
+	AltRender      string   `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Identifier     string   `xml:"identifier,attr,omitempty" json:"identifier,omitempty"`
+	Source         string   `xml:"source,attr,omitempty" json:"source,omitempty"`
 	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
 	Normal         string   `xml:"normal,attr,omitempty" json:"normal,omitempty"`
 	Rules          string   `xml:"rules,attr,omitempty" json:"rules,omitempty"`
-	Part           []*Part  `xml:"part" json:"part"`
+	Part           []*Part  `xml:"part" json:"part,omitempty"`
 }
 
 // GeogName geographical name
 type GeogName struct {
-	XMLName        xml.Name `xml:"geogname" json:"-"`
-	Source         string   `xml:"source,attr,omitempty" json:"source"`
-	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
-	Normal         string   `xml:"normal,attr,omitempty" json:"normal,omitempty"`
-	Rules          string   `xml:"rules,attr,omitempty" json:"rules,omitempty"`
-	Part           []*Part  `xml:"part" json:"part"`
+	XMLName               xml.Name                 `xml:"geogname" json:"-"`
+	ID                    string                   `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType             string                   `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	Lang                  string                   `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script                string                   `xml:"script,attr,omitempty" json:"script,omitempty"`
+	AltRender             string                   `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience              string                   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Identifier            string                   `xml:"identifier,attr,omitempty" json:"identifier,omitempty"`
+	Source                string                   `xml:"source,attr,omitempty" json:"source,omitempty"`
+	EncodingAnalog        string                   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	Normal                string                   `xml:"normal,attr,omitempty" json:"normal,omitempty"`
+	Rules                 string                   `xml:"rules,attr,omitempty" json:"rules,omitempty"`
+	Part                  []*Part                  `xml:"part" json:"part,omitempty"`
+	GeographicCoordinates []*GeographicCoordinates `xml:"geographiccoordinates,omitempty" json:"geographiccoordinates,omitempty"`
+}
+
+// GeographicCoordinates gives the coordinates of a place in a coordinate system
+type GeographicCoordinates struct {
+	XMLName          xml.Name `xml:"geographiccoordinates" json:"-"`
+	ID               string   `xml:"id,attr,omitempty" json:"id,omitempty"`
+	CoordinateSystem string   `xml:"coordinatesystem,attr" json:"coordinatesystem"`
+	LocalType        string   `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	Lang             string   `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script           string   `xml:"script,attr,omitempty" json:"script,omitempty"`
+	AltRender        string   `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience         string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Value            string   `xml:",chardata" json:"value"`
 }
 
 // Occupation
 type Occupation struct {
 	XMLName        xml.Name `xml:"occupation" json:"-"`
-	Source         string   `xml:"source,attr,omitempty" json:"source"`
+	ID             string   `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string   `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	Lang           string   `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string   `xml:"script,attr,omitempty" json:"script,omitempty"`
+	AltRender      string   `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Identifier     string   `xml:"identifier,attr,omitempty" json:"identifier,omitempty"`
+	Source         string   `xml:"source,attr,omitempty" json:"source,omitempty"`
 	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
 	Normal         string   `xml:"normal,attr,omitempty" json:"normal,omitempty"`
 	Rules          string   `xml:"rules,attr,omitempty" json:"rules,omitempty"`
-	Part           []*Part  `xml:"part" json:"part"`
+	Part           []*Part  `xml:"part" json:"part,omitempty"`
+}
+
+// Function describes an activity or process that generated the materials
+type Function struct {
+	XMLName        xml.Name `xml:"function" json:"-"`
+	ID             string   `xml:"id,attr,omitempty" json:"id,omitempty"`
+	Identifier     string   `xml:"identifier,attr,omitempty" json:"identifier,omitempty"`
+	LocalType      string   `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	Lang           string   `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string   `xml:"script,attr,omitempty" json:"script,omitempty"`
+	AltRender      string   `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Source         string   `xml:"source,attr,omitempty" json:"source,omitempty"`
+	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	Normal         string   `xml:"normal,attr,omitempty" json:"normal,omitempty"`
+	Rules          string   `xml:"rules,attr,omitempty" json:"rules,omitempty"`
+	Part           []*Part  `xml:"part" json:"part,omitempty"`
+}
+
+// Name is a name that can't be identified as a person, family or corporate body
+type Name struct {
+	XMLName        xml.Name `xml:"name" json:"-"`
+	ID             string   `xml:"id,attr,omitempty" json:"id,omitempty"`
+	Identifier     string   `xml:"identifier,attr,omitempty" json:"identifier,omitempty"`
+	LocalType      string   `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	Lang           string   `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string   `xml:"script,attr,omitempty" json:"script,omitempty"`
+	AltRender      string   `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Source         string   `xml:"source,attr,omitempty" json:"source,omitempty"`
+	Relator        string   `xml:"relator,attr,omitempty" json:"relator,omitempty"`
+	Rules          string   `xml:"rules,attr,omitempty" json:"rules,omitempty"`
+	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	Normal         string   `xml:"normal,attr,omitempty" json:"normal,omitempty"`
+	Part           []*Part  `xml:"part" json:"part,omitempty"`
 }
 
 // UnitTitle is a title for a specific unit of collect contents
@@ -516,68 +693,92 @@ type UnitTitle struct {
 	XMLName        xml.Name `xml:"unittitle" json:"-"`
 	Label          string   `xml:"label,attr,omitempty" json:"label,omitempty"`
 	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	Audience       string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value          string   `xml:",innerxml" json:"value"`
 }
 
 // UnitDateStructured provides a descriptive structure of date information related to the unit of content
 type UnitDateStructured struct {
-	XMLName        xml.Name     `xml:"unitdatestructured" json"-"`
-	XMLNameSpace   string       `xml:"xmlns,attr,omitempty", json:"-"`
+	XMLName        xml.Name     `xml:"unitdatestructured" json:"-"`
+	XMLNameSpace   string       `xml:"xmlns,attr,omitempty" json:"-"`
 	Label          string       `xml:"label,attr,omitempty" json:"label,omitempty"`
 	Era            string       `xml:"era,attr,omitempty" json:"era,omitempty"`
 	Certainty      string       `xml:"certainty,attr,omitempty" json:"certainty,omitempty"`
 	UnitDateType   string       `xml:"unitdatetype,attr,omitempty" json:"unitdatetype,omitempty"`
 	EncodingAnalog string       `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	Audience       string       `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	DateSingle     *DateSingle  `xml:"datesingle,omitempty" json:"datesingle,omitempty"`
 	DateRange      []*DateRange `xml:"daterange,omitempty" json:"daterange,omitempty"`
+	DateSet        *DateSet     `xml:"dateset,omitempty" json:"dateset,omitempty"`
+}
+
+// DateSet groups two or more single dates and date ranges describing the same thing
+type DateSet struct {
+	XMLName        xml.Name      `xml:"dateset" json:"-"`
+	ID             string        `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string        `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog string        `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string        `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string        `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string        `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string        `xml:"script,attr,omitempty" json:"script,omitempty"`
+	DateSingle     []*DateSingle `xml:"datesingle,omitempty" json:"datesingle,omitempty"`
+	DateRange      []*DateRange  `xml:"daterange,omitempty" json:"daterange,omitempty"`
 }
 
 // UnitDate provides a simpler date structure relating to content
 type UnitDate struct {
 	XMLName        xml.Name `xml:"unitdate" json:"-"`
-	XMLNameSpace   string   `xml:"xmlns,attr,omitempty", json:"-"`
+	XMLNameSpace   string   `xml:"xmlns,attr,omitempty" json:"-"`
 	Normal         string   `xml:"normal,attr,omitempty" json:"normal,omitempty"`
 	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
 	Lang           string   `xml:"lang,attr,omitempty" json:"lang,omitempty"`
 	Script         string   `xml:"script,attr,omitempty" json:"script,omitempty"`
 	Certainty      string   `xml:"certainty,attr,omitempty" json:"certainty,omitempty"`
-	UnitDateType   string   `xml:"unitdatetype,attr,omitempty" json:"unitdatetype"`
+	UnitDateType   string   `xml:"unitdatetype,attr,omitempty" json:"unitdatetype,omitempty"`
 	Label          string   `xml:"label,attr,omitempty" json:"label,omitempty"`
+	Audience       string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value          string   `xml:",chardata" json:"value"`
 }
 
 // DateRange describes the start and end dates
 type DateRange struct {
-	XMLName      xml.Name  `xml:"daterange" json"-"`
-	XMLNameSpace string    `xml:"xmlns,attr,omitempty", json:"-"`
-	FromDate     *FromDate `xml:"fromdate" json:"fromdate"`
-	ToDate       *ToDate   `xml:"todate" json:"todate"`
+	XMLName      xml.Name  `xml:"daterange" json:"-"`
+	XMLNameSpace string    `xml:"xmlns,attr,omitempty" json:"-"`
+	Audience     string    `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	FromDate     *FromDate `xml:"fromdate" json:"fromdate,omitempty"`
+	ToDate       *ToDate   `xml:"todate" json:"todate,omitempty"`
 }
 
 // FromDate holds the start position in a range of dates
 type FromDate struct {
 	XMLName      xml.Name `xml:"fromdate" json:"-"`
-	XMLNameSpace string   `xml:"xmlns,attr,omitempty", json:"-"`
-	NotBefore    string   `xml:"notbefore,attr,omitempty" json:"notbefore"`
-	NotAfter     string   `xml:"notafter,attr,omitempty" json:"notafter"`
+	XMLNameSpace string   `xml:"xmlns,attr,omitempty" json:"-"`
+	NotBefore    string   `xml:"notbefore,attr,omitempty" json:"notbefore,omitempty"`
+	NotAfter     string   `xml:"notafter,attr,omitempty" json:"notafter,omitempty"`
 	StandardDate string   `xml:"standarddate,attr,omitempty" json:"standarddate,omitempty"`
+	Audience     string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value        string   `xml:",chardata" json:"value,omitempty"`
 }
 
 // ToDate holds the end position in a range of dates
 type ToDate struct {
 	XMLName      xml.Name `xml:"todate" json:"-"`
-	XMLNameSpace string   `xml:"xmlns,attr,omitempty", json:"-"`
-	NotBefore    string   `xml:"notbefore,attr,omitempty" json:"notbefore"`
-	NotAfter     string   `xml:"notafter,attr,omitempty" json:"notafter"`
+	XMLNameSpace string   `xml:"xmlns,attr,omitempty" json:"-"`
+	NotBefore    string   `xml:"notbefore,attr,omitempty" json:"notbefore,omitempty"`
+	NotAfter     string   `xml:"notafter,attr,omitempty" json:"notafter,omitempty"`
 	StandardDate string   `xml:"standarddate,attr,omitempty" json:"standarddate,omitempty"`
+	Audience     string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value        string   `xml:",chardata" json:"value,omitempty"`
 }
 
 type DateSingle struct {
 	XMLName      xml.Name `xml:"datesingle" json:"-"`
+	NotBefore    string   `xml:"notbefore,attr,omitempty" json:"notbefore,omitempty"`
+	NotAfter     string   `xml:"notafter,attr,omitempty" json:"notafter,omitempty"`
 	StandardDate string   `xml:"standarddate,attr,omitempty" json:"standarddate,omitempty"`
 	Normal       string   `xml:"normal,attr,omitempty" json:"normal,omitempty"`
+	Audience     string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value        string   `xml:",chardata" json:"value,omitempty"`
 }
 
@@ -586,6 +787,7 @@ type Date struct {
 	Normal         string   `xml:"normal,attr,omitempty" json:"normal,omitempty"`
 	StandardDate   string   `xml:"standarddate,attr,omitempty" json:"standarddate,omitempty"`
 	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	Audience       string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value          string   `xml:",chardata" json:"value,omitempty"`
 }
 
@@ -594,13 +796,15 @@ type PhysDesc struct {
 	XMLName        xml.Name `xml:"physdesc" json:"-"`
 	Label          string   `xml:"label,attr,omitempty" json:"label,omitempty"`
 	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	Audience       string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value          string   `xml:",chardata" json:"value"`
 }
 
 // PhysDescSet describes a grouping of physical descriptions of content
 type PhysDescSet struct {
 	XMLName            xml.Name              `xml:"physdescset" json:"-"`
-	PhysDescStructured []*PhysDescStructured `xml:"physdescstructured" json:"physdescstructured"`
+	Audience           string                `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	PhysDescStructured []*PhysDescStructured `xml:"physdescstructured" json:"physdescstructured,omitempty"`
 }
 
 // PhysDescStructured provides a structured set of physical descriptions
@@ -610,6 +814,7 @@ type PhysDescStructured struct {
 	PhysDescStructuredType string      `xml:"physdescstructuredtype,attr,omitempty" json:"physdescstructuredtype,omitempty"`
 	Coverage               string      `xml:"coverage,attr,omitempty" json:"coverage,omitempty"`
 	EncodingAnalog         string      `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	Audience               string      `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Quantity               *Quantity   `xml:"quantity,omitempty" json:"quantity,omitempty"`
 	UnitType               *UnitType   `xml:"unittype,omitempty" json:"unittype,omitempty"`
 	PhysFacet              *PhysFacet  `xml:"physfacet,omitempty" json:"physfacet,omitempty"`
@@ -621,6 +826,7 @@ type Dimensions struct {
 	XMLName   xml.Name `xml:"dimensions" json:"-"`
 	LocalType string   `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
 	Unit      string   `xml:"unit,attr,omitempty" json:"unit,omitempty"`
+	Audience  string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value     string   `xml:",chardata" json:"value,omitempty"`
 }
 
@@ -628,6 +834,7 @@ type Dimensions struct {
 type Quantity struct {
 	XMLName     xml.Name `xml:"quantity" json:"-"`
 	Approximate string   `xml:"approximate,attr,omitempty" json:"approximate,omitempty"`
+	Audience    string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value       string   `xml:",chardata" json:"value,omitempty"`
 }
 
@@ -635,14 +842,16 @@ type Quantity struct {
 type PhysFacet struct {
 	XMLName   xml.Name `xml:"physfacet" json:"-"`
 	LocalType string   `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	Audience  string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value     string   `xml:",chardata" json:"value,omitempty"`
 }
 
 // UnitType
 type UnitType struct {
-	XMLName xml.Name `xml:"unittype" json:"-"`
-	Source  string   `xml:"source,attr,omitempty" json:"source,omitempty"`
-	Value   string   `xml:",chardata" json:"value,omitempty"`
+	XMLName  xml.Name `xml:"unittype" json:"-"`
+	Source   string   `xml:"source,attr,omitempty" json:"source,omitempty"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Value    string   `xml:",chardata" json:"value,omitempty"`
 }
 
 // UnitID provides a unit level identifier
@@ -652,6 +861,7 @@ type UnitID struct {
 	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
 	CountryCode    string   `xml:"countrycode,attr,omitempty" json:"countrycode,omitempty"`
 	RepositoryCode string   `xml:"repositorycode,attr,omitempty" json:"repositorycode,omitempty"`
+	Audience       string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value          string   `xml:",chardata" json:"value"`
 }
 
@@ -660,14 +870,16 @@ type Abstract struct {
 	XMLName        xml.Name `xml:"abstract" json:"-"`
 	Label          string   `xml:"label,attr,omitempty" json:"label,omitempty"`
 	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	Audience       string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value          string   `xml:",chardata" json:"value"`
 }
 
 // DIDNote are notes on the describe digital identifier
 type DIDNote struct {
-	XMLName xml.Name `xml:"didnote" json:"-"`
-	Label   string   `xml:"label,attr,omitempty" json:"label,omitempty"`
-	Value   string   `xml:",chardata" json:"value"`
+	XMLName  xml.Name `xml:"didnote" json:"-"`
+	Label    string   `xml:"label,attr,omitempty" json:"label,omitempty"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Value    string   `xml:",chardata" json:"value"`
 }
 
 // LangMaterial describes the material's language characteristics which could differ from document relating to content
@@ -675,109 +887,203 @@ type LangMaterial struct {
 	XMLName         xml.Name         `xml:"langmaterial" json:"-"`
 	Label           string           `xml:"label,attr,omitempty" json:"label,omitempty"`
 	EncodingAnalog  string           `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
-	Language        *Language        `xml:"language,omitempty" json:"language,omitempty"`
+	Audience        string           `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Language        []*Language      `xml:"language,omitempty" json:"language,omitempty"`
+	LanguageSet     []*LanguageSet   `xml:"languageset,omitempty" json:"languageset,omitempty"`
+	DescriptiveNote *DescriptiveNote `xml:"descriptivenote,omitempty" json:"descriptivenote,omitempty"`
+}
+
+// LanguageSet pairs the languages of the material with the scripts they are written in
+type LanguageSet struct {
+	XMLName         xml.Name         `xml:"languageset" json:"-"`
+	ID              string           `xml:"id,attr,omitempty" json:"id,omitempty"`
+	Label           string           `xml:"label,attr,omitempty" json:"label,omitempty"`
+	AltRender       string           `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience        string           `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang            string           `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script          []*Script        `xml:"script" json:"script,omitempty"`
+	Language        []*Language      `xml:"language" json:"language,omitempty"`
 	DescriptiveNote *DescriptiveNote `xml:"descriptivenote,omitempty" json:"descriptivenote,omitempty"`
 }
 
 // DescriptiveNote is a descriptive note about the content
 type DescriptiveNote struct {
-	XMLName xml.Name `xml:"descriptivenote" json:"-"`
-	P       []*P     `xml:"p,omitempty" json:"p,omitempty"`
-	Title   string   `xml:"title,omitempty" json:"title,omitempty"`
+	XMLName  xml.Name `xml:"descriptivenote" json:"-"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	P        []*P     `xml:"p,omitempty" json:"p,omitempty"`
+	Title    string   `xml:"title,omitempty" json:"title,omitempty"`
 }
 
 // PhysLoc describes the physical location of the content
 type PhysLoc struct {
-	XMLName xml.Name `xml:"physloc" json:"-"`
-	Label   string   `xml:"label,attr,omitempty" json:"label,omitempty"`
-	Value   string   `xml:",chardata" json:"value"`
+	XMLName  xml.Name `xml:"physloc" json:"-"`
+	Label    string   `xml:"label,attr,omitempty" json:"label,omitempty"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Value    string   `xml:",chardata" json:"value"`
 }
 
 // Bibliography information
 type Bibliography struct {
-	XMLName xml.Name  `xml:"bibliography" json:"-"`
-	Head    *Head     `xml:"head,omitempty" json:"head"`
-	BibRef  []*BibRef `xml:"bibref,omitempty" json:"bibref,omitempty"`
+	XMLName        xml.Name        `xml:"bibliography" json:"-"`
+	ID             string          `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string          `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog string          `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string          `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string          `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string          `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string          `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head           *Head           `xml:"head,omitempty" json:"head,omitempty"`
+	P              []*P            `xml:"p,omitempty" json:"p,omitempty"`
+	List           []*List         `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList      []*ChronList    `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table          []*Table        `xml:"table,omitempty" json:"table,omitempty"`
+	BlockQuote     []*BlockQuote   `xml:"blockquote,omitempty" json:"blockquote,omitempty"`
+	ArchRef        []*ArchRef      `xml:"archref,omitempty" json:"archref,omitempty"`
+	BibRef         []*BibRef       `xml:"bibref,omitempty" json:"bibref,omitempty"`
+	Bibliography   []*Bibliography `xml:"bibliography,omitempty" json:"bibliography,omitempty"`
 }
 
 // BibRef a specific reference
 type BibRef struct {
-	XMLName xml.Name `xml:"bibref" json:"-"`
-	Value   string   `xml:",innerxml" json:"value,omitempty"`
-	Ref     *Ref     `xml:"ref,omitempty" json:"ref,omitempty"`
+	XMLName  xml.Name `xml:"bibref" json:"-"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Value    string   `xml:",innerxml" json:"value,omitempty"`
+	Ref      *Ref     `xml:"ref,omitempty" json:"ref,omitempty"`
 }
 
 // BiogHist provides biographical history of content
 type BiogHist struct {
-	XMLName        xml.Name   `xml:"bioghist" json:"-"`
-	ID             string     `xml:"id,attr,omitempty" json:"id,omitempty"`
-	EncodingAnalog string     `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
-	Head           *Head      `xml:"head,omitempty" json:"head,omitempty"`
-	P              []*P       `xml:"p,omitempty" json:"p,omitempty"`
-	ChronList      *ChronList `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
-	T              *Table     `xml:"table,omitempty" json:"table,omitempty"`
+	XMLName        xml.Name      `xml:"bioghist" json:"-"`
+	ID             string        `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string        `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog string        `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string        `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string        `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string        `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string        `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head           *Head         `xml:"head,omitempty" json:"head,omitempty"`
+	P              []*P          `xml:"p,omitempty" json:"p,omitempty"`
+	List           []*List       `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList      []*ChronList  `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table          []*Table      `xml:"table,omitempty" json:"table,omitempty"`
+	BlockQuote     []*BlockQuote `xml:"blockquote,omitempty" json:"blockquote,omitempty"`
+	BiogHist       []*BiogHist   `xml:"bioghist,omitempty" json:"bioghist,omitempty"`
 }
 
 type ChronList struct {
 	XMLName   xml.Name     `xml:"chronlist" json:"-"`
+	Audience  string       `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	ChronItem []*ChronItem `xml:"chronitem,omitempty" json:"chronitem,omitempty"`
 }
 
 type ChronItem struct {
-	XMLName    xml.Name    `xml:"chronitem" json:"-"`
-	DateSingle *DateSingle `xml:"datesingle,omitempty" json:"datesingle,omitempty"`
-	Event      *Event      `xml:"event,omitempty" json:"event,omitempty"`
+	XMLName      xml.Name      `xml:"chronitem" json:"-"`
+	ID           string        `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType    string        `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	AltRender    string        `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience     string        `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	DateSingle   *DateSingle   `xml:"datesingle,omitempty" json:"datesingle,omitempty"`
+	DateRange    *DateRange    `xml:"daterange,omitempty" json:"daterange,omitempty"`
+	DateSet      *DateSet      `xml:"dateset,omitempty" json:"dateset,omitempty"`
+	GeogName     *GeogName     `xml:"geogname,omitempty" json:"geogname,omitempty"`
+	Event        *Event        `xml:"event,omitempty" json:"event,omitempty"`
+	ChronItemSet *ChronItemSet `xml:"chronitemset,omitempty" json:"chronitemset,omitempty"`
+}
+
+// ChronItemSet holds several events, and the places they happened, sharing the date of
+// a ChronItem
+type ChronItemSet struct {
+	XMLName   xml.Name    `xml:"chronitemset" json:"-"`
+	ID        string      `xml:"id,attr,omitempty" json:"id,omitempty"`
+	AltRender string      `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience  string      `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	GeogName  []*GeogName `xml:"geogname,omitempty" json:"geogname,omitempty"`
+	Event     []*Event    `xml:"event" json:"event,omitempty"`
 }
 
 type Event struct {
-	XMLName xml.Name `xml:"event" json:"-"`
-	Value   string   `xml:",innerxml" json:"value,omitempty"`
+	XMLName  xml.Name `xml:"event" json:"-"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Value    string   `xml:",innerxml" json:"value,omitempty"`
 }
 
 // ScopeContent provides scoping material for content
 type ScopeContent struct {
-	XMLName        xml.Name `xml:"scopecontent" json:"-"`
-	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
-	Head           *Head    `xml:"head,omitempty" json:"head,omitempty"`
-	P              []*P     `xml:"p,omitempty" json:"p,omitempty"`
+	XMLName        xml.Name        `xml:"scopecontent" json:"-"`
+	ID             string          `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string          `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog string          `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string          `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string          `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string          `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string          `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head           *Head           `xml:"head,omitempty" json:"head,omitempty"`
+	P              []*P            `xml:"p,omitempty" json:"p,omitempty"`
+	List           []*List         `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList      []*ChronList    `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table          []*Table        `xml:"table,omitempty" json:"table,omitempty"`
+	BlockQuote     []*BlockQuote   `xml:"blockquote,omitempty" json:"blockquote,omitempty"`
+	ScopeContent   []*ScopeContent `xml:"scopecontent,omitempty" json:"scopecontent,omitempty"`
 }
 
 // Arrangement describes the orientation of material?????
 type Arrangement struct {
-	XMLName        xml.Name `xml:"arrangement" json:"-"`
-	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
-	Head           *Head    `xml:"head,omitempty" json:"head,omitempty"`
-	P              []*P     `xml:"p,omitempty" json:"p,omitempty"`
-	List           *List    `xml:"list,omitempty" json:"list,omitempty"`
+	XMLName        xml.Name       `xml:"arrangement" json:"-"`
+	ID             string         `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string         `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog string         `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string         `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string         `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string         `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string         `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head           *Head          `xml:"head,omitempty" json:"head,omitempty"`
+	P              []*P           `xml:"p,omitempty" json:"p,omitempty"`
+	List           []*List        `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList      []*ChronList   `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table          []*Table       `xml:"table,omitempty" json:"table,omitempty"`
+	BlockQuote     []*BlockQuote  `xml:"blockquote,omitempty" json:"blockquote,omitempty"`
+	Arrangement    []*Arrangement `xml:"arrangement,omitempty" json:"arrangement,omitempty"`
+}
+
+// BlockQuote is an extended quotation held as embedded XML
+type BlockQuote struct {
+	XMLName  xml.Name `xml:"blockquote" json:"-"`
+	ID       string   `xml:"id,attr,omitempty" json:"id,omitempty"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang     string   `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script   string   `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Value    string   `xml:",innerxml" json:"value,omitempty"`
 }
 
 // List provides a list of reference to an item
 type List struct {
 	XMLName  xml.Name `xml:"list" json:"-"`
 	ListType string   `xml:"listtype,attr,omitempty" json:"listtype,omitempty"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	Value    string   `xml:",innerxml" json:"value,omitempty"`
 	// ListHead *ListHead  `xml:"listhead,omitempty" json:"listhead,omitempty"`
 	// DefItem  []*DefItem `xml:"defitem,omitempty" json:"defitem,omitempty"`
 	// Item     []*Item    `xml:"item,omitempty" json:"item,omitempty"`
 }
 
-// type ListHead struct {
-// 	XMLName xml.Name `xml:"listtype" json:"-"`
-// 	Value   string   `xml:",innerxml" json:"value"`
-// }
+//	type ListHead struct {
+//		XMLName xml.Name `xml:"listtype" json:"-"`
+//		Value   string   `xml:",innerxml" json:"value"`
+//	}
 //
-// type DefItem struct {
-// 	XMLName xml.Name `xml:"defitem" json:"-"`
-// 	Label   string   `xml:"label,omitempty" json:"label,omitempty"`
-// 	Item    *Item    `xml:"item,omitempty" json:"item,omitempty"`
-// }
+//	type DefItem struct {
+//		XMLName xml.Name `xml:"defitem" json:"-"`
+//		Label   string   `xml:"label,omitempty" json:"label,omitempty"`
+//		Item    *Item    `xml:"item,omitempty" json:"item,omitempty"`
+//	}
 //
 // // Item provides a reference link to an item
-// type Item struct {
-// 	XMLName xml.Name `xml:"item" json:"-"`
-// 	Ref     *Ref     `xml:"ref,omtempty" json:"ref,omitempty"`
-// 	Value   string   `xml:",chardata" json:"value,omitempty"`
-// }
+//
+//	type Item struct {
+//		XMLName xml.Name `xml:"item" json:"-"`
+//		Ref     *Ref     `xml:"ref,omtempty" json:"ref,omitempty"`
+//		Value   string   `xml:",chardata" json:"value,omitempty"`
+//	}
 //
 // Ref is a link to something
 type Ref struct {
@@ -787,14 +1093,66 @@ type Ref struct {
 	Show         string   `xml:"show,attr,omitempty" json:"show,omitempty"`
 	Actuate      string   `xml:"actuate,attr,omitempty" json:"actuate,omitempty"`
 	//InstanceURL  string   `xml:"instanceurl,attr,omitempty" json:"instanceurl,omitempty"`
-	Target string `xml:"target,attr,omitempty" json:"target,omitempty"`
-	Value  string `xml:",chardata" json:"value,omitempty"`
+	Target   string `xml:"target,attr,omitempty" json:"target,omitempty"`
+	Audience string `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Value    string `xml:",chardata" json:"value,omitempty"`
+}
+
+// Ptr is an empty link to an element of the finding aid, identified by Target, or to
+// an external resource
+type Ptr struct {
+	XMLName   xml.Name `xml:"ptr" json:"-"`
+	ID        string   `xml:"id,attr,omitempty" json:"id,omitempty"`
+	Target    string   `xml:"target,attr,omitempty" json:"target,omitempty"`
+	HRef      string   `xml:"href,attr,omitempty" json:"href,omitempty"`
+	XPointer  string   `xml:"xpointer,attr,omitempty" json:"xpointer,omitempty"`
+	EntityRef string   `xml:"entityref,attr,omitempty" json:"entityref,omitempty"`
+	LinkTitle string   `xml:"linktitle,attr,omitempty" json:"linktitle,omitempty"`
+	LinkRole  string   `xml:"linkrole,attr,omitempty" json:"linkrole,omitempty"`
+	ArcRole   string   `xml:"arcrole,attr,omitempty" json:"arcrole,omitempty"`
+	Show      string   `xml:"show,attr,omitempty" json:"show,omitempty"`
+	Actuate   string   `xml:"actuate,attr,omitempty" json:"actuate,omitempty"`
+	AltRender string   `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience  string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+}
+
+// PtrGrp groups the pointers and references of an index entry
+type PtrGrp struct {
+	XMLName   xml.Name `xml:"ptrgrp" json:"-"`
+	ID        string   `xml:"id,attr,omitempty" json:"id,omitempty"`
+	AltRender string   `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience  string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Ptr       []*Ptr   `xml:"ptr,omitempty" json:"ptr,omitempty"`
+	Ref       []*Ref   `xml:"ref,omitempty" json:"ref,omitempty"`
+}
+
+// NameGrp groups the access terms of an index entry
+type NameGrp struct {
+	XMLName    xml.Name      `xml:"namegrp" json:"-"`
+	ID         string        `xml:"id,attr,omitempty" json:"id,omitempty"`
+	AltRender  string        `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience   string        `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Persname   []*Persname   `xml:"persname,omitempty" json:"persname,omitempty"`
+	Famname    []*Famname    `xml:"famname,omitempty" json:"famname,omitempty"`
+	CorpName   []*CorpName   `xml:"corpname,omitempty" json:"corpname,omitempty"`
+	Subject    []*Subject    `xml:"subject,omitempty" json:"subject,omitempty"`
+	GenreForm  []*GenreForm  `xml:"genreform,omitempty" json:"genreform,omitempty"`
+	GeogName   []*GeogName   `xml:"geogname,omitempty" json:"geogname,omitempty"`
+	Occupation []*Occupation `xml:"occupation,omitempty" json:"occupation,omitempty"`
+	Function   []*Function   `xml:"function,omitempty" json:"function,omitempty"`
+	Name       []*Name       `xml:"name,omitempty" json:"name,omitempty"`
 }
 
 // ControlAccess describes who can do what
 type ControlAccess struct {
 	XMLName        xml.Name         `xml:"controlaccess" json:"-"`
+	ID             string           `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string           `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
 	EncodingAnalog string           `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string           `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string           `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string           `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string           `xml:"script,attr,omitempty" json:"script,omitempty"`
 	Head           *Head            `xml:"head,omitempty" json:"head,omitempty"`
 	P              []*P             `xml:"p,omitempty" json:"p,omitempty"`
 	Persname       []*Persname      `xml:"persname,omitempty" json:"persname,omitempty"`
@@ -804,315 +1162,719 @@ type ControlAccess struct {
 	GenreForm      []*GenreForm     `xml:"genreform,omitempty" json:"genreform,omitempty"`
 	GeogName       []*GeogName      `xml:"geogname,omitempty" json:"geogname,omitempty"`
 	Occupation     []*Occupation    `xml:"occupation,omitempty" json:"occupation,omitempty"`
+	Function       []*Function      `xml:"function,omitempty" json:"function,omitempty"`
+	Name           []*Name          `xml:"name,omitempty" json:"name,omitempty"`
 	ControlAccess  []*ControlAccess `xml:"controlaccess,omitempty" json:"controlaccess,omitempty"`
 }
 
 // RelatedMaterial provides links out to related material
 type RelatedMaterial struct {
-	XMLName        xml.Name `xml:"relatedmaterial" json:"-"`
-	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
-	Head           *Head    `xml:"head,omitempty" json:"head,omitempty"`
-	P              []*P     `xml:"p,omitempty" json:"p,omitempty"`
-	ArchRef        *ArchRef `xml:"archref,omitempty" json:"archref,omitempty"`
-	List           *List    `xml:"list,omitempty" json:"list,omitempty"`
+	XMLName         xml.Name           `xml:"relatedmaterial" json:"-"`
+	ID              string             `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType       string             `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog  string             `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender       string             `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience        string             `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang            string             `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script          string             `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head            *Head              `xml:"head,omitempty" json:"head,omitempty"`
+	P               []*P               `xml:"p,omitempty" json:"p,omitempty"`
+	List            []*List            `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList       []*ChronList       `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table           []*Table           `xml:"table,omitempty" json:"table,omitempty"`
+	BlockQuote      []*BlockQuote      `xml:"blockquote,omitempty" json:"blockquote,omitempty"`
+	ArchRef         []*ArchRef         `xml:"archref,omitempty" json:"archref,omitempty"`
+	BibRef          []*BibRef          `xml:"bibref,omitempty" json:"bibref,omitempty"`
+	RelatedMaterial []*RelatedMaterial `xml:"relatedmaterial,omitempty" json:"relatedmaterial,omitempty"`
 }
 
 // Relations
 type Relations struct {
-	XMLName  xml.Name    `xml:"relations" json:"-"`
-	Relation []*Relation `xml:"relation,omitempty" json:"relation,omitempty"`
-}
-
-// Relation
+	XMLName   xml.Name    `xml:"relations" json:"-"`
+	ID        string      `xml:"id,attr,omitempty" json:"id,omitempty"`
+	AltRender string      `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience  string      `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang      string      `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script    string      `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Relation  []*Relation `xml:"relation,omitempty" json:"relation,omitempty"`
+}
+
+// Relation types allowed for relation@relationtype
+const (
+	CPFRelation       = "cpfrelation"
+	ResourceRelation  = "resourcerelation"
+	FunctionRelation  = "functionrelation"
+	OtherRelationType = "otherrelationtype"
+)
+
+// Relation describes a corporate body, person, family, function or resource related
+// to the materials
 type Relation struct {
-	XMLName          xml.Name         `xml:"relation" json:"-"`
-	ID               string           `xml:"id,attr,omitempty" json:"id,omitempty"`
-	RelationshipType string           `xml:"relationtype,attr,omitempty" json:"relationtype,omitempty"`
-	Show             string           `xml:"show,attr,omitempty" json:"show,omitempty"`
-	HRef             string           `xml:"href,attr,omitempty" json:"href,omitempty"`
-	Actuate          string           `xml:"actuate,attr,omitempty" json:"accuate,omitempty"`
-	RelationEntry    string           `xml:"relationentry,omitempty" json:"relationentry,omitempty"`
-	DescriptiveNote  *DescriptiveNote `xml:"descriptivenote,omitempty" json:"descriptivenote,omitempty"`
+	XMLName              xml.Name         `xml:"relation" json:"-"`
+	ID                   string           `xml:"id,attr,omitempty" json:"id,omitempty"`
+	RelationshipType     string           `xml:"relationtype,attr,omitempty" json:"relationtype,omitempty"`
+	OtherRelationType    string           `xml:"otherrelationtype,attr,omitempty" json:"otherrelationtype,omitempty"`
+	Show                 string           `xml:"show,attr,omitempty" json:"show,omitempty"`
+	HRef                 string           `xml:"href,attr,omitempty" json:"href,omitempty"`
+	Actuate              string           `xml:"actuate,attr,omitempty" json:"actuate,omitempty"`
+	ArcRole              string           `xml:"arcrole,attr,omitempty" json:"arcrole,omitempty"`
+	LinkRole             string           `xml:"linkrole,attr,omitempty" json:"linkrole,omitempty"`
+	LinkTitle            string           `xml:"linktitle,attr,omitempty" json:"linktitle,omitempty"`
+	LastDateTimeVerified string           `xml:"lastdatetimeverified,attr,omitempty" json:"lastdatetimeverified,omitempty"`
+	EncodingAnalog       string           `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender            string           `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience             string           `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang                 string           `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script               string           `xml:"script,attr,omitempty" json:"script,omitempty"`
+	RelationEntry        []*RelationEntry `xml:"relationentry,omitempty" json:"relationentry,omitempty"`
+	ObjectXMLWrap        *ObjectXMLWrap   `xml:"objectxmlwrap,omitempty" json:"objectxmlwrap,omitempty"`
+	DateRange            *DateRange       `xml:"daterange,omitempty" json:"daterange,omitempty"`
+	DateSingle           *DateSingle      `xml:"datesingle,omitempty" json:"datesingle,omitempty"`
+	DateSet              *DateSet         `xml:"dateset,omitempty" json:"dateset,omitempty"`
+	GeogName             *GeogName        `xml:"geogname,omitempty" json:"geogname,omitempty"`
+	DescriptiveNote      *DescriptiveNote `xml:"descriptivenote,omitempty" json:"descriptivenote,omitempty"`
+}
+
+// RelationEntry names the related entity
+type RelationEntry struct {
+	XMLName         xml.Name `xml:"relationentry" json:"-"`
+	ID              string   `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType       string   `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	Transliteration string   `xml:"transliteration,attr,omitempty" json:"transliteration,omitempty"`
+	EncodingAnalog  string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender       string   `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience        string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang            string   `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script          string   `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Value           string   `xml:",chardata" json:"value"`
 }
 
 // ArchRef archival reference
 type ArchRef struct {
-	XMLName xml.Name `xml:"archref" json:"-"`
-	Value   string   `xml:",innerxml" json:"value,omitempty"`
+	XMLName  xml.Name `xml:"archref" json:"-"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Value    string   `xml:",innerxml" json:"value,omitempty"`
 }
 
 // AccessRestrict describes the containstraints under which items can be accessed
 type AccessRestrict struct {
-	XMLName        xml.Name `xml:"accessrestrict" json:"-"`
-	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
-	Head           string   `xml:"head,omitempty" json:"head,omitempty"`
-	P              []*P     `xml:"p,omitempty" json:"p,omitempty"`
-	List           *List    `xml:"list,omitempty" json:"list,omitempty"`
+	XMLName        xml.Name          `xml:"accessrestrict" json:"-"`
+	ID             string            `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string            `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog string            `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string            `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string            `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string            `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string            `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head           *Head             `xml:"head,omitempty" json:"head,omitempty"`
+	P              []*P              `xml:"p,omitempty" json:"p,omitempty"`
+	List           []*List           `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList      []*ChronList      `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table          []*Table          `xml:"table,omitempty" json:"table,omitempty"`
+	BlockQuote     []*BlockQuote     `xml:"blockquote,omitempty" json:"blockquote,omitempty"`
+	AccessRestrict []*AccessRestrict `xml:"accessrestrict,omitempty" json:"accessrestrict,omitempty"`
 }
 
 // UseRestrict describe use restrictions that access to an item allows
 type UseRestrict struct {
-	XMLName        xml.Name `xml:"userestrict" json:"-"`
-	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
-	Head           string   `xml:"head,omitempty" json:"head,omitempty"`
-	P              []*P     `xml:"p,omitempty" json:"p,omitempty"`
-	Table          *Table   `xml:"table,omitempty" json:"table,omitempty"`
+	XMLName        xml.Name       `xml:"userestrict" json:"-"`
+	ID             string         `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string         `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog string         `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string         `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string         `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string         `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string         `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head           *Head          `xml:"head,omitempty" json:"head,omitempty"`
+	P              []*P           `xml:"p,omitempty" json:"p,omitempty"`
+	List           []*List        `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList      []*ChronList   `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table          []*Table       `xml:"table,omitempty" json:"table,omitempty"`
+	BlockQuote     []*BlockQuote  `xml:"blockquote,omitempty" json:"blockquote,omitempty"`
+	UseRestrict    []*UseRestrict `xml:"userestrict,omitempty" json:"userestrict,omitempty"`
 }
 
 type Table struct {
-	XMLName xml.Name `xml:"table" json:"table"`
-	Frame   string   `xml:"frame,attr,omitempty" json:"frame,omitempty"`
-	Colsep  string   `xml:"colsep,attr,omitempty" json:"colsep,omitempty"`
-	Rowsep  string   `xml:"rowsep,attr,omitempty" json:"rowsep,omitempty"`
-	TGroup  *TGroup  `xml:"tgroup,omitempty" json:"tgroup,omitempty"`
+	XMLName  xml.Name `xml:"table" json:"-"`
+	Frame    string   `xml:"frame,attr,omitempty" json:"frame,omitempty"`
+	Colsep   string   `xml:"colsep,attr,omitempty" json:"colsep,omitempty"`
+	Rowsep   string   `xml:"rowsep,attr,omitempty" json:"rowsep,omitempty"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	TGroup   *TGroup  `xml:"tgroup,omitempty" json:"tgroup,omitempty"`
 }
 
 type TGroup struct {
-	XMLName xml.Name   `xml:"tgroup" json:"-"`
-	Align   string     `xml:"align,attr,omitempty" json:"align,omitempty"`
-	Cols    string     `xml:"cols,attr,omitempty" json:"cols,omitempty"`
-	ColSpec []*ColSpec `xml:"colspec,omitempty" json:"colspec,omitempty"`
-	THead   *THead     `xml:"thead,omitempty" json:"thead,omitempty"`
-	TBody   *TBody     `xml:"tbody,omitempty" json:"tbody,omitempty"`
+	XMLName  xml.Name   `xml:"tgroup" json:"-"`
+	Align    string     `xml:"align,attr,omitempty" json:"align,omitempty"`
+	Cols     string     `xml:"cols,attr,omitempty" json:"cols,omitempty"`
+	Audience string     `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	ColSpec  []*ColSpec `xml:"colspec,omitempty" json:"colspec,omitempty"`
+	THead    *THead     `xml:"thead,omitempty" json:"thead,omitempty"`
+	TBody    *TBody     `xml:"tbody,omitempty" json:"tbody,omitempty"`
 }
 
 type ColSpec struct {
-	XMLName xml.Name `xml:"colspec" json:"-"`
-	ColName string   `xml:"colname,attr,omitempty" json:"colname,omitempty"`
-	ColNum  string   `xml:"colnum,attr,omitempty" json:"colnum,omitempty"`
-	Value   string   `xml:",chardata" json:"value,omitempty"`
+	XMLName  xml.Name `xml:"colspec" json:"-"`
+	ColName  string   `xml:"colname,attr,omitempty" json:"colname,omitempty"`
+	ColNum   string   `xml:"colnum,attr,omitempty" json:"colnum,omitempty"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Value    string   `xml:",chardata" json:"value,omitempty"`
 }
 
 type THead struct {
-	XMLName xml.Name `xml:"thead" json:"-"`
-	VAlign  string   `xml:"valign,attr,omitempty" json:"valign,omitempty"`
-	Row     []*Row   `xml:"row,omitempty" json:"row,omitempty"`
+	XMLName  xml.Name `xml:"thead" json:"-"`
+	VAlign   string   `xml:"valign,attr,omitempty" json:"valign,omitempty"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Row      []*Row   `xml:"row,omitempty" json:"row,omitempty"`
 }
 
 type Row struct {
-	XMLName xml.Name `xml:"row" json:"-"`
-	Entry   []*Entry `xml:"entry,omitempty" json:"entry,omitempty"`
+	XMLName  xml.Name `xml:"row" json:"-"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Entry    []*Entry `xml:"entry,omitempty" json:"entry,omitempty"`
 }
 
 type Entry struct {
-	XMLName xml.Name `xml:"entry" json:"-"`
-	ColName string   `xml:"colname,attr,omitempty" json:"colname,omitempty"`
-	Value   string   `xml:",chardata" json:"value,omitempty"`
+	XMLName  xml.Name `xml:"entry" json:"-"`
+	ColName  string   `xml:"colname,attr,omitempty" json:"colname,omitempty"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Value    string   `xml:",chardata" json:"value,omitempty"`
 }
 
 type TBody struct {
-	XMLName xml.Name `xml:"tbody" json:"-"`
-	VAlign  string   `xml:"valign,attr,omitempty" json:"valign,omitempty"`
-	Row     []*Row   `xml:"row,omitempty" json:"row,omitempty"`
+	XMLName  xml.Name `xml:"tbody" json:"-"`
+	VAlign   string   `xml:"valign,attr,omitempty" json:"valign,omitempty"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Row      []*Row   `xml:"row,omitempty" json:"row,omitempty"`
 }
 
 // AcqInfo provides information about acquisition of an item
 type AcqInfo struct {
-	XMLName        xml.Name `xml:"acqinfo" json:"-"`
-	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
-	Head           string   `xml:"head,omitempty" json:"head,omitempty"`
-	P              []*P     `xml:"p,omitempty" json:"p,omitempty"`
+	XMLName        xml.Name      `xml:"acqinfo" json:"-"`
+	ID             string        `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string        `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog string        `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string        `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string        `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string        `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string        `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head           *Head         `xml:"head,omitempty" json:"head,omitempty"`
+	P              []*P          `xml:"p,omitempty" json:"p,omitempty"`
+	List           []*List       `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList      []*ChronList  `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table          []*Table      `xml:"table,omitempty" json:"table,omitempty"`
+	BlockQuote     []*BlockQuote `xml:"blockquote,omitempty" json:"blockquote,omitempty"`
+	AcqInfo        []*AcqInfo    `xml:"acqinfo,omitempty" json:"acqinfo,omitempty"`
 }
 
 // ProcessInfo proccessing information
 type ProcessInfo struct {
-	XMLName        xml.Name `xml:"processinfo" json:"-"`
-	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
-	Head           string   `xml:"head,omitempty" json:"head,omitempty"`
-	P              []*P     `xml:"p,omitempty" json:"p,omitempty"`
+	XMLName        xml.Name       `xml:"processinfo" json:"-"`
+	ID             string         `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string         `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog string         `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string         `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string         `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string         `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string         `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head           *Head          `xml:"head,omitempty" json:"head,omitempty"`
+	P              []*P           `xml:"p,omitempty" json:"p,omitempty"`
+	List           []*List        `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList      []*ChronList   `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table          []*Table       `xml:"table,omitempty" json:"table,omitempty"`
+	BlockQuote     []*BlockQuote  `xml:"blockquote,omitempty" json:"blockquote,omitempty"`
+	ProcessInfo    []*ProcessInfo `xml:"processinfo,omitempty" json:"processinfo,omitempty"`
 }
 
 // AltFormAvail describes other forms of access for an item
 type AltFormAvail struct {
-	XMLName xml.Name `xml:"altformavail" json:"-"`
-	Head    string   `xml:"head,omitempty" json:"head,omitempty"`
-	P       []*P     `xml:"p,omitempty" json:"p,omitempty"`
+	XMLName        xml.Name        `xml:"altformavail" json:"-"`
+	ID             string          `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string          `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog string          `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string          `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string          `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string          `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string          `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head           *Head           `xml:"head,omitempty" json:"head,omitempty"`
+	P              []*P            `xml:"p,omitempty" json:"p,omitempty"`
+	List           []*List         `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList      []*ChronList    `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table          []*Table        `xml:"table,omitempty" json:"table,omitempty"`
+	BlockQuote     []*BlockQuote   `xml:"blockquote,omitempty" json:"blockquote,omitempty"`
+	AltFormAvail   []*AltFormAvail `xml:"altformavail,omitempty" json:"altformavail,omitempty"`
 }
 
 // Appraisal information
 type Appraisal struct {
-	XMLName xml.Name `xml:"appraisal" json:"-"`
-	Head    string   `xml:"head,omitempty" json:"head,omitempty"`
-	P       []*P     `xml:"p,omitempty" json:"p,omitempty"`
+	XMLName        xml.Name      `xml:"appraisal" json:"-"`
+	ID             string        `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string        `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog string        `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string        `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string        `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string        `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string        `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head           *Head         `xml:"head,omitempty" json:"head,omitempty"`
+	P              []*P          `xml:"p,omitempty" json:"p,omitempty"`
+	List           []*List       `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList      []*ChronList  `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table          []*Table      `xml:"table,omitempty" json:"table,omitempty"`
+	BlockQuote     []*BlockQuote `xml:"blockquote,omitempty" json:"blockquote,omitempty"`
+	Appraisal      []*Appraisal  `xml:"appraisal,omitempty" json:"appraisal,omitempty"`
 }
 
 // CustodHist provides a custodial history of content
 type CustodHist struct {
-	XMLName xml.Name `xml:"custodhist" json:"-"`
-	Head    string   `xml:"head,omitempty" json:"head,omitempty"`
-	P       []*P     `xml:"p,omitempty" json:"p,omitempty"`
+	XMLName        xml.Name      `xml:"custodhist" json:"-"`
+	ID             string        `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string        `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog string        `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string        `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string        `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string        `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string        `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head           *Head         `xml:"head,omitempty" json:"head,omitempty"`
+	P              []*P          `xml:"p,omitempty" json:"p,omitempty"`
+	List           []*List       `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList      []*ChronList  `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table          []*Table      `xml:"table,omitempty" json:"table,omitempty"`
+	BlockQuote     []*BlockQuote `xml:"blockquote,omitempty" json:"blockquote,omitempty"`
+	CustodHist     []*CustodHist `xml:"custodhist,omitempty" json:"custodhist,omitempty"`
 }
 
 // FilePlan Information about any classification scheme used for arranging, storing, and retrieving
 // the described materials by the parties originally responsible for creating or compiling
 // them.
 type FilePlan struct {
-	XMLName xml.Name `xml:"fileplan" json:"-"`
-	Head    string   `xml:"head,omitempty" json:"head,omitempty"`
-	P       []*P     `xml:"p,omitempty" json:"p,omitempty"`
+	XMLName        xml.Name      `xml:"fileplan" json:"-"`
+	ID             string        `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string        `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog string        `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string        `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string        `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string        `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string        `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head           *Head         `xml:"head,omitempty" json:"head,omitempty"`
+	P              []*P          `xml:"p,omitempty" json:"p,omitempty"`
+	List           []*List       `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList      []*ChronList  `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table          []*Table      `xml:"table,omitempty" json:"table,omitempty"`
+	BlockQuote     []*BlockQuote `xml:"blockquote,omitempty" json:"blockquote,omitempty"`
+	FilePlan       []*FilePlan   `xml:"fileplan,omitempty" json:"fileplan,omitempty"`
 }
 
 // Accruals describes added values for an element
 type Accruals struct {
-	XMLName xml.Name `xml:"accruals" json:"-"`
-	Head    string   `xml:"head,omitempty" json:"head,omitempty"`
-	P       []*P     `xml:"p,omitempty" json:"p,omitempty"`
+	XMLName        xml.Name      `xml:"accruals" json:"-"`
+	ID             string        `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string        `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog string        `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string        `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string        `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string        `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string        `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head           *Head         `xml:"head,omitempty" json:"head,omitempty"`
+	P              []*P          `xml:"p,omitempty" json:"p,omitempty"`
+	List           []*List       `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList      []*ChronList  `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table          []*Table      `xml:"table,omitempty" json:"table,omitempty"`
+	BlockQuote     []*BlockQuote `xml:"blockquote,omitempty" json:"blockquote,omitempty"`
+	Accruals       []*Accruals   `xml:"accruals,omitempty" json:"accruals,omitempty"`
 }
 
 // LegalStatus is what is sounds like
 type LegalStatus struct {
-	XMLName xml.Name `xml:"legalstatus" json:"-"`
-	Head    string   `xml:"head,omitempty" json:"head,omitempty"`
-	P       []*P     `xml:"p,omitempty" json:"p,omitempty"`
+	XMLName        xml.Name       `xml:"legalstatus" json:"-"`
+	ID             string         `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string         `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog string         `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string         `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string         `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string         `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string         `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head           *Head          `xml:"head,omitempty" json:"head,omitempty"`
+	P              []*P           `xml:"p,omitempty" json:"p,omitempty"`
+	List           []*List        `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList      []*ChronList   `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table          []*Table       `xml:"table,omitempty" json:"table,omitempty"`
+	BlockQuote     []*BlockQuote  `xml:"blockquote,omitempty" json:"blockquote,omitempty"`
+	LegalStatus    []*LegalStatus `xml:"legalstatus,omitempty" json:"legalstatus,omitempty"`
 }
 
 // Odd misc.
 type Odd struct {
-	XMLName xml.Name `xml:"odd" json:"-"`
-	Head    string   `xml:"head,omitempty" json:"head,omitempty"`
-	P       []*P     `xml:"p,omitempty" json:"p,omitempty"`
+	XMLName        xml.Name      `xml:"odd" json:"-"`
+	ID             string        `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string        `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog string        `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string        `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string        `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string        `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string        `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head           *Head         `xml:"head,omitempty" json:"head,omitempty"`
+	P              []*P          `xml:"p,omitempty" json:"p,omitempty"`
+	List           []*List       `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList      []*ChronList  `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table          []*Table      `xml:"table,omitempty" json:"table,omitempty"`
+	BlockQuote     []*BlockQuote `xml:"blockquote,omitempty" json:"blockquote,omitempty"`
+	Odd            []*Odd        `xml:"odd,omitempty" json:"odd,omitempty"`
 }
 
 // OriginalsLoc location of original content
 type OriginalsLoc struct {
-	XMLName xml.Name `xml:"originalsloc" json:"-"`
-	Head    string   `xml:"head,omitempty" json:"head,omitempty"`
-	P       []*P     `xml:"p,omitempty" json:"p,omitempty"`
+	XMLName        xml.Name        `xml:"originalsloc" json:"-"`
+	ID             string          `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string          `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog string          `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string          `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string          `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string          `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string          `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head           *Head           `xml:"head,omitempty" json:"head,omitempty"`
+	P              []*P            `xml:"p,omitempty" json:"p,omitempty"`
+	List           []*List         `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList      []*ChronList    `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table          []*Table        `xml:"table,omitempty" json:"table,omitempty"`
+	BlockQuote     []*BlockQuote   `xml:"blockquote,omitempty" json:"blockquote,omitempty"`
+	OriginalsLoc   []*OriginalsLoc `xml:"originalsloc,omitempty" json:"originalsloc,omitempty"`
 }
 
 // PreferCite preferred citation format
 type PreferCite struct {
-	XMLName        xml.Name `xml:"prefercite" json:"-"`
-	EncodingAnalog string   `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
-	Head           string   `xml:"head,omitempty" json:"head,omitempty"`
-	P              []*P     `xml:"p,omitempty" json:"p,omitempty"`
+	XMLName        xml.Name      `xml:"prefercite" json:"-"`
+	ID             string        `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string        `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog string        `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string        `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string        `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string        `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string        `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head           *Head         `xml:"head,omitempty" json:"head,omitempty"`
+	P              []*P          `xml:"p,omitempty" json:"p,omitempty"`
+	List           []*List       `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList      []*ChronList  `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table          []*Table      `xml:"table,omitempty" json:"table,omitempty"`
+	BlockQuote     []*BlockQuote `xml:"blockquote,omitempty" json:"blockquote,omitempty"`
+	PreferCite     []*PreferCite `xml:"prefercite,omitempty" json:"prefercite,omitempty"`
 }
 
 // OtherFindAID other finding aids
 type OtherFindAID struct {
-	XMLName xml.Name `xml:"otherfindaid" json:"-"`
-	Head    string   `xml:"head,omitempty" json:"head,omitempty"`
-	P       []*P     `xml:"p,omitempty" json:"p,omitempty"`
+	XMLName        xml.Name        `xml:"otherfindaid" json:"-"`
+	ID             string          `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string          `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog string          `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string          `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string          `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string          `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string          `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head           *Head           `xml:"head,omitempty" json:"head,omitempty"`
+	P              []*P            `xml:"p,omitempty" json:"p,omitempty"`
+	List           []*List         `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList      []*ChronList    `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table          []*Table        `xml:"table,omitempty" json:"table,omitempty"`
+	BlockQuote     []*BlockQuote   `xml:"blockquote,omitempty" json:"blockquote,omitempty"`
+	ArchRef        []*ArchRef      `xml:"archref,omitempty" json:"archref,omitempty"`
+	BibRef         []*BibRef       `xml:"bibref,omitempty" json:"bibref,omitempty"`
+	OtherFindAID   []*OtherFindAID `xml:"otherfindaid,omitempty" json:"otherfindaid,omitempty"`
 }
 
 // PhysTech Physical Characteristics and Technical Specifications
 type PhysTech struct {
-	XMLName xml.Name `xml:"phystech" json:"-"`
-	Head    string   `xml:"head,omitempty" json:"head,omitempty"`
-	P       []*P     `xml:"p,omitempty" json:"p,omitempty"`
+	XMLName        xml.Name      `xml:"phystech" json:"-"`
+	ID             string        `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string        `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog string        `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string        `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string        `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string        `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string        `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head           *Head         `xml:"head,omitempty" json:"head,omitempty"`
+	P              []*P          `xml:"p,omitempty" json:"p,omitempty"`
+	List           []*List       `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList      []*ChronList  `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table          []*Table      `xml:"table,omitempty" json:"table,omitempty"`
+	BlockQuote     []*BlockQuote `xml:"blockquote,omitempty" json:"blockquote,omitempty"`
+	PhysTech       []*PhysTech   `xml:"phystech,omitempty" json:"phystech,omitempty"`
 }
 
 // SeparatedMaterial element indicates items acquired as part of a collection
 // and then subsequently removed from the collection.
 type SeparatedMaterial struct {
-	XMLName xml.Name `xml:"separatedmaterial" json:"-"`
-	Head    string   `xml:"head,omitempty" json:"head,omitempty"`
-	P       []*P     `xml:"p,omitempty" json:"p,omitempty"`
+	XMLName           xml.Name             `xml:"separatedmaterial" json:"-"`
+	ID                string               `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType         string               `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog    string               `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender         string               `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience          string               `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang              string               `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script            string               `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head              *Head                `xml:"head,omitempty" json:"head,omitempty"`
+	P                 []*P                 `xml:"p,omitempty" json:"p,omitempty"`
+	List              []*List              `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList         []*ChronList         `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table             []*Table             `xml:"table,omitempty" json:"table,omitempty"`
+	BlockQuote        []*BlockQuote        `xml:"blockquote,omitempty" json:"blockquote,omitempty"`
+	ArchRef           []*ArchRef           `xml:"archref,omitempty" json:"archref,omitempty"`
+	BibRef            []*BibRef            `xml:"bibref,omitempty" json:"bibref,omitempty"`
+	SeparatedMaterial []*SeparatedMaterial `xml:"separatedmaterial,omitempty" json:"separatedmaterial,omitempty"`
 }
 
 // Dsc - descovery???
 type Dsc struct {
-	XMLName xml.Name `xml:"dsc" json:"-"`
-	DscType string   `xml:"dsctype,attr,omitempty" json:"dsctype,omitempty"`
-	Head    *Head    `xml:"head,omitempty" json:"head,omitempty"`
-	P       []*P     `xml:"p,omitempty" json:"p,omitempty"`
-	C       []*C     `xml:"c,omitempty" json:"c,omitempty"`
-	C01     []*C01   `xml:"c01,omitempty" json:"c01"`
+	XMLName  xml.Name `xml:"dsc" json:"-"`
+	DscType  string   `xml:"dsctype,attr,omitempty" json:"dsctype,omitempty"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Head     *Head    `xml:"head,omitempty" json:"head,omitempty"`
+	P        []*P     `xml:"p,omitempty" json:"p,omitempty"`
+	C        []*C     `xml:"c,omitempty" json:"c,omitempty"`
+	C01      []*C01   `xml:"c01,omitempty" json:"c01,omitempty"`
 }
 
 // C container un-numbered level
 type C struct {
-	XMLName        xml.Name        `xml:"c" json:"-"`
-	Level          string          `xml:"level,attr,omitempty" json:"level"`
-	ID             string          `xml:"id,attr,omitempty" json:"id"`
-	DID            *DID            `xml:"did,omitempty" json:"did,omitempty"`
-	ScopeContent   *ScopeContent   `xml:"scopecontent,omitempty" json:"scopecontent,omitempty"`
-	AccessRestrict *AccessRestrict `xml:"accessrestrict,omitempty" json:"accessrestrict,omitempty"`
-	UseRestrict    *UseRestrict    `xml:"userestrict,omitempty" json:"userestrict,omitempty"`
-	PhysTech       *PhysTech       `xml:"phystech,omitempty" json:"phystech,omitempty"`
-	DIDNote        *DIDNote        `xml:"didnote,omitempty" json:"didnote,omitempty"`
-	Container      []*Container    `xml:"container,omitempty" json:"container,omitempty"`
-	C              []*C            `xml:"c,omitempty" json:"c,omitempty"`
-	Odd            *Odd            `xml:"odd,omitempty" json:"odd,omitempty"`
-	ControlAccess  *ControlAccess  `xml:"controlaccess,omitempty" json:"controlaccess,omitempty"`
-	OriginalsLoc   *OriginalsLoc   `xml:"originalsloc,omitempty" json:"originalsloc,omitempty"`
-	AltFormAvail   *AltFormAvail   `xml:"altformavail,omitempty" json:"altformavail,omitempty"`
-	Index          *Index          `xml:"index,omitempty" json:"index,omitempty"`
+	XMLName           xml.Name             `xml:"c" json:"-"`
+	Level             string               `xml:"level,attr,omitempty" json:"level,omitempty"`
+	ID                string               `xml:"id,attr,omitempty" json:"id,omitempty"`
+	Audience          string               `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Head              *Head                `xml:"head,omitempty" json:"head,omitempty"`
+	DID               *DID                 `xml:"did,omitempty" json:"did,omitempty"`
+	BiogHist          []*BiogHist          `xml:"bioghist,omitempty" json:"bioghist,omitempty"`
+	ScopeContent      []*ScopeContent      `xml:"scopecontent,omitempty" json:"scopecontent,omitempty"`
+	Arrangement       []*Arrangement       `xml:"arrangement,omitempty" json:"arrangement,omitempty"`
+	AccessRestrict    []*AccessRestrict    `xml:"accessrestrict,omitempty" json:"accessrestrict,omitempty"`
+	UseRestrict       []*UseRestrict       `xml:"userestrict,omitempty" json:"userestrict,omitempty"`
+	AcqInfo           []*AcqInfo           `xml:"acqinfo,omitempty" json:"acqinfo,omitempty"`
+	CustodHist        []*CustodHist        `xml:"custodhist,omitempty" json:"custodhist,omitempty"`
+	Appraisal         []*Appraisal         `xml:"appraisal,omitempty" json:"appraisal,omitempty"`
+	Accruals          []*Accruals          `xml:"accruals,omitempty" json:"accruals,omitempty"`
+	ProcessInfo       []*ProcessInfo       `xml:"processinfo,omitempty" json:"processinfo,omitempty"`
+	RelatedMaterial   []*RelatedMaterial   `xml:"relatedmaterial,omitempty" json:"relatedmaterial,omitempty"`
+	SeparatedMaterial []*SeparatedMaterial `xml:"separatedmaterial,omitempty" json:"separatedmaterial,omitempty"`
+	OtherFindAID      []*OtherFindAID      `xml:"otherfindaid,omitempty" json:"otherfindaid,omitempty"`
+	OriginalsLoc      []*OriginalsLoc      `xml:"originalsloc,omitempty" json:"originalsloc,omitempty"`
+	AltFormAvail      []*AltFormAvail      `xml:"altformavail,omitempty" json:"altformavail,omitempty"`
+	PhysTech          []*PhysTech          `xml:"phystech,omitempty" json:"phystech,omitempty"`
+	FilePlan          []*FilePlan          `xml:"fileplan,omitempty" json:"fileplan,omitempty"`
+	LegalStatus       []*LegalStatus       `xml:"legalstatus,omitempty" json:"legalstatus,omitempty"`
+	PreferCite        []*PreferCite        `xml:"prefercite,omitempty" json:"prefercite,omitempty"`
+	Bibliography      []*Bibliography      `xml:"bibliography,omitempty" json:"bibliography,omitempty"`
+	Odd               []*Odd               `xml:"odd,omitempty" json:"odd,omitempty"`
+	ControlAccess     []*ControlAccess     `xml:"controlaccess,omitempty" json:"controlaccess,omitempty"`
+	Relations         []*Relations         `xml:"relations,omitempty" json:"relations,omitempty"`
+	Index             []*Index             `xml:"index,omitempty" json:"index,omitempty"`
+	DIDNote           *DIDNote             `xml:"didnote,omitempty" json:"didnote,omitempty"`
+	Container         []*Container         `xml:"container,omitempty" json:"container,omitempty"`
+	Dsc               []*Dsc               `xml:"dsc,omitempty" json:"dsc,omitempty"`
+	C                 []*C                 `xml:"c,omitempty" json:"c,omitempty"`
 }
 
 type Index struct {
-	XMLName xml.Name `xml:"index" json:"-"`
-	Value   string   `xml:",innerxml" json:"value,omitempty"`
+	XMLName        xml.Name      `xml:"index" json:"-"`
+	ID             string        `xml:"id,attr,omitempty" json:"id,omitempty"`
+	LocalType      string        `xml:"localtype,attr,omitempty" json:"localtype,omitempty"`
+	EncodingAnalog string        `xml:"encodinganalog,attr,omitempty" json:"encodinganalog,omitempty"`
+	AltRender      string        `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience       string        `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang           string        `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script         string        `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Head           *Head         `xml:"head,omitempty" json:"head,omitempty"`
+	P              []*P          `xml:"p,omitempty" json:"p,omitempty"`
+	List           *List         `xml:"list,omitempty" json:"list,omitempty"`
+	ChronList      *ChronList    `xml:"chronlist,omitempty" json:"chronlist,omitempty"`
+	Table          *Table        `xml:"table,omitempty" json:"table,omitempty"`
+	IndexEntry     []*IndexEntry `xml:"indexentry,omitempty" json:"indexentry,omitempty"`
+	Index          []*Index      `xml:"index,omitempty" json:"index,omitempty"`
+}
+
+// IndexEntry pairs an access term, or a group of them, with pointers or a reference to
+// where it is found
+type IndexEntry struct {
+	XMLName    xml.Name      `xml:"indexentry" json:"-"`
+	ID         string        `xml:"id,attr,omitempty" json:"id,omitempty"`
+	AltRender  string        `xml:"altrender,attr,omitempty" json:"altrender,omitempty"`
+	Audience   string        `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Lang       string        `xml:"lang,attr,omitempty" json:"lang,omitempty"`
+	Script     string        `xml:"script,attr,omitempty" json:"script,omitempty"`
+	Persname   *Persname     `xml:"persname,omitempty" json:"persname,omitempty"`
+	Famname    *Famname      `xml:"famname,omitempty" json:"famname,omitempty"`
+	CorpName   *CorpName     `xml:"corpname,omitempty" json:"corpname,omitempty"`
+	Subject    *Subject      `xml:"subject,omitempty" json:"subject,omitempty"`
+	GenreForm  *GenreForm    `xml:"genreform,omitempty" json:"genreform,omitempty"`
+	GeogName   *GeogName     `xml:"geogname,omitempty" json:"geogname,omitempty"`
+	Occupation *Occupation   `xml:"occupation,omitempty" json:"occupation,omitempty"`
+	Function   *Function     `xml:"function,omitempty" json:"function,omitempty"`
+	Name       *Name         `xml:"name,omitempty" json:"name,omitempty"`
+	NameGrp    *NameGrp      `xml:"namegrp,omitempty" json:"namegrp,omitempty"`
+	Ptr        *Ptr          `xml:"ptr,omitempty" json:"ptr,omitempty"`
+	PtrGrp     *PtrGrp       `xml:"ptrgrp,omitempty" json:"ptrgrp,omitempty"`
+	Ref        *Ref          `xml:"ref,omitempty" json:"ref,omitempty"`
+	IndexEntry []*IndexEntry `xml:"indexentry,omitempty" json:"indexentry,omitempty"`
 }
 
 // C01 container level 1
 type C01 struct {
-	XMLName        xml.Name        `xml:"c01" json:"-"`
-	Level          string          `xml:"level,attr,omitempty" json:"level,omitempty"`
-	ID             string          `xml:"id,attr,omitempty" json:"id,omitempty"`
-	DID            *DID            `xml:"did,omitempty" json:"did,omitempty"`
-	ScopeContent   *ScopeContent   `xml:"scopecontent,omitempty" json:"scopecontent,omitempty"`
-	AccessRestrict *AccessRestrict `xml:"accessrestrict,omitempty" json:"accessrestrict,omitempty"`
-	UseRestrict    *UseRestrict    `xml:"userestrict,omitempty" json:"userestrict,omitempty"`
-	PhysTech       *PhysTech       `xml:"phystech,omitempty" json:"phystech,omitempty"`
-	DIDNote        *DIDNote        `xml:"didnote,omitempty" json:"didnote,omitempty"`
-	Container      []*Container    `xml:"container,omitempty" json:"container,omitempty"`
-	C02            []*C02          `xml:"c02,omitempty" json:"c02,omitempty"`
+	XMLName           xml.Name             `xml:"c01" json:"-"`
+	Level             string               `xml:"level,attr,omitempty" json:"level,omitempty"`
+	ID                string               `xml:"id,attr,omitempty" json:"id,omitempty"`
+	Audience          string               `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Head              *Head                `xml:"head,omitempty" json:"head,omitempty"`
+	DID               *DID                 `xml:"did,omitempty" json:"did,omitempty"`
+	BiogHist          []*BiogHist          `xml:"bioghist,omitempty" json:"bioghist,omitempty"`
+	ScopeContent      []*ScopeContent      `xml:"scopecontent,omitempty" json:"scopecontent,omitempty"`
+	Arrangement       []*Arrangement       `xml:"arrangement,omitempty" json:"arrangement,omitempty"`
+	AccessRestrict    []*AccessRestrict    `xml:"accessrestrict,omitempty" json:"accessrestrict,omitempty"`
+	UseRestrict       []*UseRestrict       `xml:"userestrict,omitempty" json:"userestrict,omitempty"`
+	AcqInfo           []*AcqInfo           `xml:"acqinfo,omitempty" json:"acqinfo,omitempty"`
+	CustodHist        []*CustodHist        `xml:"custodhist,omitempty" json:"custodhist,omitempty"`
+	Appraisal         []*Appraisal         `xml:"appraisal,omitempty" json:"appraisal,omitempty"`
+	Accruals          []*Accruals          `xml:"accruals,omitempty" json:"accruals,omitempty"`
+	ProcessInfo       []*ProcessInfo       `xml:"processinfo,omitempty" json:"processinfo,omitempty"`
+	RelatedMaterial   []*RelatedMaterial   `xml:"relatedmaterial,omitempty" json:"relatedmaterial,omitempty"`
+	SeparatedMaterial []*SeparatedMaterial `xml:"separatedmaterial,omitempty" json:"separatedmaterial,omitempty"`
+	OtherFindAID      []*OtherFindAID      `xml:"otherfindaid,omitempty" json:"otherfindaid,omitempty"`
+	OriginalsLoc      []*OriginalsLoc      `xml:"originalsloc,omitempty" json:"originalsloc,omitempty"`
+	AltFormAvail      []*AltFormAvail      `xml:"altformavail,omitempty" json:"altformavail,omitempty"`
+	PhysTech          []*PhysTech          `xml:"phystech,omitempty" json:"phystech,omitempty"`
+	FilePlan          []*FilePlan          `xml:"fileplan,omitempty" json:"fileplan,omitempty"`
+	LegalStatus       []*LegalStatus       `xml:"legalstatus,omitempty" json:"legalstatus,omitempty"`
+	PreferCite        []*PreferCite        `xml:"prefercite,omitempty" json:"prefercite,omitempty"`
+	Bibliography      []*Bibliography      `xml:"bibliography,omitempty" json:"bibliography,omitempty"`
+	Odd               []*Odd               `xml:"odd,omitempty" json:"odd,omitempty"`
+	ControlAccess     []*ControlAccess     `xml:"controlaccess,omitempty" json:"controlaccess,omitempty"`
+	Relations         []*Relations         `xml:"relations,omitempty" json:"relations,omitempty"`
+	Index             []*Index             `xml:"index,omitempty" json:"index,omitempty"`
+	DIDNote           *DIDNote             `xml:"didnote,omitempty" json:"didnote,omitempty"`
+	Container         []*Container         `xml:"container,omitempty" json:"container,omitempty"`
+	Dsc               []*Dsc               `xml:"dsc,omitempty" json:"dsc,omitempty"`
+	C02               []*C02               `xml:"c02,omitempty" json:"c02,omitempty"`
 }
 
 // C02 container level 2
 type C02 struct {
-	XMLName        xml.Name        `xml:"c02" json:"-"`
-	Level          string          `xml:"level,attr,omitempty" json:"level,omitempty"`
-	ID             string          `xml:"id,attr,omitempty" json:"id,omitempty"`
-	DID            *DID            `xml:"did,omitempty" json:"did,omitempty"`
-	ScopeContent   *ScopeContent   `xml:"scopecontent,omitempty" json:"scopecontent,omitempty"`
-	AccessRestrict *AccessRestrict `xml:"accessrestrict,omitempty" json:"accessrestrict,omitempty"`
-	UseRestrict    *UseRestrict    `xml:"userestrict,omitempty" json:"userestrict,omitempty"`
-	PhysTech       *PhysTech       `xml:"phystech,omitempty" json:"phystech,omitempty"`
-	DIDNote        *DIDNote        `xml:"didnote,omitempty" json:"didnote,omitempty"`
-	Container      []*Container    `xml:"container,omitempty" json:"container,omitempty"`
-	C03            []*C03          `xml:"c03,omitempty" json:"c03,omitempty"`
+	XMLName           xml.Name             `xml:"c02" json:"-"`
+	Level             string               `xml:"level,attr,omitempty" json:"level,omitempty"`
+	ID                string               `xml:"id,attr,omitempty" json:"id,omitempty"`
+	Audience          string               `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Head              *Head                `xml:"head,omitempty" json:"head,omitempty"`
+	DID               *DID                 `xml:"did,omitempty" json:"did,omitempty"`
+	BiogHist          []*BiogHist          `xml:"bioghist,omitempty" json:"bioghist,omitempty"`
+	ScopeContent      []*ScopeContent      `xml:"scopecontent,omitempty" json:"scopecontent,omitempty"`
+	Arrangement       []*Arrangement       `xml:"arrangement,omitempty" json:"arrangement,omitempty"`
+	AccessRestrict    []*AccessRestrict    `xml:"accessrestrict,omitempty" json:"accessrestrict,omitempty"`
+	UseRestrict       []*UseRestrict       `xml:"userestrict,omitempty" json:"userestrict,omitempty"`
+	AcqInfo           []*AcqInfo           `xml:"acqinfo,omitempty" json:"acqinfo,omitempty"`
+	CustodHist        []*CustodHist        `xml:"custodhist,omitempty" json:"custodhist,omitempty"`
+	Appraisal         []*Appraisal         `xml:"appraisal,omitempty" json:"appraisal,omitempty"`
+	Accruals          []*Accruals          `xml:"accruals,omitempty" json:"accruals,omitempty"`
+	ProcessInfo       []*ProcessInfo       `xml:"processinfo,omitempty" json:"processinfo,omitempty"`
+	RelatedMaterial   []*RelatedMaterial   `xml:"relatedmaterial,omitempty" json:"relatedmaterial,omitempty"`
+	SeparatedMaterial []*SeparatedMaterial `xml:"separatedmaterial,omitempty" json:"separatedmaterial,omitempty"`
+	OtherFindAID      []*OtherFindAID      `xml:"otherfindaid,omitempty" json:"otherfindaid,omitempty"`
+	OriginalsLoc      []*OriginalsLoc      `xml:"originalsloc,omitempty" json:"originalsloc,omitempty"`
+	AltFormAvail      []*AltFormAvail      `xml:"altformavail,omitempty" json:"altformavail,omitempty"`
+	PhysTech          []*PhysTech          `xml:"phystech,omitempty" json:"phystech,omitempty"`
+	FilePlan          []*FilePlan          `xml:"fileplan,omitempty" json:"fileplan,omitempty"`
+	LegalStatus       []*LegalStatus       `xml:"legalstatus,omitempty" json:"legalstatus,omitempty"`
+	PreferCite        []*PreferCite        `xml:"prefercite,omitempty" json:"prefercite,omitempty"`
+	Bibliography      []*Bibliography      `xml:"bibliography,omitempty" json:"bibliography,omitempty"`
+	Odd               []*Odd               `xml:"odd,omitempty" json:"odd,omitempty"`
+	ControlAccess     []*ControlAccess     `xml:"controlaccess,omitempty" json:"controlaccess,omitempty"`
+	Relations         []*Relations         `xml:"relations,omitempty" json:"relations,omitempty"`
+	Index             []*Index             `xml:"index,omitempty" json:"index,omitempty"`
+	DIDNote           *DIDNote             `xml:"didnote,omitempty" json:"didnote,omitempty"`
+	Container         []*Container         `xml:"container,omitempty" json:"container,omitempty"`
+	Dsc               []*Dsc               `xml:"dsc,omitempty" json:"dsc,omitempty"`
+	C03               []*C03               `xml:"c03,omitempty" json:"c03,omitempty"`
 }
 
 // C03 Container level 3
 type C03 struct {
-	XMLName        xml.Name        `xml:"c03" json:"-"`
-	Level          string          `xml:"level,attr,omitempty" json:"level,omitempty"`
-	ID             string          `xml:"id,attr,omitempty" json:"id,omitempty"`
-	DID            *DID            `xml:"did,omitempty" json:"did,omitempty"`
-	ScopeContent   *ScopeContent   `xml:"scopecontent,omitempty" json:"scopecontent,omitempty"`
-	AccessRestrict *AccessRestrict `xml:"accessrestrict,omitempty" json:"accessrestrict,omitempty"`
-	UseRestrict    *UseRestrict    `xml:"userestrict,omitempty" json:"userestrict,omitempty"`
-	PhysTech       *PhysTech       `xml:"phystech,omitempty" json:"phystech,omitempty"`
-	DIDNote        *DIDNote        `xml:"didnote,omitempty" json:"didnote,omitempty"`
-	Container      []*Container    `xml:"container,omitempty" json:"container,omitempty"`
-	C04            []*C04          `xml:"c04,omitempty" json:"c04,omitempty"`
+	XMLName           xml.Name             `xml:"c03" json:"-"`
+	Level             string               `xml:"level,attr,omitempty" json:"level,omitempty"`
+	ID                string               `xml:"id,attr,omitempty" json:"id,omitempty"`
+	Audience          string               `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Head              *Head                `xml:"head,omitempty" json:"head,omitempty"`
+	DID               *DID                 `xml:"did,omitempty" json:"did,omitempty"`
+	BiogHist          []*BiogHist          `xml:"bioghist,omitempty" json:"bioghist,omitempty"`
+	ScopeContent      []*ScopeContent      `xml:"scopecontent,omitempty" json:"scopecontent,omitempty"`
+	Arrangement       []*Arrangement       `xml:"arrangement,omitempty" json:"arrangement,omitempty"`
+	AccessRestrict    []*AccessRestrict    `xml:"accessrestrict,omitempty" json:"accessrestrict,omitempty"`
+	UseRestrict       []*UseRestrict       `xml:"userestrict,omitempty" json:"userestrict,omitempty"`
+	AcqInfo           []*AcqInfo           `xml:"acqinfo,omitempty" json:"acqinfo,omitempty"`
+	CustodHist        []*CustodHist        `xml:"custodhist,omitempty" json:"custodhist,omitempty"`
+	Appraisal         []*Appraisal         `xml:"appraisal,omitempty" json:"appraisal,omitempty"`
+	Accruals          []*Accruals          `xml:"accruals,omitempty" json:"accruals,omitempty"`
+	ProcessInfo       []*ProcessInfo       `xml:"processinfo,omitempty" json:"processinfo,omitempty"`
+	RelatedMaterial   []*RelatedMaterial   `xml:"relatedmaterial,omitempty" json:"relatedmaterial,omitempty"`
+	SeparatedMaterial []*SeparatedMaterial `xml:"separatedmaterial,omitempty" json:"separatedmaterial,omitempty"`
+	OtherFindAID      []*OtherFindAID      `xml:"otherfindaid,omitempty" json:"otherfindaid,omitempty"`
+	OriginalsLoc      []*OriginalsLoc      `xml:"originalsloc,omitempty" json:"originalsloc,omitempty"`
+	AltFormAvail      []*AltFormAvail      `xml:"altformavail,omitempty" json:"altformavail,omitempty"`
+	PhysTech          []*PhysTech          `xml:"phystech,omitempty" json:"phystech,omitempty"`
+	FilePlan          []*FilePlan          `xml:"fileplan,omitempty" json:"fileplan,omitempty"`
+	LegalStatus       []*LegalStatus       `xml:"legalstatus,omitempty" json:"legalstatus,omitempty"`
+	PreferCite        []*PreferCite        `xml:"prefercite,omitempty" json:"prefercite,omitempty"`
+	Bibliography      []*Bibliography      `xml:"bibliography,omitempty" json:"bibliography,omitempty"`
+	Odd               []*Odd               `xml:"odd,omitempty" json:"odd,omitempty"`
+	ControlAccess     []*ControlAccess     `xml:"controlaccess,omitempty" json:"controlaccess,omitempty"`
+	Relations         []*Relations         `xml:"relations,omitempty" json:"relations,omitempty"`
+	Index             []*Index             `xml:"index,omitempty" json:"index,omitempty"`
+	DIDNote           *DIDNote             `xml:"didnote,omitempty" json:"didnote,omitempty"`
+	Container         []*Container         `xml:"container,omitempty" json:"container,omitempty"`
+	Dsc               []*Dsc               `xml:"dsc,omitempty" json:"dsc,omitempty"`
+	C04               []*C04               `xml:"c04,omitempty" json:"c04,omitempty"`
 }
 
 // C04 container level 4
 type C04 struct {
-	XMLName        xml.Name        `xml:"c04" json:"-"`
-	Level          string          `xml:"level,attr,omitempty" json:"level,omitempty"`
-	ID             string          `xml:"id,attr,omitempty" json:"id,omitempty"`
-	DID            *DID            `xml:"did,omitempty" json:"did,omitempty"`
-	ScopeContent   *ScopeContent   `xml:"scopecontent,omitempty" json:"scopecontent,omitempty"`
-	AccessRestrict *AccessRestrict `xml:"accessrestrict,omitempty" json:"accessrestrict,omitempty"`
-	UseRestrict    *UseRestrict    `xml:"userestrict,omitempty" json:"userestrict,omitempty"`
-	PhysTech       *PhysTech       `xml:"phystech,omitempty" json:"phystech,omitempty"`
-	DIDNote        *DIDNote        `xml:"didnote,omitempty" json:"didnote,omitempty"`
-	Container      []*Container    `xml:"container,omitempty" json:"container,omitempty"`
+	XMLName           xml.Name             `xml:"c04" json:"-"`
+	Level             string               `xml:"level,attr,omitempty" json:"level,omitempty"`
+	ID                string               `xml:"id,attr,omitempty" json:"id,omitempty"`
+	Audience          string               `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Head              *Head                `xml:"head,omitempty" json:"head,omitempty"`
+	DID               *DID                 `xml:"did,omitempty" json:"did,omitempty"`
+	BiogHist          []*BiogHist          `xml:"bioghist,omitempty" json:"bioghist,omitempty"`
+	ScopeContent      []*ScopeContent      `xml:"scopecontent,omitempty" json:"scopecontent,omitempty"`
+	Arrangement       []*Arrangement       `xml:"arrangement,omitempty" json:"arrangement,omitempty"`
+	AccessRestrict    []*AccessRestrict    `xml:"accessrestrict,omitempty" json:"accessrestrict,omitempty"`
+	UseRestrict       []*UseRestrict       `xml:"userestrict,omitempty" json:"userestrict,omitempty"`
+	AcqInfo           []*AcqInfo           `xml:"acqinfo,omitempty" json:"acqinfo,omitempty"`
+	CustodHist        []*CustodHist        `xml:"custodhist,omitempty" json:"custodhist,omitempty"`
+	Appraisal         []*Appraisal         `xml:"appraisal,omitempty" json:"appraisal,omitempty"`
+	Accruals          []*Accruals          `xml:"accruals,omitempty" json:"accruals,omitempty"`
+	ProcessInfo       []*ProcessInfo       `xml:"processinfo,omitempty" json:"processinfo,omitempty"`
+	RelatedMaterial   []*RelatedMaterial   `xml:"relatedmaterial,omitempty" json:"relatedmaterial,omitempty"`
+	SeparatedMaterial []*SeparatedMaterial `xml:"separatedmaterial,omitempty" json:"separatedmaterial,omitempty"`
+	OtherFindAID      []*OtherFindAID      `xml:"otherfindaid,omitempty" json:"otherfindaid,omitempty"`
+	OriginalsLoc      []*OriginalsLoc      `xml:"originalsloc,omitempty" json:"originalsloc,omitempty"`
+	AltFormAvail      []*AltFormAvail      `xml:"altformavail,omitempty" json:"altformavail,omitempty"`
+	PhysTech          []*PhysTech          `xml:"phystech,omitempty" json:"phystech,omitempty"`
+	FilePlan          []*FilePlan          `xml:"fileplan,omitempty" json:"fileplan,omitempty"`
+	LegalStatus       []*LegalStatus       `xml:"legalstatus,omitempty" json:"legalstatus,omitempty"`
+	PreferCite        []*PreferCite        `xml:"prefercite,omitempty" json:"prefercite,omitempty"`
+	Bibliography      []*Bibliography      `xml:"bibliography,omitempty" json:"bibliography,omitempty"`
+	Odd               []*Odd               `xml:"odd,omitempty" json:"odd,omitempty"`
+	ControlAccess     []*ControlAccess     `xml:"controlaccess,omitempty" json:"controlaccess,omitempty"`
+	Relations         []*Relations         `xml:"relations,omitempty" json:"relations,omitempty"`
+	Index             []*Index             `xml:"index,omitempty" json:"index,omitempty"`
+	DIDNote           *DIDNote             `xml:"didnote,omitempty" json:"didnote,omitempty"`
+	Container         []*Container         `xml:"container,omitempty" json:"container,omitempty"`
+	Dsc               []*Dsc               `xml:"dsc,omitempty" json:"dsc,omitempty"`
 }
 
 // Sources contains one or more source
 type Sources struct {
-	XMLName xml.Name  `xml:"sources" json:"-"`
-	Source  []*Source `xml:"source,omitempty" json:"source,omitempty"`
+	XMLName  xml.Name  `xml:"sources" json:"-"`
+	Audience string    `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Source   []*Source `xml:"source,omitempty" json:"source,omitempty"`
 }
 
 // Source detailed information about source
 type Source struct {
 	XMLName         xml.Name         `xml:"source" json:"-"`
+	Audience        string           `xml:"audience,attr,omitempty" json:"audience,omitempty"`
 	SourceEntry     string           `xml:"sourceentry,omitempty" json:"sourceentry,omitempty"`
 	ObjectXMLWrap   *ObjectXMLWrap   `xml:"objectxmlwrap,omitempty" json:"objectxmlwrap,omitempty"`
 	Descriptivenote *DescriptiveNote `xml:"descriptivenote,omitempty" json:"descriptivenote,omitempty"`
@@ -1120,8 +1882,9 @@ type Source struct {
 
 // ObjectXMLWrap include an existing XML object as is.
 type ObjectXMLWrap struct {
-	XMLName xml.Name `xml:"objectxmlwrap" json:"-"`
-	Value   string   `xml:",innerxml" json:"value,omitempty"`
+	XMLName  xml.Name `xml:"objectxmlwrap" json:"-"`
+	Audience string   `xml:"audience,attr,omitempty" json:"audience,omitempty"`
+	Value    string   `xml:",innerxml" json:"value,omitempty"`
 }
 
 // Create a new EAD document structure
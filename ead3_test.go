@@ -38,6 +38,7 @@ import (
 	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"runtime"
@@ -248,3 +249,55 @@ func TestStructureCompleteness(t *testing.T) {
 		XMLDecodeEncodeTest(t, fname)
 	}
 }
+
+// noteElementCounts counts the elements found inside narrative notes by name
+func noteElementCounts(t *testing.T, src []byte) map[string]int {
+	counts := map[string]int{}
+	depth := 0
+	dec := xml.NewDecoder(bytes.NewReader(src))
+	for {
+		token, err := dec.Token()
+		if err == io.EOF {
+			return counts
+		}
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+		switch e := token.(type) {
+		case xml.StartElement:
+			if depth > 0 {
+				depth++
+				counts[e.Name.Local]++
+			} else if _, ok := NoteLabels[e.Name.Local]; ok == true {
+				depth = 1
+			}
+		case xml.EndElement:
+			if depth > 0 {
+				depth--
+			}
+		}
+	}
+}
+
+func TestNoteRoundTrip(t *testing.T) {
+	for _, fname := range testEAD3Files {
+		input, err := ioutil.ReadFile(fname)
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+		record := New()
+		if err := xml.Unmarshal(input, &record); err != nil {
+			t.Fatalf("%s, %s", fname, err)
+		}
+		output, err := xml.Marshal(record)
+		if err != nil {
+			t.Fatalf("%s, %s", fname, err)
+		}
+		expected, got := noteElementCounts(t, input), noteElementCounts(t, output)
+		for name, count := range expected {
+			if got[name] < count {
+				t.Errorf("%s, expected %d <%s> in notes, got %d", fname, count, name, got[name])
+			}
+		}
+	}
+}
@@ -0,0 +1,263 @@
+//
+// facets.go computes browse facets across a corpus of finding aids.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Facet names used by Aggregate
+const (
+	FacetSubject    = "subject"
+	FacetGenreForm  = "genreform"
+	FacetGeogName   = "geogname"
+	FacetName       = "name"
+	FacetRepository = "repository"
+	FacetLanguage   = "language"
+	FacetDecade     = "decade"
+	FacetLevel      = "level"
+)
+
+// FacetNames lists the facets computed by Aggregate in display order
+var FacetNames = []string{
+	FacetRepository,
+	FacetLevel,
+	FacetDecade,
+	FacetLanguage,
+	FacetName,
+	FacetSubject,
+	FacetGeogName,
+	FacetGenreForm,
+}
+
+// FacetValue is a value of a facet with the records it occurs in
+type FacetValue struct {
+	Value string `json:"value"`
+	// Source is the vocabulary of a controlled access term (e.g. lcsh), if given
+	Source string `json:"source,omitempty"`
+	Count  int    `json:"count"`
+	// RecordIDs are the recordid of each record with the value
+	RecordIDs []string `json:"recordids"`
+}
+
+// Facet holds the values of a facet ordered by descending count
+type Facet struct {
+	Name   string        `json:"name"`
+	Values []*FacetValue `json:"values"`
+}
+
+// Aggregation is the result of Aggregate
+type Aggregation struct {
+	// Records is the number of records aggregated
+	Records int               `json:"records"`
+	Facets  map[string]*Facet `json:"facets"`
+}
+
+// facetCounter collects the values of a facet, each record is counted once per value
+type facetCounter map[string]*FacetValue
+
+func (fc facetCounter) add(recordID, value, source string) {
+	value = strings.TrimSpace(strings.Join(strings.Fields(value), " "))
+	value = strings.TrimSuffix(value, ".")
+	if value == "" {
+		return
+	}
+	source = strings.ToLower(strings.TrimSpace(source))
+	key := source + "\x00" + value
+	fv, ok := fc[key]
+	if ok == false {
+		fv = &FacetValue{Value: value, Source: source}
+		fc[key] = fv
+	}
+	if n := len(fv.RecordIDs); n > 0 && fv.RecordIDs[n-1] == recordID {
+		return
+	}
+	fv.RecordIDs = append(fv.RecordIDs, recordID)
+	fv.Count++
+}
+
+func (fc facetCounter) facet(name string) *Facet {
+	facet := &Facet{Name: name, Values: []*FacetValue{}}
+	for _, fv := range fc {
+		facet.Values = append(facet.Values, fv)
+	}
+	sort.Slice(facet.Values, func(i, j int) bool {
+		a, b := facet.Values[i], facet.Values[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Value != b.Value {
+			return a.Value < b.Value
+		}
+		return a.Source < b.Source
+	})
+	return facet
+}
+
+// headingFacets maps heading types to the facet counting them
+var headingFacets = map[string]string{
+	"persname":   FacetName,
+	"famname":    FacetName,
+	"corpname":   FacetName,
+	"name":       FacetName,
+	"function":   FacetSubject,
+	"subject":    FacetSubject,
+	"occupation": FacetSubject,
+	"geogname":   FacetGeogName,
+	"genreform":  FacetGenreForm,
+}
+
+func addControlAccessFacets(facets map[string]facetCounter, recordID string, controlAccess *ControlAccess) {
+	for _, heading := range controlAccess.Headings() {
+		if name, ok := headingFacets[heading.Type]; ok == true {
+			facets[name].add(recordID, heading.Term(), heading.Source)
+		}
+	}
+}
+
+// Decade returns the label of the decade containing year, e.g. "1950s"
+func Decade(year int) string {
+	return fmt.Sprintf("%ds", year-year%10)
+}
+
+// facetRecordID returns the recordid of a record, records without one are identified
+// by their position in the slice passed to Aggregate (e.g. "#3")
+func facetRecordID(i int, record *EAD3) string {
+	if record.Control != nil && record.Control.RecordID != nil {
+		if s := strings.TrimSpace(record.Control.RecordID.Value); s != "" {
+			return s
+		}
+	}
+	return fmt.Sprintf("#%d", i)
+}
+
+// Aggregate counts the records having each value of the browse facets: the controlled
+// access names, subjects, places and genres (in the collection and its components), the
+// repository, the languages of the materials, the decades covered by the collection's
+// unit dates and the archdesc level.
+func Aggregate(records []*EAD3) *Aggregation {
+	facets := map[string]facetCounter{}
+	for _, name := range FacetNames {
+		facets[name] = facetCounter{}
+	}
+	for i, record := range records {
+		if record == nil || record.ArchDesc == nil {
+			continue
+		}
+		recordID := facetRecordID(i, record)
+		archDesc := record.ArchDesc
+		facets[FacetLevel].add(recordID, archDesc.Level, "")
+		decades := []int{}
+		for _, did := range archDesc.DID {
+			if did.Repository != nil {
+				for _, name := range did.Repository.CorpName {
+					facets[FacetRepository].add(recordID, NewHeading(name).Term(), "")
+				}
+				for _, name := range did.Repository.Persname {
+					facets[FacetRepository].add(recordID, NewHeading(name).Term(), "")
+				}
+				for _, name := range did.Repository.Famname {
+					facets[FacetRepository].add(recordID, NewHeading(name).Term(), "")
+				}
+				for _, name := range did.Repository.Name {
+					facets[FacetRepository].add(recordID, NewHeading(name).Term(), "")
+				}
+			}
+			for _, language := range did.LangMaterial.Languages() {
+				if s := strings.TrimSpace(language.Value); s != "" {
+					facets[FacetLanguage].add(recordID, s, "")
+				} else {
+					facets[FacetLanguage].add(recordID, language.LangCode, "")
+				}
+			}
+			for _, yr := range did.Years() {
+				for decade := yr.From - yr.From%10; decade <= yr.To; decade += 10 {
+					decades = append(decades, decade)
+				}
+			}
+		}
+		sort.Ints(decades)
+		for _, decade := range decades {
+			facets[FacetDecade].add(recordID, Decade(decade), "")
+		}
+		for _, controlAccess := range archDesc.ControlAccess {
+			addControlAccessFacets(facets, recordID, controlAccess)
+		}
+		record.Walk(func(c *Component) error {
+			for _, controlAccess := range c.ControlAccess() {
+				addControlAccessFacets(facets, recordID, controlAccess)
+			}
+			return nil
+		})
+	}
+	aggregation := &Aggregation{Records: len(records), Facets: map[string]*Facet{}}
+	for _, name := range FacetNames {
+		aggregation.Facets[name] = facets[name].facet(name)
+	}
+	// Decades read best in chronological order
+	decades := aggregation.Facets[FacetDecade].Values
+	sort.SliceStable(decades, func(i, j int) bool {
+		a, _ := strconv.Atoi(strings.TrimSuffix(decades[i].Value, "s"))
+		b, _ := strconv.Atoi(strings.TrimSuffix(decades[j].Value, "s"))
+		return a < b
+	})
+	return aggregation
+}
+
+// Drilldown returns the ids of the records having value for the named facet, if
+// source is not empty only values from that vocabulary match
+func (aggregation *Aggregation) Drilldown(name, value, source string) []string {
+	recordIDs := []string{}
+	facet, ok := aggregation.Facets[name]
+	if ok == false {
+		return recordIDs
+	}
+	seen := map[string]bool{}
+	for _, fv := range facet.Values {
+		if fv.Value == value && (source == "" || fv.Source == strings.ToLower(source)) {
+			for _, recordID := range fv.RecordIDs {
+				if seen[recordID] == false {
+					seen[recordID] = true
+					recordIDs = append(recordIDs, recordID)
+				}
+			}
+		}
+	}
+	sort.Strings(recordIDs)
+	return recordIDs
+}
@@ -0,0 +1,107 @@
+//
+// facets_test.go tests browse facet aggregation.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"testing"
+)
+
+func TestAggregate(t *testing.T) {
+	records := []*EAD3{
+		readTestRecord(t, "testsamples/ead3/NCSU/mc00019.xml"),
+		readTestRecord(t, "testsamples/ead3/NCSU/mc00312.xml"),
+		readTestRecord(t, "testsamples/ead3/UMN/yusa0008-ead3.xml"),
+		New(),
+	}
+	aggregation := Aggregate(records)
+	if aggregation.Records != len(records) {
+		t.Errorf("expected %d records, got %d", len(records), aggregation.Records)
+	}
+	for _, name := range FacetNames {
+		if _, ok := aggregation.Facets[name]; ok == false {
+			t.Errorf("missing facet %q", name)
+		}
+	}
+
+	level := aggregation.Facets[FacetLevel]
+	if len(level.Values) != 1 || level.Values[0].Value != "collection" || level.Values[0].Count != 3 {
+		t.Errorf("unexpected level facet %+v", level.Values)
+	}
+
+	repositories := aggregation.Facets[FacetRepository]
+	if len(repositories.Values) != 2 || repositories.Values[0].Count != 2 {
+		t.Errorf("unexpected repository facet %+v", repositories.Values)
+	}
+	for _, fv := range repositories.Values {
+		if fv.Value == "University of Minnesota. Kautz Family YMCA Archives. [ymca]" {
+			continue
+		}
+		if fv.Value != "North Carolina State University Libraries, Special Collections Research Center" {
+			t.Errorf("unexpected repository %q", fv.Value)
+		}
+	}
+
+	decades := aggregation.Facets[FacetDecade].Values
+	for i := 1; i < len(decades); i++ {
+		if len(decades[i-1].Value) == len(decades[i].Value) && decades[i-1].Value >= decades[i].Value {
+			t.Errorf("decades out of order, %q before %q", decades[i-1].Value, decades[i].Value)
+		}
+	}
+	if recordIDs := aggregation.Drilldown(FacetDecade, "1820s", ""); len(recordIDs) != 0 {
+		t.Errorf("expected no records for the 1820s, got %v", recordIDs)
+	}
+	if recordIDs := aggregation.Drilldown(FacetDecade, "2000s", ""); len(recordIDs) != 2 || recordIDs[0] != "mc00019" || recordIDs[1] != "mc00312" {
+		t.Errorf("expected mc00019 and mc00312 for the 2000s, got %v", recordIDs)
+	}
+
+	// Each record is counted once per value however often the term appears
+	for _, fv := range aggregation.Facets[FacetSubject].Values {
+		if fv.Count != len(fv.RecordIDs) || fv.Count > 3 {
+			t.Errorf("unexpected count for %q, %d %v", fv.Value, fv.Count, fv.RecordIDs)
+		}
+	}
+	if recordIDs := aggregation.Drilldown(FacetSubject, "Audiotapes", ""); len(recordIDs) != 1 || recordIDs[0] != "mc00019" {
+		t.Errorf("expected mc00019 for Audiotapes, got %v", recordIDs)
+	}
+	if recordIDs := aggregation.Drilldown(FacetGeogName, "Havana (Cuba)", "lcsh"); len(recordIDs) != 0 {
+		t.Errorf("expected no records for Havana (Cuba), got %v", recordIDs)
+	}
+	if recordIDs := aggregation.Drilldown("nosuchfacet", "x", ""); len(recordIDs) != 0 {
+		t.Errorf("expected no records for unknown facet, got %v", recordIDs)
+	}
+	if Decade(1957) != "1950s" || Decade(950) != "950s" {
+		t.Errorf("unexpected decade labels %q, %q", Decade(1957), Decade(950))
+	}
+}
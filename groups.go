@@ -0,0 +1,158 @@
+//
+// groups.go flattens the EAD3 grouping elements (daoset, dateset, languageset, chronitemset).
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"strings"
+)
+
+// DAOs returns the digital archival objects of the DID, those grouped in a daoset
+// follow the ones given directly
+func (did *DID) DAOs() []*DAO {
+	daos := []*DAO{}
+	if did == nil {
+		return daos
+	}
+	daos = append(daos, did.DAO...)
+	for _, set := range did.DAOSet {
+		daos = append(daos, set.DAO...)
+	}
+	return daos
+}
+
+// Type returns the daotype of the dao (e.g. "derived"), or its otherdaotype when the
+// daotype is "otherdaotype"
+func (dao *DAO) Type() string {
+	if dao.DOAType == "otherdaotype" && dao.OtherDAOType != "" {
+		return dao.OtherDAOType
+	}
+	return dao.DOAType
+}
+
+// Label returns the dao's linktitle or, failing that, its descriptive note as plain text
+func (dao *DAO) Label() string {
+	if s := strings.TrimSpace(dao.LinkTitle); s != "" {
+		return s
+	}
+	if dao.DescriptiveNote == nil {
+		return ""
+	}
+	paragraphs := []string{}
+	for _, p := range dao.DescriptiveNote.P {
+		if s := StripMarkup(p.Value); s != "" {
+			paragraphs = append(paragraphs, s)
+		}
+	}
+	return strings.Join(paragraphs, " ")
+}
+
+// Languages returns the languages of the material, including those of its languagesets
+func (langMaterial *LangMaterial) Languages() []*Language {
+	languages := []*Language{}
+	if langMaterial == nil {
+		return languages
+	}
+	languages = append(languages, langMaterial.Language...)
+	for _, set := range langMaterial.LanguageSet {
+		languages = append(languages, set.Language...)
+	}
+	return languages
+}
+
+// Singles returns the single dates of a unitdatestructured, including those of its dateset
+func (structured *UnitDateStructured) Singles() []*DateSingle {
+	singles := []*DateSingle{}
+	if structured.DateSingle != nil {
+		singles = append(singles, structured.DateSingle)
+	}
+	if structured.DateSet != nil {
+		singles = append(singles, structured.DateSet.DateSingle...)
+	}
+	return singles
+}
+
+// Ranges returns the date ranges of a unitdatestructured, including those of its dateset
+func (structured *UnitDateStructured) Ranges() []*DateRange {
+	ranges := []*DateRange{}
+	ranges = append(ranges, structured.DateRange...)
+	if structured.DateSet != nil {
+		ranges = append(ranges, structured.DateSet.DateRange...)
+	}
+	return ranges
+}
+
+// String returns the text of a date range as "from-to"
+func (dateRange *DateRange) String() string {
+	from, to := "", ""
+	if dateRange.FromDate != nil {
+		from = strings.TrimSpace(dateRange.FromDate.Value)
+	}
+	if dateRange.ToDate != nil {
+		to = strings.TrimSpace(dateRange.ToDate.Value)
+	}
+	return strings.Trim(from+"-"+to, "-")
+}
+
+// Date returns the text of the chronitem's date, the dates of a dateset are joined by "; "
+func (item *ChronItem) Date() string {
+	dates := []string{}
+	if item.DateSingle != nil {
+		dates = append(dates, strings.TrimSpace(item.DateSingle.Value))
+	}
+	if item.DateRange != nil {
+		dates = append(dates, item.DateRange.String())
+	}
+	if set := item.DateSet; set != nil {
+		for _, single := range set.DateSingle {
+			dates = append(dates, strings.TrimSpace(single.Value))
+		}
+		for _, dateRange := range set.DateRange {
+			dates = append(dates, dateRange.String())
+		}
+	}
+	return strings.Join(dates, "; ")
+}
+
+// Events returns the event of the chronitem, or the events of its chronitemset
+func (item *ChronItem) Events() []*Event {
+	events := []*Event{}
+	if item.Event != nil {
+		events = append(events, item.Event)
+	}
+	if item.ChronItemSet != nil {
+		events = append(events, item.ChronItemSet.Event...)
+	}
+	return events
+}
@@ -0,0 +1,116 @@
+//
+// groups_test.go tests the EAD3 grouping elements.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestGroups(t *testing.T) {
+	record := readTestRecord(t, "testsamples/ead3/S.0001_valid.xml")
+	daos, languages, items := 0, []*Language{}, []*ChronItem{}
+	record.Walk(func(c *Component) error {
+		if did := c.DID(); did != nil {
+			for _, set := range did.DAOSet {
+				if set.Coverage != "part" {
+					t.Errorf("expected daoset coverage part, got %q", set.Coverage)
+				}
+			}
+			languages = append(languages, did.LangMaterial.Languages()...)
+		}
+		daos += len(c.DAOs())
+		for _, note := range c.Notes() {
+			for _, chronList := range note.ChronList {
+				items = append(items, chronList.ChronItem...)
+			}
+		}
+		return nil
+	})
+	if daos < 2 {
+		t.Errorf("expected the daos of the daoset, got %d", daos)
+	}
+	if len(languages) != 1 || languages[0].LangCode != "lat" {
+		t.Errorf("expected Latin from the languageset, got %+v", languages)
+	}
+
+	// the acqinfo chronlist holds a chronitemset and a dateset
+	if len(items) != 4 {
+		t.Fatalf("expected the 4 chronitems of the acqinfo chronlist, got %d", len(items))
+	}
+	first, last := items[0], items[3]
+	if first.Date() != "circa 1100" || len(first.Events()) != 1 {
+		t.Errorf("expected one event circa 1100, got %q with %d events", first.Date(), len(first.Events()))
+	}
+	if first.ChronItemSet == nil || len(first.ChronItemSet.Event) != 1 || strings.Contains(first.ChronItemSet.Event[0].Value, "<geogname") == false {
+		t.Errorf("expected the event of the chronitemset to keep its geogname, got %+v", first.ChronItemSet)
+	}
+	if date := last.Date(); date != "March 1924; July 1924-September 1924" {
+		t.Errorf("expected the dates of the dateset, got %q", date)
+	}
+	if events := last.Events(); len(events) != 2 || strings.HasPrefix(events[1].Value, "For nearly three months in 1924") == false {
+		t.Errorf("expected the events of the chronitemset, got %+v", events)
+	}
+
+	structured := &UnitDateStructured{DateSet: last.DateSet}
+	if years := structured.Years(); len(years) != 2 || years[0].From != 1924 || years[1].To != 1924 {
+		t.Errorf("expected the years of the dateset, got %+v", years)
+	}
+}
+
+func TestDAO(t *testing.T) {
+	src := []byte(`<dao daotype="otherdaotype" otherdaotype="iiif" href="https://example.edu/iiif/1/info.json"
+	identifier="ark:/12345/abc" linktitle="Page one" localtype="page" coverage="part">
+	<descriptivenote><p>The first page</p></descriptivenote>
+</dao>`)
+	dao := new(DAO)
+	if err := xml.Unmarshal(src, dao); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if dao.Identifier != "ark:/12345/abc" || dao.LocalType != "page" || dao.Coverage != "part" {
+		t.Errorf("expected the dao attributes, got %+v", dao)
+	}
+	if dao.Type() != "iiif" {
+		t.Errorf("expected otherdaotype as the type, got %q", dao.Type())
+	}
+	if dao.Label() != "Page one" {
+		t.Errorf("expected linktitle as the label, got %q", dao.Label())
+	}
+	dao.LinkTitle = ""
+	if dao.Label() != "The first page" {
+		t.Errorf("expected the descriptive note as the label, got %q", dao.Label())
+	}
+}
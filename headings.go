@@ -0,0 +1,510 @@
+//
+// headings.go builds canonical forms of controlled access headings and finds duplicates.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Heading is the canonical form of a controlled access name or term
+type Heading struct {
+	// Type is the element name, e.g. persname or subject
+	Type string `json:"type"`
+	// Value is the heading built from the element's parts
+	Value      string `json:"value"`
+	Normal     string `json:"normal,omitempty"`
+	Source     string `json:"source,omitempty"`
+	Rules      string `json:"rules,omitempty"`
+	Identifier string `json:"identifier,omitempty"`
+	// Element is the underlying *Persname, *Subject, etc.
+	Element interface{} `json:"-"`
+}
+
+// subdivisionTypes are part localtypes joined to the preceding part with "--"
+var subdivisionTypes = map[string]bool{
+	"topical":       true,
+	"geographic":    true,
+	"temporal":      true,
+	"chronological": true,
+	"genre_form":    true,
+	"genreform":     true,
+	"form":          true,
+	"subdivision":   true,
+	"v":             true,
+	"x":             true,
+	"y":             true,
+	"z":             true,
+}
+
+// subordinateTypes are part localtypes for subordinate units of a corporate name,
+// joined to the preceding part with ". "
+var subordinateTypes = map[string]bool{
+	"secondaryPart": true,
+	"tertiaryPart":  true,
+	"b":             true,
+}
+
+// qualifierTypes are part localtypes given in parentheses after the preceding part
+var qualifierTypes = map[string]bool{
+	"qualifier":  true,
+	"fullerForm": true,
+	"q":          true,
+}
+
+// abbreviation matches a final word whose period should be kept, e.g. "E.", "Jr." or "etc."
+var abbreviation = regexp.MustCompile(`(^|[\s.(])(\p{L}{1,3}|etc)\.$`)
+
+// trimPeriod removes the terminal period of a part unless it ends an abbreviation
+func trimPeriod(s string) string {
+	if strings.HasSuffix(s, ".") && abbreviation.MatchString(s) == false {
+		return strings.TrimSuffix(s, ".")
+	}
+	return s
+}
+
+// CanonicalParts joins the parts of a heading by their localtype, subdivisions (topical,
+// geographic, genre_form, etc.) with "--" as in LCSH, subordinate corporate units with
+// ". ", qualifiers in parentheses and other parts with defaultSep. Untyped parts are
+// joined with defaultSep.
+func CanonicalParts(parts []*Part, defaultSep string) string {
+	var buf strings.Builder
+	for _, part := range parts {
+		value := strings.Join(strings.Fields(StripMarkup(part.Value)), " ")
+		value = strings.TrimRight(value, ",;:")
+		if value == "" {
+			continue
+		}
+		localType := strings.TrimSpace(part.LocalType)
+		if buf.Len() == 0 {
+			buf.WriteString(value)
+			continue
+		}
+		prev := buf.String()
+		switch {
+		case subdivisionTypes[localType] == true:
+			buf.Reset()
+			buf.WriteString(trimPeriod(prev) + "--" + trimPeriod(value))
+		case subordinateTypes[localType] == true:
+			buf.Reset()
+			buf.WriteString(strings.TrimSuffix(prev, ".") + ". " + value)
+		case qualifierTypes[localType] == true:
+			if strings.HasPrefix(value, "(") == false {
+				value = "(" + value + ")"
+			}
+			buf.WriteString(" " + value)
+		default:
+			sep := defaultSep
+			if strings.HasPrefix(sep, ".") || sep == "--" {
+				buf.Reset()
+				buf.WriteString(trimPeriod(prev))
+			}
+			buf.WriteString(sep + value)
+		}
+	}
+	return trimPeriod(buf.String())
+}
+
+// NewHeading returns the heading of a *Persname, *Famname, *CorpName, *Name, *Subject,
+// *GenreForm, *GeogName, *Occupation or *Function, nil for any other value
+func NewHeading(element interface{}) *Heading {
+	switch e := element.(type) {
+	case *Persname:
+		return &Heading{Type: "persname", Value: CanonicalParts(e.Part, ", "), Normal: e.Normal, Source: e.Source, Rules: e.Rules, Identifier: e.Identifier, Element: e}
+	case *Famname:
+		return &Heading{Type: "famname", Value: CanonicalParts(e.Part, ", "), Normal: e.Normal, Source: e.Source, Rules: e.Rules, Identifier: e.Identifier, Element: e}
+	case *CorpName:
+		return &Heading{Type: "corpname", Value: CanonicalParts(e.Part, ". "), Normal: e.Normal, Source: e.Source, Rules: e.Rules, Identifier: e.Identifier, Element: e}
+	case *Subject:
+		return &Heading{Type: "subject", Value: CanonicalParts(e.Part, "--"), Normal: e.Normal, Source: e.Source, Rules: e.Rules, Identifier: e.Identifier, Element: e}
+	case *GenreForm:
+		return &Heading{Type: "genreform", Value: CanonicalParts(e.Part, "--"), Normal: e.Normal, Source: e.Source, Rules: e.Rules, Identifier: e.Identifier, Element: e}
+	case *GeogName:
+		return &Heading{Type: "geogname", Value: CanonicalParts(e.Part, "--"), Normal: e.Normal, Source: e.Source, Rules: e.Rules, Identifier: e.Identifier, Element: e}
+	case *Occupation:
+		return &Heading{Type: "occupation", Value: CanonicalParts(e.Part, "--"), Normal: e.Normal, Source: e.Source, Rules: e.Rules, Identifier: e.Identifier, Element: e}
+	case *Function:
+		return &Heading{Type: "function", Value: CanonicalParts(e.Part, "--"), Normal: e.Normal, Source: e.Source, Rules: e.Rules, Identifier: e.Identifier, Element: e}
+	case *Name:
+		return &Heading{Type: "name", Value: CanonicalParts(e.Part, ", "), Normal: e.Normal, Source: e.Source, Rules: e.Rules, Identifier: e.Identifier, Element: e}
+	}
+	return nil
+}
+
+// Term returns the heading's normal form if given, otherwise its canonical value
+func (heading *Heading) Term() string {
+	if s := strings.Join(strings.Fields(heading.Normal), " "); s != "" {
+		return s
+	}
+	return heading.Value
+}
+
+// SetIdentifier sets the identifier (e.g. an authority URI) and, if not empty, the
+// source of the heading's element
+func (heading *Heading) SetIdentifier(identifier, source string) {
+	switch e := heading.Element.(type) {
+	case *Persname:
+		e.Identifier = identifier
+		if source != "" {
+			e.Source = source
+		}
+	case *Famname:
+		e.Identifier = identifier
+		if source != "" {
+			e.Source = source
+		}
+	case *CorpName:
+		e.Identifier = identifier
+		if source != "" {
+			e.Source = source
+		}
+	case *Subject:
+		e.Identifier = identifier
+		if source != "" {
+			e.Source = source
+		}
+	case *GenreForm:
+		e.Identifier = identifier
+		if source != "" {
+			e.Source = source
+		}
+	case *GeogName:
+		e.Identifier = identifier
+		if source != "" {
+			e.Source = source
+		}
+	case *Occupation:
+		e.Identifier = identifier
+		if source != "" {
+			e.Source = source
+		}
+	case *Function:
+		e.Identifier = identifier
+		if source != "" {
+			e.Source = source
+		}
+	case *Name:
+		e.Identifier = identifier
+		if source != "" {
+			e.Source = source
+		}
+	}
+	heading.Identifier = identifier
+	if source != "" {
+		heading.Source = source
+	}
+}
+
+// key identifies identical headings
+func (heading *Heading) key() string {
+	return heading.Type + "\x00" + strings.ToLower(strings.TrimSpace(heading.Source)) + "\x00" + heading.Value
+}
+
+// headingDates matches date qualifiers in a heading such as "1859-1924", "b. 1920" or "ca. 1900"
+var headingDates = regexp.MustCompile(`(?i)\b(?:(?:b|d|fl|ca|approximately|active|born|died)\.?\s*)?\d{3,4}\??(?:\s*-\s*(?:\d{3,4}\??)?)?`)
+
+// LooseKey reduces a heading value to a key that ignores case, punctuation and date
+// qualifiers so "Hill, Daniel Harvey, 1859-1924." and "hill daniel harvey" match
+func LooseKey(value string) string {
+	value = headingDates.ReplaceAllString(value, " ")
+	value = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, value)
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// Headings returns the headings in the controlled access tree in document order
+func (controlAccess *ControlAccess) Headings() []*Heading {
+	headings := []*Heading{}
+	if controlAccess == nil {
+		return headings
+	}
+	for _, e := range controlAccess.Persname {
+		headings = append(headings, NewHeading(e))
+	}
+	for _, e := range controlAccess.Famname {
+		headings = append(headings, NewHeading(e))
+	}
+	for _, e := range controlAccess.CorpName {
+		headings = append(headings, NewHeading(e))
+	}
+	for _, e := range controlAccess.Subject {
+		headings = append(headings, NewHeading(e))
+	}
+	for _, e := range controlAccess.GenreForm {
+		headings = append(headings, NewHeading(e))
+	}
+	for _, e := range controlAccess.GeogName {
+		headings = append(headings, NewHeading(e))
+	}
+	for _, e := range controlAccess.Occupation {
+		headings = append(headings, NewHeading(e))
+	}
+	for _, e := range controlAccess.Function {
+		headings = append(headings, NewHeading(e))
+	}
+	for _, e := range controlAccess.Name {
+		headings = append(headings, NewHeading(e))
+	}
+	for _, child := range controlAccess.ControlAccess {
+		headings = append(headings, child.Headings()...)
+	}
+	return headings
+}
+
+// Headings returns the names of the creators
+func (origination *Origination) Headings() []*Heading {
+	headings := []*Heading{}
+	if origination == nil {
+		return headings
+	}
+	for _, e := range origination.Persname {
+		headings = append(headings, NewHeading(e))
+	}
+	for _, e := range origination.Famname {
+		headings = append(headings, NewHeading(e))
+	}
+	for _, e := range origination.CorpName {
+		headings = append(headings, NewHeading(e))
+	}
+	for _, e := range origination.Name {
+		headings = append(headings, NewHeading(e))
+	}
+	return headings
+}
+
+// dedupe removes headings already in seen, returning the number removed
+func (controlAccess *ControlAccess) dedupe(seen map[string]bool) int {
+	removed := 0
+	keep := func(element interface{}) bool {
+		key := NewHeading(element).key()
+		if seen[key] == true {
+			removed++
+			return false
+		}
+		seen[key] = true
+		return true
+	}
+	persnames := controlAccess.Persname[:0]
+	for _, e := range controlAccess.Persname {
+		if keep(e) {
+			persnames = append(persnames, e)
+		}
+	}
+	controlAccess.Persname = persnames
+	famnames := controlAccess.Famname[:0]
+	for _, e := range controlAccess.Famname {
+		if keep(e) {
+			famnames = append(famnames, e)
+		}
+	}
+	controlAccess.Famname = famnames
+	corpNames := controlAccess.CorpName[:0]
+	for _, e := range controlAccess.CorpName {
+		if keep(e) {
+			corpNames = append(corpNames, e)
+		}
+	}
+	controlAccess.CorpName = corpNames
+	subjects := controlAccess.Subject[:0]
+	for _, e := range controlAccess.Subject {
+		if keep(e) {
+			subjects = append(subjects, e)
+		}
+	}
+	controlAccess.Subject = subjects
+	genreForms := controlAccess.GenreForm[:0]
+	for _, e := range controlAccess.GenreForm {
+		if keep(e) {
+			genreForms = append(genreForms, e)
+		}
+	}
+	controlAccess.GenreForm = genreForms
+	geogNames := controlAccess.GeogName[:0]
+	for _, e := range controlAccess.GeogName {
+		if keep(e) {
+			geogNames = append(geogNames, e)
+		}
+	}
+	controlAccess.GeogName = geogNames
+	occupations := controlAccess.Occupation[:0]
+	for _, e := range controlAccess.Occupation {
+		if keep(e) {
+			occupations = append(occupations, e)
+		}
+	}
+	controlAccess.Occupation = occupations
+	functions := controlAccess.Function[:0]
+	for _, e := range controlAccess.Function {
+		if keep(e) {
+			functions = append(functions, e)
+		}
+	}
+	controlAccess.Function = functions
+	nameElements := controlAccess.Name[:0]
+	for _, e := range controlAccess.Name {
+		if keep(e) {
+			nameElements = append(nameElements, e)
+		}
+	}
+	controlAccess.Name = nameElements
+	for _, child := range controlAccess.ControlAccess {
+		removed += child.dedupe(seen)
+	}
+	return removed
+}
+
+// Dedupe removes headings repeated within the controlled access tree, keeping the first
+// occurrence. Headings are identical when they are the same element type with the same
+// source and canonical value. It returns the number of headings removed.
+func (controlAccess *ControlAccess) Dedupe() int {
+	if controlAccess == nil {
+		return 0
+	}
+	return controlAccess.dedupe(map[string]bool{})
+}
+
+// DedupeHeadings removes repeated headings from the controlled access of the archdesc
+// (treating its controlaccess elements as one tree) and of each component. It returns
+// the number of headings removed.
+func (ead *EAD3) DedupeHeadings() int {
+	removed := 0
+	if ead.ArchDesc != nil {
+		seen := map[string]bool{}
+		for _, controlAccess := range ead.ArchDesc.ControlAccess {
+			removed += controlAccess.dedupe(seen)
+		}
+	}
+	ead.Walk(func(c *Component) error {
+		seen := map[string]bool{}
+		for _, controlAccess := range c.ControlAccess() {
+			removed += controlAccess.dedupe(seen)
+		}
+		return nil
+	})
+	return removed
+}
+
+// HeadingVariant is one form of a heading found in a corpus
+type HeadingVariant struct {
+	Value     string   `json:"value"`
+	Source    string   `json:"source,omitempty"`
+	RecordIDs []string `json:"recordids"`
+}
+
+// NearDuplicate groups headings of the same type that differ only in case, punctuation
+// or date qualifiers
+type NearDuplicate struct {
+	Type     string            `json:"type"`
+	Key      string            `json:"key"`
+	Variants []*HeadingVariant `json:"variants"`
+}
+
+// NearDuplicates reports the headings in the records' controlled access (collection and
+// component level) that have more than one form
+func NearDuplicates(records []*EAD3) []*NearDuplicate {
+	groups := map[string]*NearDuplicate{}
+	variants := map[string]*HeadingVariant{}
+	add := func(recordID string, heading *Heading) {
+		key := LooseKey(heading.Value)
+		if key == "" {
+			return
+		}
+		groupKey := heading.Type + "\x00" + key
+		group, ok := groups[groupKey]
+		if ok == false {
+			group = &NearDuplicate{Type: heading.Type, Key: key}
+			groups[groupKey] = group
+		}
+		source := strings.ToLower(strings.TrimSpace(heading.Source))
+		variantKey := groupKey + "\x00" + source + "\x00" + heading.Value
+		variant, ok := variants[variantKey]
+		if ok == false {
+			variant = &HeadingVariant{Value: heading.Value, Source: source}
+			variants[variantKey] = variant
+			group.Variants = append(group.Variants, variant)
+		}
+		if n := len(variant.RecordIDs); n == 0 || variant.RecordIDs[n-1] != recordID {
+			variant.RecordIDs = append(variant.RecordIDs, recordID)
+		}
+	}
+	for i, record := range records {
+		if record == nil || record.ArchDesc == nil {
+			continue
+		}
+		recordID := facetRecordID(i, record)
+		for _, controlAccess := range record.ArchDesc.ControlAccess {
+			for _, heading := range controlAccess.Headings() {
+				add(recordID, heading)
+			}
+		}
+		record.Walk(func(c *Component) error {
+			for _, controlAccess := range c.ControlAccess() {
+				for _, heading := range controlAccess.Headings() {
+					add(recordID, heading)
+				}
+			}
+			return nil
+		})
+	}
+	report := []*NearDuplicate{}
+	for _, group := range groups {
+		values := map[string]bool{}
+		for _, variant := range group.Variants {
+			values[variant.Value] = true
+		}
+		if len(values) > 1 {
+			sort.Slice(group.Variants, func(i, j int) bool {
+				if group.Variants[i].Value != group.Variants[j].Value {
+					return group.Variants[i].Value < group.Variants[j].Value
+				}
+				return group.Variants[i].Source < group.Variants[j].Source
+			})
+			report = append(report, group)
+		}
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Type != report[j].Type {
+			return report[i].Type < report[j].Type
+		}
+		return report[i].Key < report[j].Key
+	})
+	return report
+}
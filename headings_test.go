@@ -0,0 +1,186 @@
+//
+// headings_test.go tests canonical headings, deduplication and near duplicate reports.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+)
+
+func TestCanonicalParts(t *testing.T) {
+	parts := func(pairs ...string) []*Part {
+		l := []*Part{}
+		for i := 0; i < len(pairs); i += 2 {
+			l = append(l, &Part{LocalType: pairs[i], Value: pairs[i+1]})
+		}
+		return l
+	}
+	testData := []struct {
+		parts    []*Part
+		sep      string
+		expected string
+	}{
+		{parts("a", "World War, 1914-1918", "x", "Campaigns."), "--", "World War, 1914-1918--Campaigns"},
+		{parts("topical", "United States", "topical", "Comic books, strips, etc."), "--", "United States--Comic books, strips, etc."},
+		{parts("a", "United States.", "b", " Army."), ". ", "United States. Army"},
+		{parts("surname", "Hill", "forename", "D. H.", "fullerForm", "Daniel Harvey", "existDates", "1821-1889"), ", ", "Hill, D. H. (Daniel Harvey), 1821-1889"},
+		{parts("surname", "Green", "forename", "Scott E."), ", ", "Green, Scott E."},
+		{parts("primaryPart", "Rotary Club ", "qualifier", "Raleigh, N.C."), ". ", "Rotary Club (Raleigh, N.C.)"},
+		{parts("", "Raleigh (N.C.)", "geographic", "Buildings"), "--", "Raleigh (N.C.)--Buildings"},
+	}
+	for _, td := range testData {
+		if s := CanonicalParts(td.parts, td.sep); s != td.expected {
+			t.Errorf("expected %q, got %q", td.expected, s)
+		}
+	}
+
+	if key := LooseKey("Hill, Daniel Harvey, 1859-1924."); key != "hill daniel harvey" {
+		t.Errorf("unexpected loose key %q", key)
+	}
+	if LooseKey("Serow, Robert C., b. 1947") != LooseKey("serow robert c") {
+		t.Errorf("expected date qualifier to be ignored")
+	}
+}
+
+func TestDedupeHeadings(t *testing.T) {
+	src := []byte(`<controlaccess>
+	<persname source="naf"><part localtype="surname">Hill</part><part localtype="forename">Daniel Harvey</part></persname>
+	<subject source="lcsh"><part>Audiotapes.</part></subject>
+	<subject source="lcsh"><part>Audiotapes</part></subject>
+	<subject source="aat"><part>Audiotapes</part></subject>
+	<controlaccess>
+		<persname source="NAF"><part localtype="surname">Hill</part><part localtype="forename">Daniel Harvey</part></persname>
+		<geogname><part>Raleigh (N.C.)</part></geogname>
+	</controlaccess>
+</controlaccess>`)
+	controlAccess := new(ControlAccess)
+	if err := xml.Unmarshal(src, &controlAccess); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if headings := controlAccess.Headings(); len(headings) != 6 {
+		t.Fatalf("expected 6 headings, got %d", len(headings))
+	}
+	if removed := controlAccess.Dedupe(); removed != 2 {
+		t.Errorf("expected 2 headings removed, got %d", removed)
+	}
+	if len(controlAccess.Subject) != 2 || controlAccess.Subject[1].Source != "aat" {
+		t.Errorf("expected lcsh and aat subjects to remain, got %d", len(controlAccess.Subject))
+	}
+	if len(controlAccess.ControlAccess[0].Persname) != 0 || len(controlAccess.ControlAccess[0].GeogName) != 1 {
+		t.Errorf("expected nested duplicate name to be removed")
+	}
+	if removed := controlAccess.Dedupe(); removed != 0 {
+		t.Errorf("expected nothing removed on second pass, got %d", removed)
+	}
+}
+
+func TestNearDuplicates(t *testing.T) {
+	record := func(recordID string, names ...string) *EAD3 {
+		ead := New()
+		ead.Control = &Control{RecordID: &RecordID{Value: recordID}}
+		controlAccess := new(ControlAccess)
+		for _, name := range names {
+			controlAccess.Persname = append(controlAccess.Persname, &Persname{Source: "naf", Part: []*Part{{Value: name}}})
+		}
+		ead.ArchDesc = &ArchDesc{ControlAccess: []*ControlAccess{controlAccess}}
+		return ead
+	}
+	records := []*EAD3{
+		record("a", "Hill, Daniel Harvey, 1859-1924", "Cummings, Ralph W."),
+		record("b", "Hill, Daniel Harvey"),
+		record("c", "HILL, DANIEL HARVEY, 1859-1924.", "Cummings, Ralph W."),
+	}
+	report := NearDuplicates(records)
+	if len(report) != 1 {
+		t.Fatalf("expected one near duplicate, got %d", len(report))
+	}
+	nd := report[0]
+	if nd.Type != "persname" || nd.Key != "hill daniel harvey" || len(nd.Variants) != 3 {
+		t.Errorf("unexpected near duplicate %+v", nd)
+	}
+	for _, variant := range nd.Variants {
+		if len(variant.RecordIDs) != 1 {
+			t.Errorf("expected one record for %q, got %v", variant.Value, variant.RecordIDs)
+		}
+	}
+}
+
+func TestAccessElementAttributes(t *testing.T) {
+	src := []byte(`<controlaccess>
+	<persname id="p1" identifier="http://id.loc.gov/authorities/names/n79021164" source="lcnaf" lang="eng" script="Latn" altrender="Twain, Mark" audience="external" localtype="author"><part>Twain, Mark</part><part localtype="dates">1835-1910</part></persname>
+	<function source="local" identifier="f12" audience="internal"><part>Magical instruction</part></function>
+	<name identifier="http://example.org/names/1" relator="collector"><part>Albert Shallowheart</part></name>
+	<geogname identifier="http://vocab.getty.edu/tgn/7005560"><part>Slackbuie</part><part>Scotland</part><geographiccoordinates coordinatesystem="UTM">30V 427400mE 6368503mN</geographiccoordinates></geogname>
+</controlaccess>`)
+	controlAccess := new(ControlAccess)
+	if err := xml.Unmarshal(src, &controlAccess); err != nil {
+		t.Fatalf("%s", err)
+	}
+	persname := controlAccess.Persname[0]
+	if persname.ID != "p1" || persname.Identifier != "http://id.loc.gov/authorities/names/n79021164" || persname.Lang != "eng" ||
+		persname.Script != "Latn" || persname.AltRender != "Twain, Mark" || persname.Audience != "external" || persname.LocalType != "author" {
+		t.Errorf("unexpected persname attributes %+v", persname)
+	}
+	if len(controlAccess.Function) != 1 || controlAccess.Function[0].Audience != "internal" {
+		t.Errorf("expected function, got %+v", controlAccess.Function)
+	}
+	if len(controlAccess.Name) != 1 || controlAccess.Name[0].Relator != "collector" {
+		t.Errorf("expected name, got %+v", controlAccess.Name)
+	}
+	geogName := controlAccess.GeogName[0]
+	if len(geogName.GeographicCoordinates) != 1 || geogName.GeographicCoordinates[0].CoordinateSystem != "UTM" {
+		t.Errorf("expected geographic coordinates, got %+v", geogName.GeographicCoordinates)
+	}
+
+	headings := controlAccess.Headings()
+	types := []string{}
+	for _, heading := range headings {
+		types = append(types, heading.Type)
+	}
+	if len(headings) != 4 || headings[0].Identifier != persname.Identifier || types[2] != "function" || types[3] != "name" {
+		t.Errorf("unexpected headings %v", types)
+	}
+
+	out, err := xml.Marshal(controlAccess)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	for _, s := range []string{`identifier="http://id.loc.gov/authorities/names/n79021164"`, `audience="internal"`, `<function `, `<name `, `coordinatesystem="UTM"`} {
+		if bytes.Contains(out, []byte(s)) == false {
+			t.Errorf("expected %s in %s", s, out)
+		}
+	}
+}
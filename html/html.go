@@ -0,0 +1,371 @@
+// Package html renders EAD3 finding aids as HTML pages using html/template.
+//
+// The default templates can be replaced one at a time (e.g. by an institution
+// wanting its own header or container list) with Parse, ParseFiles, ParseGlob or ParseFS.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+//   - Redistributions of source code must retain the above copyright notice, this
+//     list of conditions and the following disclaimer.
+//
+//   - Redistributions in binary form must reproduce the above copyright notice,
+//     this list of conditions and the following disclaimer in the documentation
+//     and/or other materials provided with the distribution.
+//
+//   - Neither the name of epgo nor the names of its
+//     contributors may be used to endorse or promote products derived from
+//     this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package html
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"strings"
+
+	"github.com/caltechlibrary/ead3"
+)
+
+//go:embed templates/*.html
+var defaultTemplates embed.FS
+
+// Renderer renders EAD3 records with a set of named templates. The page is rendered
+// by the "page" template which is passed a *Page.
+type Renderer struct {
+	templates *template.Template
+}
+
+// Page is the data passed to the "page" template
+type Page struct {
+	EAD        *ead3.EAD3
+	Title      template.HTML
+	Subtitle   template.HTML
+	Repository string
+	// Summary holds the collection overview taken from the ArchDesc DID
+	Summary []*Field
+	// Notes are the ArchDesc notes in display order
+	Notes []*Note
+	// Contents is the table of contents built from the note heads
+	Contents []*Entry
+	// DscHead is the head of the container list
+	DscHead        string
+	ContainerTypes []string
+	Components     []*Component
+}
+
+// Field is a labeled value in the collection overview
+type Field struct {
+	Label string
+	Value template.HTML
+}
+
+// Note is an ArchDesc or component note with the anchor used for it in the page
+type Note struct {
+	*ead3.Note
+	Anchor string
+}
+
+// Entry is an entry in the table of contents
+type Entry struct {
+	Label  string
+	Anchor string
+}
+
+// Component is a component of the Dsc ready for rendering
+type Component struct {
+	*ead3.Component
+	Anchor string
+	Title  template.HTML
+	Dates  string
+	// Containers holds the container values in the same order as Page.ContainerTypes
+	Containers []string
+	DAO        []*ead3.DAO
+	Notes      []*Note
+	Children   []*Component
+}
+
+var funcMap = template.FuncMap{
+	"markup": Markup,
+	"text":   ead3.StripMarkup,
+	"list":   List,
+}
+
+// List converts an EAD3 list into an HTML list
+func List(list *ead3.List) template.HTML {
+	return Markup(`<list listtype="` + template.HTMLEscapeString(list.ListType) + `">` + list.Value + `</list>`)
+}
+
+// New returns a Renderer using the default templates
+func New() (*Renderer, error) {
+	t, err := template.New("ead3").Funcs(funcMap).ParseFS(defaultTemplates, "templates/*.html")
+	if err != nil {
+		return nil, err
+	}
+	return &Renderer{templates: t}, nil
+}
+
+// Parse parses template definitions replacing any default templates of the same name
+func (r *Renderer) Parse(src string) error {
+	_, err := r.templates.Parse(src)
+	return err
+}
+
+// ParseFiles parses the template definitions in the named files
+func (r *Renderer) ParseFiles(filenames ...string) error {
+	_, err := r.templates.ParseFiles(filenames...)
+	return err
+}
+
+// ParseGlob parses the template definitions in the files matching pattern
+func (r *Renderer) ParseGlob(pattern string) error {
+	_, err := r.templates.ParseGlob(pattern)
+	return err
+}
+
+// ParseFS parses the template definitions in the files of fsys matching the patterns
+func (r *Renderer) ParseFS(fsys fs.FS, patterns ...string) error {
+	_, err := r.templates.ParseFS(fsys, patterns...)
+	return err
+}
+
+// Render writes the HTML finding aid for record to w
+func (r *Renderer) Render(w io.Writer, record *ead3.EAD3) error {
+	// NOTE: html/template can't parse after execution, clone so overrides stay possible.
+	t, err := r.templates.Clone()
+	if err != nil {
+		return err
+	}
+	return t.ExecuteTemplate(w, "page", NewPage(record))
+}
+
+// Render writes the HTML finding aid for record using the default templates
+func Render(w io.Writer, record *ead3.EAD3) error {
+	r, err := New()
+	if err != nil {
+		return err
+	}
+	return r.Render(w, record)
+}
+
+// anchorFor returns a unique HTML id for a note or component
+func anchorFor(used map[string]bool, id, fallback string) string {
+	anchor := id
+	if anchor == "" {
+		anchor = fallback
+	}
+	for i := 2; used[anchor] == true; i++ {
+		anchor = fmt.Sprintf("%s-%d", fallback, i)
+	}
+	used[anchor] = true
+	return anchor
+}
+
+func dates(did *ead3.DID) string {
+	values := []string{}
+	for _, unitDate := range did.UnitDate {
+		values = append(values, strings.TrimSpace(unitDate.Value))
+	}
+	for _, structured := range did.UnitDateStructured {
+		for _, single := range structured.Singles() {
+			values = append(values, strings.TrimSpace(single.Value))
+		}
+		for _, dateRange := range structured.Ranges() {
+			from, to := "", ""
+			if dateRange.FromDate != nil {
+				from = strings.TrimSpace(dateRange.FromDate.Value)
+			}
+			if dateRange.ToDate != nil {
+				to = strings.TrimSpace(dateRange.ToDate.Value)
+			}
+			values = append(values, strings.Trim(from+"-"+to, "-"))
+		}
+	}
+	return strings.Join(values, ", ")
+}
+
+func names(persnames []*ead3.Persname, famnames []*ead3.Famname, corpnames []*ead3.CorpName, otherNames []*ead3.Name) []string {
+	values := []string{}
+	for _, name := range persnames {
+		values = append(values, partsText(name.Part))
+	}
+	for _, name := range famnames {
+		values = append(values, partsText(name.Part))
+	}
+	for _, name := range corpnames {
+		values = append(values, partsText(name.Part))
+	}
+	for _, name := range otherNames {
+		values = append(values, partsText(name.Part))
+	}
+	return values
+}
+
+func partsText(parts []*ead3.Part) string {
+	values := []string{}
+	for _, part := range parts {
+		values = append(values, strings.TrimSpace(part.Value))
+	}
+	return strings.Join(values, ", ")
+}
+
+func summary(did *ead3.DID) []*Field {
+	fields := []*Field{}
+	add := func(label, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			fields = append(fields, &Field{Label: label, Value: Markup(value)})
+		}
+	}
+	if did.Origination != nil {
+		add("Creator", template.HTMLEscapeString(strings.Join(names(did.Origination.Persname, did.Origination.Famname, did.Origination.CorpName, did.Origination.Name), "; ")))
+	}
+	if did.UnitTitle != nil {
+		add("Title", did.UnitTitle.Value)
+	}
+	if did.UnitID != nil {
+		add("Identifier", did.UnitID.Value)
+	}
+	add("Dates", template.HTMLEscapeString(dates(did)))
+	if did.PhysDesc != nil {
+		add("Extent", did.PhysDesc.Value)
+	}
+	for _, physDesc := range did.PhysDescStructured {
+		extent := []string{}
+		if physDesc.Quantity != nil {
+			extent = append(extent, physDesc.Quantity.Value)
+		}
+		if physDesc.UnitType != nil {
+			extent = append(extent, physDesc.UnitType.Value)
+		}
+		add("Extent", template.HTMLEscapeString(strings.Join(extent, " ")))
+	}
+	for _, language := range did.LangMaterial.Languages() {
+		add("Language", language.Value)
+	}
+	if did.Abstract != nil {
+		add("Abstract", did.Abstract.Value)
+	}
+	if did.PhysLoc != nil {
+		add("Location", did.PhysLoc.Value)
+	}
+	return fields
+}
+
+// containerTypes returns the container localtypes used in the components in order of first use
+func containerTypes(components []*ead3.Component) []string {
+	types := []string{}
+	seen := map[string]bool{}
+	ead3.Walk(components, func(c *ead3.Component) error {
+		for _, container := range c.Containers() {
+			localType := strings.ToLower(container.LocalType)
+			if seen[localType] == false {
+				seen[localType] = true
+				types = append(types, localType)
+			}
+		}
+		return nil
+	})
+	return types
+}
+
+func newComponents(used map[string]bool, types []string, components []*ead3.Component) []*Component {
+	views := []*Component{}
+	for _, c := range components {
+		view := &Component{
+			Component:  c,
+			Anchor:     anchorFor(used, c.ID(), fmt.Sprintf("c-%d", len(used))),
+			Containers: make([]string, len(types)),
+			DAO:        c.DAOs(),
+		}
+		if did := c.DID(); did != nil {
+			if did.UnitTitle != nil {
+				view.Title = Markup(did.UnitTitle.Value)
+			}
+			view.Dates = dates(did)
+		}
+		for _, container := range c.Containers() {
+			for i, localType := range types {
+				if strings.ToLower(container.LocalType) == localType {
+					view.Containers[i] = strings.TrimSpace(strings.Join([]string{view.Containers[i], container.Value}, " "))
+				}
+			}
+		}
+		for _, note := range c.Notes() {
+			view.Notes = append(view.Notes, &Note{Note: note, Anchor: anchorFor(used, note.ID, view.Anchor+"-"+note.Name)})
+		}
+		view.Children = newComponents(used, types, c.Children)
+		views = append(views, view)
+	}
+	return views
+}
+
+// NewPage builds the data passed to the "page" template for record
+func NewPage(record *ead3.EAD3) *Page {
+	page := new(Page)
+	page.EAD = record
+	// NOTE: "overview" and "dsc" are the anchors used by the default templates
+	used := map[string]bool{"overview": true, "dsc": true}
+	if record.Control != nil && record.Control.FileDesc != nil && record.Control.FileDesc.TitleStmt != nil {
+		titleStmt := record.Control.FileDesc.TitleStmt
+		if titleStmt.TitleProper != nil {
+			page.Title = Markup(titleStmt.TitleProper.Value)
+		}
+		if titleStmt.Subtitle != nil {
+			page.Subtitle = Markup(titleStmt.Subtitle.Value)
+		}
+	}
+	archDesc := record.ArchDesc
+	if archDesc == nil {
+		return page
+	}
+	for _, did := range archDesc.DID {
+		if page.Title == "" && did.UnitTitle != nil {
+			page.Title = Markup(did.UnitTitle.Value)
+		}
+		if did.Repository != nil {
+			page.Repository = strings.Join(names(did.Repository.Persname, did.Repository.Famname, did.Repository.CorpName, did.Repository.Name), "; ")
+		}
+		page.Summary = append(page.Summary, summary(did)...)
+	}
+	if len(page.Summary) > 0 {
+		page.Contents = append(page.Contents, &Entry{Label: "Collection Overview", Anchor: "overview"})
+	}
+	for _, note := range archDesc.Notes() {
+		n := &Note{Note: note, Anchor: anchorFor(used, note.ID, note.Name)}
+		page.Notes = append(page.Notes, n)
+		if note.Depth == 0 {
+			page.Contents = append(page.Contents, &Entry{Label: note.Label(), Anchor: n.Anchor})
+		}
+	}
+	if archDesc.Dsc != nil {
+		components := archDesc.Dsc.Components()
+		page.DscHead = "Collection Inventory"
+		if archDesc.Dsc.Head != nil {
+			page.DscHead = ead3.StripMarkup(archDesc.Dsc.Head.Value)
+		}
+		page.ContainerTypes = containerTypes(components)
+		page.Components = newComponents(used, page.ContainerTypes, components)
+		if len(page.Components) > 0 {
+			page.Contents = append(page.Contents, &Entry{Label: page.DscHead, Anchor: "dsc"})
+		}
+	}
+	return page
+}
@@ -0,0 +1,143 @@
+//
+// html_test.go tests rendering finding aids as HTML.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package html
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/caltechlibrary/ead3"
+)
+
+func readRecord(t *testing.T, fname string) *ead3.EAD3 {
+	src, err := ioutil.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	record := ead3.New()
+	if err := xml.Unmarshal(src, &record); err != nil {
+		t.Fatalf("%s, %s", fname, err)
+	}
+	return record
+}
+
+func TestMarkup(t *testing.T) {
+	testData := map[string]string{
+		`plain &amp; simple`:                                                                `plain &amp; simple`,
+		`<emph render="italic">Dissendium</emph>`:                                           `<em>Dissendium</em>`,
+		`<emph render="bold">Bold</emph>`:                                                   `<strong>Bold</strong>`,
+		`see <ref href="http://example.edu/a">here</ref>`:                                   `see <a href="http://example.edu/a">here</a>`,
+		`see <ref target="c01">series 1</ref>`:                                              `see <a href="#c01">series 1</a>`,
+		`<ref href="javascript:alert(1)">x</ref>`:                                           `x`,
+		`<title render="italic">A Title</title>`:                                            `<cite>A Title</cite>`,
+		`<list><item>one</item><item>two</item></list>`:                                     `<ul><li>one</li><li>two</li></ul>`,
+		`<list listtype="deflist"><defitem><label>a</label><item>b</item></defitem></list>`: `<dl><dt>a</dt><dd>b</dd></dl>`,
+		`a <script>alert(1)</script>`:                                                       `a alert(1)`,
+	}
+	for src, expected := range testData {
+		if result := string(Markup(src)); result != expected {
+			t.Errorf("Markup(%q), expected %q, got %q", src, expected, result)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	record := readRecord(t, "../testsamples/ead3/NCSU/mc00019.xml")
+	buf := new(bytes.Buffer)
+	if err := Render(buf, record); err != nil {
+		t.Fatalf("%s", err)
+	}
+	src := buf.String()
+	for _, expected := range []string{
+		`<nav class="toc">`,
+		`<a href="#overview">Collection Overview</a>`,
+		`<section id="overview" class="overview">`,
+		`<section id="dsc" class="dsc">`,
+		`<details open>`,
+	} {
+		if strings.Contains(src, expected) == false {
+			t.Errorf("expected %q in rendered page", expected)
+		}
+	}
+	page := NewPage(record)
+	if len(page.Notes) == 0 {
+		t.Errorf("expected notes from ArchDesc")
+	}
+	if len(page.Contents) != len(page.Notes)+2 {
+		t.Errorf("expected %d table of contents entries, got %d", len(page.Notes)+2, len(page.Contents))
+	}
+	if len(page.ContainerTypes) == 0 {
+		t.Errorf("expected container columns")
+	}
+}
+
+func TestOverride(t *testing.T) {
+	record := readRecord(t, "../testsamples/ead3/UMN/mss060.xml")
+	r, err := New()
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if err := r.Parse(`{{define "style"}}<link rel="stylesheet" href="/css/umn.css">{{end}}`); err != nil {
+		t.Fatalf("%s", err)
+	}
+	buf := new(bytes.Buffer)
+	if err := r.Render(buf, record); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if strings.Contains(buf.String(), `href="/css/umn.css"`) == false {
+		t.Errorf("expected the overridden style template")
+	}
+	// Rendering must not prevent further overrides
+	if err := r.Parse(`{{define "toc"}}{{end}}`); err != nil {
+		t.Errorf("expected to parse after rendering, %s", err)
+	}
+}
+
+func TestRenderSamples(t *testing.T) {
+	for _, fname := range []string{
+		"../testsamples/ead3/EAD3test.xml",
+		"../testsamples/ead3/S.0001_valid.xml",
+		"../testsamples/ead3/NCSU/mc00003.xml",
+		"../testsamples/ead3/UMN/uarc01180.xml",
+	} {
+		record := readRecord(t, fname)
+		if err := Render(ioutil.Discard, record); err != nil {
+			t.Errorf("%s, %s", fname, err)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+//
+// markup.go converts EAD3 mixed content into HTML.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package html
+
+import (
+	"html/template"
+	"strings"
+
+	"github.com/caltechlibrary/ead3"
+)
+
+// emphTags maps emph@render values to HTML elements
+var emphTags = map[string]string{
+	"bold":            "strong",
+	"boldunderline":   "strong",
+	"bolditalic":      "strong",
+	"boldsmcaps":      "strong",
+	"italic":          "em",
+	"underline":       "u",
+	"super":           "sup",
+	"sub":             "sub",
+	"quoted":          "q",
+	"boldquoted":      "q",
+	"doublequote":     "q",
+	"bolddoublequote": "q",
+}
+
+// inlineTags maps EAD3 elements directly to an HTML element
+var inlineTags = map[string]string{
+	"p":          "p",
+	"blockquote": "blockquote",
+	"quote":      "q",
+	"title":      "cite",
+	"abbr":       "abbr",
+	"footnote":   "small",
+}
+
+// safeURL reports if href can be used as a link, script URLs are refused
+func safeURL(href string) bool {
+	s := strings.ToLower(strings.TrimSpace(href))
+	return strings.HasPrefix(s, "javascript:") == false && strings.HasPrefix(s, "vbscript:") == false && strings.HasPrefix(s, "data:") == false
+}
+
+func writeAnchor(buf *strings.Builder, node *ead3.MarkupNode) {
+	href := node.Attr["href"]
+	if href == "" && node.Attr["target"] != "" {
+		href = "#" + node.Attr["target"]
+	}
+	if href == "" || safeURL(href) == false {
+		writeNodes(buf, node.Children)
+		return
+	}
+	buf.WriteString(`<a href="` + template.HTMLEscapeString(href) + `"`)
+	if node.Attr["show"] == "new" {
+		buf.WriteString(` target="_blank" rel="noopener"`)
+	}
+	buf.WriteString(">")
+	if len(node.Children) == 0 {
+		// NOTE: ptr and extptr are empty elements, use their link title or address
+		if s := node.Attr["linktitle"]; s != "" {
+			buf.WriteString(template.HTMLEscapeString(s))
+		} else {
+			buf.WriteString(template.HTMLEscapeString(href))
+		}
+	}
+	writeNodes(buf, node.Children)
+	buf.WriteString("</a>")
+}
+
+func writeList(buf *strings.Builder, node *ead3.MarkupNode) {
+	tag := "ul"
+	switch node.Attr["listtype"] {
+	case "ordered":
+		tag = "ol"
+	case "deflist":
+		tag = "dl"
+	}
+	for _, child := range node.Children {
+		if child.Name == "head" {
+			buf.WriteString(`<p class="list-head">`)
+			writeNodes(buf, child.Children)
+			buf.WriteString("</p>")
+		}
+	}
+	buf.WriteString("<" + tag + ">")
+	for _, child := range node.Children {
+		switch child.Name {
+		case "item":
+			buf.WriteString("<li>")
+			writeNodes(buf, child.Children)
+			buf.WriteString("</li>")
+		case "defitem":
+			for _, part := range child.Children {
+				switch part.Name {
+				case "label":
+					buf.WriteString("<dt>")
+					writeNodes(buf, part.Children)
+					buf.WriteString("</dt>")
+				case "item":
+					buf.WriteString("<dd>")
+					writeNodes(buf, part.Children)
+					buf.WriteString("</dd>")
+				}
+			}
+		}
+	}
+	buf.WriteString("</" + tag + ">")
+}
+
+func writeNodes(buf *strings.Builder, nodes []*ead3.MarkupNode) {
+	for _, node := range nodes {
+		switch node.Name {
+		case "":
+			buf.WriteString(template.HTMLEscapeString(node.Text))
+		case "lb":
+			buf.WriteString("<br>")
+		case "emph":
+			tag, ok := emphTags[node.Attr["render"]]
+			if ok == false {
+				tag = "em"
+			}
+			buf.WriteString("<" + tag + ">")
+			writeNodes(buf, node.Children)
+			buf.WriteString("</" + tag + ">")
+		case "ref", "extref", "ptr", "extptr":
+			writeAnchor(buf, node)
+		case "list":
+			writeList(buf, node)
+		case "foreign":
+			buf.WriteString(`<span lang="` + template.HTMLEscapeString(node.Attr["lang"]) + `">`)
+			writeNodes(buf, node.Children)
+			buf.WriteString("</span>")
+		default:
+			if tag, ok := inlineTags[node.Name]; ok == true {
+				if node.Name == "abbr" && node.Attr["expan"] != "" {
+					buf.WriteString(`<abbr title="` + template.HTMLEscapeString(node.Attr["expan"]) + `">`)
+				} else {
+					buf.WriteString("<" + tag + ">")
+				}
+				writeNodes(buf, node.Children)
+				buf.WriteString("</" + tag + ">")
+			} else {
+				writeNodes(buf, node.Children)
+			}
+		}
+	}
+}
+
+// Markup converts EAD3 mixed content (e.g. the Value of a P or UnitTitle) into HTML.
+// Elements without an HTML equivalent contribute their text, if the content can't be
+// parsed it is returned as escaped text.
+func Markup(src string) template.HTML {
+	nodes, err := ead3.ParseMarkup(src)
+	if err != nil {
+		return template.HTML(template.HTMLEscapeString(ead3.StripMarkup(src)))
+	}
+	buf := new(strings.Builder)
+	writeNodes(buf, nodes)
+	return template.HTML(buf.String())
+}
@@ -0,0 +1,253 @@
+//
+// ids.go indexes the id attributes of a finding aid and the references to them.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// IDElement is an element carrying an id attribute
+type IDElement struct {
+	ID string
+	// Name is the element name, e.g. "c" or "footnote"
+	Name string
+	// Element is the element (e.g. *C), or for an id in embedded XML the element
+	// holding the markup (e.g. the *P containing a <footnote>)
+	Element interface{}
+	// Embedded is true when the id is in embedded XML
+	Embedded bool
+}
+
+// IDReference is an attribute pointing at an id, i.e. the target of a <ref> or <ptr>
+type IDReference struct {
+	Target string
+	// Name is the element name, e.g. "ref" or "ptr"
+	Name string
+	// Element is the referring element, or the element holding the embedded XML
+	Element  interface{}
+	Embedded bool
+}
+
+// IDIndex lists the ids of a record and the references to them in document order
+type IDIndex struct {
+	Elements   []*IDElement
+	References []*IDReference
+	byID       map[string][]*IDElement
+}
+
+// embeddedTag matches a start or empty element tag in embedded XML
+var embeddedTag = regexp.MustCompile(`<([A-Za-z_][\w.:-]*)((?:\s+[\w.:-]+\s*=\s*(?:"[^"]*"|'[^']*'))*)(\s*/?>)`)
+
+// embeddedIDAttr matches the id and target attributes of a tag in embedded XML
+var embeddedIDAttr = regexp.MustCompile(`(\s)(id|target)(\s*=\s*)(?:"([^"]*)"|'([^']*)')`)
+
+// idVisitor is called for each id ("id") and reference ("target") attribute, the
+// attribute is set to the value returned
+type idVisitor func(attr, value, name string, element interface{}, embedded bool) string
+
+// elementName returns the element name of a struct from the tag of its XMLName field
+func elementName(t reflect.Type) string {
+	if field, ok := t.FieldByName("XMLName"); ok == true {
+		return strings.Split(field.Tag.Get("xml"), ",")[0]
+	}
+	return strings.ToLower(t.Name())
+}
+
+// visitEmbeddedIDs calls fn for the id and target attributes in embedded XML
+func visitEmbeddedIDs(src string, owner interface{}, fn idVisitor) string {
+	if strings.Contains(src, "<") == false {
+		return src
+	}
+	return embeddedTag.ReplaceAllStringFunc(src, func(tag string) string {
+		m := embeddedTag.FindStringSubmatch(tag)
+		name := m[1]
+		attrs := embeddedIDAttr.ReplaceAllStringFunc(m[2], func(attr string) string {
+			a := embeddedIDAttr.FindStringSubmatch(attr)
+			value, quote := a[4], attr[len(a[1])+len(a[2])+len(a[3]):][0:1]
+			if quote == "'" {
+				value = a[5]
+			}
+			if value == "" {
+				return attr
+			}
+			return a[1] + a[2] + a[3] + quote + fn(a[2], value, name, owner, true) + quote
+		})
+		return "<" + name + attrs + m[3]
+	})
+}
+
+// visitIDs walks v in document order calling fn for each id and target attribute,
+// including those in embedded XML
+func visitIDs(v reflect.Value, owner interface{}, fn idVisitor) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() == false {
+			visitIDs(v.Elem(), v.Interface(), fn)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			visitIDs(v.Index(i), owner, fn)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		name := elementName(t)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" || field.Name == "XMLName" {
+				continue
+			}
+			f := v.Field(i)
+			if f.Kind() != reflect.String {
+				visitIDs(f, owner, fn)
+				continue
+			}
+			tag := field.Tag.Get("xml")
+			switch {
+			case strings.HasPrefix(tag, "id,attr") && f.String() != "":
+				f.SetString(fn("id", f.String(), name, owner, false))
+			case strings.HasPrefix(tag, "target,attr") && f.String() != "":
+				f.SetString(fn("target", f.String(), name, owner, false))
+			case strings.HasSuffix(tag, ",innerxml"):
+				f.SetString(visitEmbeddedIDs(f.String(), owner, fn))
+			}
+		}
+	}
+}
+
+// IDs indexes the ids of the record's elements and the references to them
+func (ead *EAD3) IDs() *IDIndex {
+	index := &IDIndex{byID: map[string][]*IDElement{}}
+	if ead == nil {
+		return index
+	}
+	visitIDs(reflect.ValueOf(ead), ead, func(attr, value, name string, element interface{}, embedded bool) string {
+		if attr == "id" {
+			e := &IDElement{ID: value, Name: name, Element: element, Embedded: embedded}
+			index.Elements = append(index.Elements, e)
+			index.byID[value] = append(index.byID[value], e)
+		} else {
+			index.References = append(index.References, &IDReference{Target: value, Name: name, Element: element, Embedded: embedded})
+		}
+		return value
+	})
+	return index
+}
+
+// ByID returns the first element with the id, nil if there is none. For an id in
+// embedded XML it is the element holding the markup.
+func (index *IDIndex) ByID(id string) interface{} {
+	if elements := index.byID[id]; len(elements) > 0 {
+		return elements[0].Element
+	}
+	return nil
+}
+
+// ByID returns the first element of the record with the id, nil if there is none. It
+// indexes the record on each call, use IDs() for repeated lookups.
+func (ead *EAD3) ByID(id string) interface{} {
+	return ead.IDs().ByID(id)
+}
+
+// Duplicates returns the ids carried by more than one element, in document order
+func (index *IDIndex) Duplicates() []string {
+	ids := []string{}
+	for _, e := range index.Elements {
+		if elements := index.byID[e.ID]; len(elements) > 1 && elements[0] == e {
+			ids = append(ids, e.ID)
+		}
+	}
+	return ids
+}
+
+// Dangling returns the references whose target is not the id of any element
+func (index *IDIndex) Dangling() []*IDReference {
+	dangling := []*IDReference{}
+	for _, ref := range index.References {
+		if _, ok := index.byID[ref.Target]; ok == false {
+			dangling = append(dangling, ref)
+		}
+	}
+	return dangling
+}
+
+// RenameIDs changes the ids found in mapping (old id to new id) along with every
+// reference to them, returning the number of attributes changed
+func (ead *EAD3) RenameIDs(mapping map[string]string) int {
+	changed := 0
+	if ead == nil {
+		return changed
+	}
+	visitIDs(reflect.ValueOf(ead), ead, func(attr, value, name string, element interface{}, embedded bool) string {
+		if s, ok := mapping[value]; ok == true && s != value {
+			changed++
+			return s
+		}
+		return value
+	})
+	return changed
+}
+
+// RenumberIDs gives every element with an id a new id made of prefix and its position
+// in document order (e.g. "id1", "id2"), and rewrites the references to match. Elements
+// sharing a duplicate id get distinct ids, references follow the first of them. It
+// returns the mapping from old to new ids.
+func (ead *EAD3) RenumberIDs(prefix string) map[string]string {
+	mapping := map[string]string{}
+	if ead == nil {
+		return mapping
+	}
+	n := 0
+	visitIDs(reflect.ValueOf(ead), ead, func(attr, value, name string, element interface{}, embedded bool) string {
+		if attr != "id" {
+			return value
+		}
+		n++
+		id := prefix + strconv.Itoa(n)
+		if _, ok := mapping[value]; ok == false {
+			mapping[value] = id
+		}
+		return id
+	})
+	visitIDs(reflect.ValueOf(ead), ead, func(attr, value, name string, element interface{}, embedded bool) string {
+		if s, ok := mapping[value]; ok == true && attr == "target" {
+			return s
+		}
+		return value
+	})
+	return mapping
+}
@@ -0,0 +1,113 @@
+//
+// ids_test.go tests the id index and renumbering.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestIDs(t *testing.T) {
+	record := readTestRecord(t, "testsamples/ead3/S.0001_valid.xml")
+	ids := record.IDs()
+	relation, ok := ids.ByID("hhuf").(*Relation)
+	if ok == false || relation.HRef != "http://eadiva.com/hogwarts-cpf/H.001.xml" {
+		t.Errorf("expected the hhuf relation, got %+v", ids.ByID("hhuf"))
+	}
+	if record.ByID("missing") != nil {
+		t.Errorf("expected no element for a missing id")
+	}
+	targets := map[string]bool{}
+	for _, ref := range ids.References {
+		targets[ref.Target] = true
+	}
+	for _, target := range []string{"ggry", "hhuf", "rrav"} {
+		if targets[target] == false {
+			t.Errorf("expected a reference to %s", target)
+		}
+	}
+	if dangling := ids.Dangling(); len(dangling) != 0 {
+		t.Errorf("expected no dangling references, got %d", len(dangling))
+	}
+
+	// ids and references in embedded XML, a duplicate id and a dangling pointer
+	numberComponents(record)
+	scopeContent := record.ArchDesc.Dsc.C[0].ScopeContent[0]
+	scopeContent.P = append(scopeContent.P,
+		&P{Value: `See the note<footnote id='fn1'><p>A footnote</p></footnote> and <ref target="c3">a later series</ref>.`},
+		&P{Value: `<ptr target="nowhere"/>`})
+	record.ArchDesc.Dsc.C[1].ID = "c1"
+	ids = record.IDs()
+	if p, ok := ids.ByID("fn1").(*P); ok == false || strings.Contains(p.Value, "footnote") == false {
+		t.Errorf("expected the paragraph holding fn1, got %+v", ids.ByID("fn1"))
+	}
+	if duplicates := ids.Duplicates(); len(duplicates) != 1 || duplicates[0] != "c1" {
+		t.Errorf("expected c1 to be duplicated, got %+v", duplicates)
+	}
+	if dangling := ids.Dangling(); len(dangling) != 1 || dangling[0].Target != "nowhere" || dangling[0].Embedded == false {
+		t.Errorf("expected nowhere to be dangling, got %d", len(dangling))
+	}
+
+	mapping := record.RenumberIDs("x")
+	if mapping["hhuf"] == "" || mapping["fn1"] == "" || mapping["c1"] == "" {
+		t.Fatalf("expected hhuf, fn1 and c1 to be renumbered, got %+v", mapping)
+	}
+	ids = record.IDs()
+	if len(ids.Duplicates()) != 0 {
+		t.Errorf("expected no duplicates after renumbering, got %+v", ids.Duplicates())
+	}
+	if _, ok := ids.ByID(mapping["hhuf"]).(*Relation); ok == false {
+		t.Errorf("expected %s to be the hhuf relation", mapping["hhuf"])
+	}
+	for _, ref := range ids.References {
+		if ref.Target == "hhuf" || ref.Target == "ggry" || ref.Target == "rrav" {
+			t.Errorf("expected references to be renumbered, found %s", ref.Target)
+		}
+	}
+	if dangling := ids.Dangling(); len(dangling) != 1 {
+		t.Errorf("expected 1 dangling reference, got %d", len(dangling))
+	}
+	if strings.Contains(scopeContent.P[len(scopeContent.P)-2].Value, "<footnote id='"+mapping["fn1"]+"'>") == false {
+		t.Errorf("expected the footnote id to be renumbered, got %s", scopeContent.P[len(scopeContent.P)-2].Value)
+	}
+
+	if n := record.RenameIDs(map[string]string{mapping["hhuf"]: "helga"}); n != 2 {
+		t.Errorf("expected the id and a reference to be renamed, got %d", n)
+	}
+	if _, err := xml.Marshal(record); err != nil {
+		t.Errorf("%s", err)
+	}
+}
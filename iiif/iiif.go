@@ -0,0 +1,525 @@
+//
+// Package iiif exports the digital archival objects of finding aids as IIIF
+// Presentation 3 manifests.
+//
+// A manifest is made for each component with digital archival objects, one canvas per
+// <dao>, labeled with the component's title and carrying its dates, unitid, containers
+// and effective access as metadata. The manifests are gathered into a collection for the
+// finding aid following the hierarchy of the components, a component holding others
+// with digital objects becomes a collection of its own.
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package iiif
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/caltechlibrary/ead3"
+)
+
+// Context is the JSON-LD context of the IIIF Presentation 3 API
+const Context = "http://iiif.io/api/presentation/3/context.json"
+
+// LanguageMap holds values by language code, "none" for values without a language
+type LanguageMap map[string][]string
+
+// MetadataEntry is a label and value pair shown to users
+type MetadataEntry struct {
+	Label LanguageMap `json:"label"`
+	Value LanguageMap `json:"value"`
+}
+
+// Resource is content painted on a canvas or linked from a manifest, it is also used
+// to reference a manifest from a collection
+type Resource struct {
+	ID     string      `json:"id"`
+	Type   string      `json:"type"`
+	Label  LanguageMap `json:"label,omitempty"`
+	Format string      `json:"format,omitempty"`
+}
+
+// Annotation paints a resource onto a canvas
+type Annotation struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	Motivation string    `json:"motivation"`
+	Body       *Resource `json:"body"`
+	Target     string    `json:"target"`
+}
+
+// AnnotationPage holds the annotations of a canvas
+type AnnotationPage struct {
+	ID    string        `json:"id"`
+	Type  string        `json:"type"`
+	Items []*Annotation `json:"items"`
+}
+
+// Canvas is a view of one digital archival object
+type Canvas struct {
+	ID       string            `json:"id"`
+	Type     string            `json:"type"`
+	Label    LanguageMap       `json:"label,omitempty"`
+	Width    int               `json:"width,omitempty"`
+	Height   int               `json:"height,omitempty"`
+	Metadata []*MetadataEntry  `json:"metadata,omitempty"`
+	Items    []*AnnotationPage `json:"items"`
+}
+
+// Manifest describes the digital archival objects of a component
+type Manifest struct {
+	Context           string           `json:"@context,omitempty"`
+	ID                string           `json:"id"`
+	Type              string           `json:"type"`
+	Label             LanguageMap      `json:"label"`
+	Summary           LanguageMap      `json:"summary,omitempty"`
+	Metadata          []*MetadataEntry `json:"metadata,omitempty"`
+	RequiredStatement *MetadataEntry   `json:"requiredStatement,omitempty"`
+	Items             []*Canvas        `json:"items"`
+
+	// key names the manifest's directory, see WriteDir
+	key string
+}
+
+// Collection gathers the manifests of a finding aid or of a component. Items holds
+// *Resource references to manifests and embedded *Collection values.
+type Collection struct {
+	Context  string           `json:"@context,omitempty"`
+	ID       string           `json:"id"`
+	Type     string           `json:"type"`
+	Label    LanguageMap      `json:"label"`
+	Summary  LanguageMap      `json:"summary,omitempty"`
+	Metadata []*MetadataEntry `json:"metadata,omitempty"`
+	Items    []interface{}    `json:"items"`
+
+	// key names the collection's directory, empty for the finding aid, see WriteDir
+	key string
+}
+
+// Exporter converts finding aids into IIIF manifests and collections
+type Exporter struct {
+	// BaseURL is the address the manifests are published under, e.g.
+	// "https://iiif.example.edu/findingaids"
+	BaseURL string
+	// Language is the language of labels and metadata, default "en"
+	Language string
+	// Width and Height size the canvases, EAD3 does not record the size of the objects
+	Width  int
+	Height int
+	// AsOf is the date access restrictions are evaluated on, the current time if zero
+	AsOf time.Time
+
+	record    *ead3.EAD3
+	base      string
+	manifests []*Manifest
+}
+
+// NewExporter returns an Exporter publishing under baseURL with English labels and
+// 1000 by 1000 canvases
+func NewExporter(baseURL string) *Exporter {
+	return &Exporter{
+		BaseURL:  baseURL,
+		Language: "en",
+		Width:    1000,
+		Height:   1000,
+	}
+}
+
+// mediaTypes maps file extensions to the type and format of the resource painted
+var mediaTypes = map[string][2]string{
+	".jpg":  {"Image", "image/jpeg"},
+	".jpeg": {"Image", "image/jpeg"},
+	".png":  {"Image", "image/png"},
+	".gif":  {"Image", "image/gif"},
+	".tif":  {"Image", "image/tiff"},
+	".tiff": {"Image", "image/tiff"},
+	".jp2":  {"Image", "image/jp2"},
+	".webp": {"Image", "image/webp"},
+	".mp3":  {"Sound", "audio/mpeg"},
+	".m4a":  {"Sound", "audio/mp4"},
+	".wav":  {"Sound", "audio/wav"},
+	".mp4":  {"Video", "video/mp4"},
+	".mov":  {"Video", "video/quicktime"},
+	".webm": {"Video", "video/webm"},
+	".pdf":  {"Text", "application/pdf"},
+}
+
+// mediaType returns the type and format of the resource at href, a web page unless the
+// extension says otherwise
+func mediaType(href string) (string, string) {
+	p := href
+	if u, err := url.Parse(href); err == nil {
+		p = u.Path
+	}
+	if t, ok := mediaTypes[strings.ToLower(path.Ext(p))]; ok == true {
+		return t[0], t[1]
+	}
+	return "Text", "text/html"
+}
+
+func (x *Exporter) languageMap(values ...string) LanguageMap {
+	lang := x.Language
+	if lang == "" {
+		lang = "none"
+	}
+	kept := []string{}
+	for _, value := range values {
+		if s := strings.TrimSpace(value); s != "" {
+			kept = append(kept, s)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return LanguageMap{lang: kept}
+}
+
+// addMetadata appends a metadata entry if any of the values is not empty
+func (x *Exporter) addMetadata(metadata []*MetadataEntry, label string, values ...string) []*MetadataEntry {
+	if value := x.languageMap(values...); value != nil {
+		metadata = append(metadata, &MetadataEntry{Label: x.languageMap(label), Value: value})
+	}
+	return metadata
+}
+
+// dates returns the unit dates of a DID as written
+func dates(did *ead3.DID) []string {
+	values := []string{}
+	if did == nil {
+		return values
+	}
+	for _, unitDate := range did.UnitDate {
+		values = append(values, ead3.StripMarkup(unitDate.Value))
+	}
+	for _, structured := range did.UnitDateStructured {
+		for _, single := range structured.Singles() {
+			values = append(values, strings.TrimSpace(single.Value))
+		}
+		for _, dateRange := range structured.Ranges() {
+			values = append(values, dateRange.String())
+		}
+	}
+	return values
+}
+
+// describe returns the metadata of a DID: its dates, unitid and containers
+func (x *Exporter) describe(did *ead3.DID) []*MetadataEntry {
+	metadata := x.addMetadata([]*MetadataEntry{}, "Date", dates(did)...)
+	if did == nil {
+		return metadata
+	}
+	if did.UnitID != nil {
+		metadata = x.addMetadata(metadata, "Identifier", ead3.StripMarkup(did.UnitID.Value))
+	}
+	containers := []string{}
+	for _, container := range did.Container {
+		label := strings.TrimSpace(container.LocalType + " " + strings.TrimSpace(container.Value))
+		containers = append(containers, label)
+	}
+	return x.addMetadata(metadata, "Container", strings.Join(containers, ", "))
+}
+
+// access returns the metadata entry for the effective access of c
+func (x *Exporter) access(c *ead3.Component) *MetadataEntry {
+	asOf := x.AsOf
+	if asOf.IsZero() == true {
+		asOf = time.Now()
+	}
+	access := ead3.EffectiveAccess(x.record, c, asOf)
+	values := []string{access.Status}
+	if access.Restriction != nil && access.Restriction.StatusOn(asOf) != ead3.AccessOpen {
+		values = append(values, access.Restriction.Text)
+	}
+	return &MetadataEntry{Label: x.languageMap("Access"), Value: x.languageMap(values...)}
+}
+
+// useRestrict returns the text of the nearest conditions governing use of c
+func useRestrict(record *ead3.EAD3, c *ead3.Component) string {
+	notes := []*ead3.UseRestrict{}
+	for p := c; p != nil && len(notes) == 0; p = p.Parent {
+		notes = p.UseRestrict()
+	}
+	if len(notes) == 0 && record.ArchDesc != nil {
+		notes = record.ArchDesc.UseRestrict
+	}
+	text := []string{}
+	for _, note := range notes {
+		for _, p := range note.P {
+			text = append(text, ead3.StripMarkup(p.Value))
+		}
+	}
+	return strings.Join(text, " ")
+}
+
+// componentKey returns the component's id or its position in the Dsc (e.g. "c2_1_4")
+func componentKey(c *ead3.Component, position []int) string {
+	if id := strings.TrimSpace(c.ID()); id != "" {
+		return id
+	}
+	parts := []string{}
+	for _, i := range position {
+		parts = append(parts, fmt.Sprintf("%d", i))
+	}
+	return "c" + strings.Join(parts, "_")
+}
+
+// manifest returns the manifest of the digital archival objects of c
+func (x *Exporter) manifest(c *ead3.Component, key string) *Manifest {
+	id := x.base + "/" + url.PathEscape(key)
+	title := c.Title()
+	if title == "" {
+		title = key
+	}
+	manifest := &Manifest{
+		Context:  Context,
+		ID:       id + "/manifest.json",
+		Type:     "Manifest",
+		Label:    x.languageMap(title),
+		Metadata: append(x.describe(c.DID()), x.access(c)),
+		Items:    []*Canvas{},
+		key:      key,
+	}
+	if did := c.DID(); did != nil && did.Abstract != nil {
+		manifest.Summary = x.languageMap(ead3.StripMarkup(did.Abstract.Value))
+	}
+	if s := useRestrict(x.record, c); s != "" {
+		manifest.RequiredStatement = &MetadataEntry{Label: x.languageMap("Conditions Governing Use"), Value: x.languageMap(s)}
+	}
+	for i, dao := range c.DAOs() {
+		href := strings.TrimSpace(dao.HRef)
+		if href == "" {
+			continue
+		}
+		canvasID := fmt.Sprintf("%s/canvas/%d", id, i+1)
+		label := dao.Label()
+		if label == "" {
+			label = fmt.Sprintf("%s, %d", title, i+1)
+		}
+		mediaType, format := mediaType(href)
+		canvas := &Canvas{
+			ID:     canvasID,
+			Type:   "Canvas",
+			Label:  x.languageMap(label),
+			Width:  x.Width,
+			Height: x.Height,
+			Items: []*AnnotationPage{{
+				ID:   canvasID + "/page",
+				Type: "AnnotationPage",
+				Items: []*Annotation{{
+					ID:         canvasID + "/annotation",
+					Type:       "Annotation",
+					Motivation: "painting",
+					Body:       &Resource{ID: href, Type: mediaType, Format: format},
+					Target:     canvasID,
+				}},
+			}},
+		}
+		canvas.Metadata = x.addMetadata(canvas.Metadata, "Identifier", dao.Identifier)
+		canvas.Metadata = x.addMetadata(canvas.Metadata, "Type", dao.Type())
+		canvas.Metadata = x.addMetadata(canvas.Metadata, "Coverage", dao.Coverage)
+		manifest.Items = append(manifest.Items, canvas)
+	}
+	return manifest
+}
+
+// items returns the references to the manifests and the collections for components
+// and their descendants holding digital archival objects
+func (x *Exporter) items(components []*ead3.Component, position []int) []interface{} {
+	items := []interface{}{}
+	for i, c := range components {
+		pos := append(append([]int{}, position...), i+1)
+		key := componentKey(c, pos)
+		var manifest *Manifest
+		if len(c.DAOs()) > 0 {
+			manifest = x.manifest(c, key)
+			if len(manifest.Items) == 0 {
+				manifest = nil
+			} else {
+				x.manifests = append(x.manifests, manifest)
+			}
+		}
+		children := x.items(c.Children, pos)
+		switch {
+		case len(children) == 0 && manifest == nil:
+		case len(children) == 0:
+			items = append(items, &Resource{ID: manifest.ID, Type: "Manifest", Label: manifest.Label})
+		default:
+			collection := &Collection{
+				ID:       x.base + "/" + url.PathEscape(key) + "/collection.json",
+				Type:     "Collection",
+				Label:    x.languageMap(c.Title()),
+				Metadata: x.describe(c.DID()),
+				Items:    []interface{}{},
+				key:      key,
+			}
+			if collection.Label == nil {
+				collection.Label = x.languageMap(key)
+			}
+			if manifest != nil {
+				collection.Items = append(collection.Items, &Resource{ID: manifest.ID, Type: "Manifest", Label: manifest.Label})
+			}
+			collection.Items = append(collection.Items, children...)
+			items = append(items, collection)
+		}
+	}
+	return items
+}
+
+// recordID returns the record's recordid
+func recordID(record *ead3.EAD3) string {
+	if record.Control != nil && record.Control.RecordID != nil {
+		return strings.TrimSpace(record.Control.RecordID.Value)
+	}
+	return ""
+}
+
+// Export returns the collection for the finding aid and the manifests of its
+// components in document order. The collection's id is BaseURL/recordid/collection.json,
+// and the manifests and the collections of components are published under
+// BaseURL/recordid/key where key is the component's id or position (e.g. "c2_1_4").
+func (x *Exporter) Export(record *ead3.EAD3) (*Collection, []*Manifest, error) {
+	if strings.TrimSpace(x.BaseURL) == "" {
+		return nil, nil, fmt.Errorf("missing base url")
+	}
+	id := recordID(record)
+	if id == "" {
+		return nil, nil, fmt.Errorf("record has no recordid")
+	}
+	if record.ArchDesc == nil {
+		return nil, nil, fmt.Errorf("%s has no archdesc", id)
+	}
+	x.record = record
+	x.base = strings.TrimRight(x.BaseURL, "/") + "/" + url.PathEscape(id)
+	x.manifests = []*Manifest{}
+
+	collection := &Collection{
+		Context:  Context,
+		ID:       x.base + "/collection.json",
+		Type:     "Collection",
+		Metadata: []*MetadataEntry{},
+	}
+	for _, did := range record.ArchDesc.DID {
+		if collection.Label == nil && did.UnitTitle != nil {
+			collection.Label = x.languageMap(ead3.StripMarkup(did.UnitTitle.Value))
+		}
+		if collection.Summary == nil && did.Abstract != nil {
+			collection.Summary = x.languageMap(ead3.StripMarkup(did.Abstract.Value))
+		}
+		collection.Metadata = append(collection.Metadata, x.describe(did)...)
+	}
+	if collection.Label == nil {
+		collection.Label = x.languageMap(id)
+	}
+	collection.Metadata = append(collection.Metadata, x.access(nil))
+	collection.Items = x.items(record.ArchDesc.Dsc.Components(), []int{})
+	return collection, x.manifests, nil
+}
+
+// Write writes v (e.g. a *Manifest) to w as indented JSON
+func Write(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+	return enc.Encode(v)
+}
+
+func writeFile(fname string, v interface{}) error {
+	if err := os.MkdirAll(filepath.Dir(fname), 0775); err != nil {
+		return err
+	}
+	f, err := os.Create(fname)
+	if err != nil {
+		return err
+	}
+	if err := Write(f, v); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteDir exports record and writes the collection and manifests under dir laid out
+// as their ids under BaseURL, so dir can be published at BaseURL
+func (x *Exporter) WriteDir(dir string, record *ead3.EAD3) error {
+	collection, manifests, err := x.Export(record)
+	if err != nil {
+		return err
+	}
+	root := filepath.Join(dir, url.PathEscape(recordID(record)))
+	var writeCollection func(collection *Collection) error
+	writeCollection = func(collection *Collection) error {
+		if err := writeFile(filepath.Join(root, url.PathEscape(collection.key), "collection.json"), collection); err != nil {
+			return err
+		}
+		for _, item := range collection.Items {
+			if sub, ok := item.(*Collection); ok == true {
+				// a collection published on its own carries the context
+				c := *sub
+				c.Context = Context
+				if err := writeCollection(&c); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := writeCollection(collection); err != nil {
+		return err
+	}
+	for _, manifest := range manifests {
+		if err := writeFile(filepath.Join(root, url.PathEscape(manifest.key), "manifest.json"), manifest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadManifest reads a manifest written by WriteDir
+func ReadManifest(fname string) (*Manifest, error) {
+	src, err := ioutil.ReadFile(fname)
+	if err != nil {
+		return nil, err
+	}
+	manifest := new(Manifest)
+	if err := json.Unmarshal(src, manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
@@ -0,0 +1,189 @@
+//
+// iiif_test.go tests exporting IIIF manifests and collections.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package iiif
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caltechlibrary/ead3"
+)
+
+func readRecord(t *testing.T, fname string) *ead3.EAD3 {
+	src, err := ioutil.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	record := ead3.New()
+	if err := xml.Unmarshal(src, &record); err != nil {
+		t.Fatalf("%s, %s", fname, err)
+	}
+	return record
+}
+
+func TestExport(t *testing.T) {
+	record := readRecord(t, "../testsamples/ead3/S.0001_valid.xml")
+	x := NewExporter("https://iiif.example.edu/ead/")
+	x.AsOf = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	collection, manifests, err := x.Export(record)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if collection.ID != "https://iiif.example.edu/ead/S.0001/collection.json" || collection.Context != Context {
+		t.Errorf("unexpected collection id %q, context %q", collection.ID, collection.Context)
+	}
+	if len(collection.Label["en"]) != 1 {
+		t.Errorf("expected the finding aid title as label, got %+v", collection.Label)
+	}
+	if len(manifests) != 1 {
+		t.Fatalf("expected a manifest for the component with daos, got %d", len(manifests))
+	}
+	manifest := manifests[0]
+	if manifest.Label["en"][0] != "Class Notes for Care of Magical Creatures" {
+		t.Errorf("expected the component title as label, got %+v", manifest.Label)
+	}
+	if len(manifest.Items) != 2 {
+		t.Fatalf("expected a canvas per dao, got %d", len(manifest.Items))
+	}
+	canvas := manifest.Items[0]
+	if canvas.Label["en"][0] != "Sample portion of a scroll on the care of salamanders" {
+		t.Errorf("expected the dao's descriptive note as canvas label, got %+v", canvas.Label)
+	}
+	body := canvas.Items[0].Items[0].Body
+	if body.ID != "http://eadiva.com/wizlib-files/salamanders.jpg" || body.Type != "Image" || body.Format != "image/jpeg" {
+		t.Errorf("unexpected canvas body %+v", body)
+	}
+	if canvas.Items[0].Items[0].Target != canvas.ID || strings.HasPrefix(canvas.ID, strings.TrimSuffix(manifest.ID, "manifest.json")) == false {
+		t.Errorf("expected the annotation to target canvas %q, got %q", canvas.ID, canvas.Items[0].Items[0].Target)
+	}
+	labels := map[string]bool{}
+	for _, entry := range manifest.Metadata {
+		labels[entry.Label["en"][0]] = true
+	}
+	for _, label := range []string{"Container", "Access"} {
+		if labels[label] == false {
+			t.Errorf("expected %s metadata, got %+v", label, labels)
+		}
+	}
+
+	// The manifest is referenced from the collection of its series
+	if len(collection.Items) != 1 {
+		t.Fatalf("expected the series holding the manifest, got %d items", len(collection.Items))
+	}
+	series, ok := collection.Items[0].(*Collection)
+	if ok == false {
+		t.Fatalf("expected a collection for the series, got %T", collection.Items[0])
+	}
+	if len(series.Items) != 1 {
+		t.Fatalf("expected the manifest reference in the series, got %+v", series.Items)
+	}
+	if ref, ok := series.Items[0].(*Resource); ok == false || ref.ID != manifest.ID || ref.Type != "Manifest" {
+		t.Errorf("expected a reference to %q, got %+v", manifest.ID, series.Items[0])
+	}
+
+	buf := new(bytes.Buffer)
+	if err := Write(buf, manifest); err != nil {
+		t.Fatalf("%s", err)
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if m["@context"] != Context || m["type"] != "Manifest" {
+		t.Errorf("unexpected manifest JSON %s", buf.String())
+	}
+
+	if _, _, err := NewExporter("").Export(record); err == nil {
+		t.Errorf("expected an error without a base url")
+	}
+}
+
+func TestWriteDir(t *testing.T) {
+	record := readRecord(t, "../testsamples/ead3/S.0001_valid.xml")
+	dir, err := ioutil.TempDir("", "iiif")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// a series id that needs escaping in a URL
+	record.Walk(func(c *ead3.Component) error {
+		if len(c.DAOs()) > 0 && c.Parent != nil {
+			c.Parent.SetID("series one/a")
+		}
+		return nil
+	})
+	x := NewExporter("https://iiif.example.edu/ead")
+	if err := x.WriteDir(dir, record); err != nil {
+		t.Fatalf("%s", err)
+	}
+	collection, manifests, err := x.Export(record)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	ids := []string{collection.ID, manifests[0].ID}
+	for _, item := range collection.Items {
+		if sub, ok := item.(*Collection); ok == true {
+			ids = append(ids, sub.ID)
+		}
+	}
+	for _, id := range ids {
+		fname := filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(id, x.BaseURL+"/")))
+		if _, err := os.Stat(fname); err != nil {
+			t.Errorf("expected %s to be written at the path of its id, %s", id, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "S.0001", "collection.json")); err != nil {
+		t.Errorf("expected the finding aid collection, %s", err)
+	}
+	fnames, _ := filepath.Glob(filepath.Join(dir, "S.0001", "*", "manifest.json"))
+	if len(fnames) != 1 {
+		t.Fatalf("expected one manifest, got %+v", fnames)
+	}
+	manifest, err := ReadManifest(fnames[0])
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(manifest.Items) != 2 {
+		t.Errorf("expected two canvases, got %d", len(manifest.Items))
+	}
+}
@@ -0,0 +1,523 @@
+// Package index provides a local full text index over a corpus of EAD3 finding aids.
+//
+// Each finding aid contributes a document for the collection and one for each of its
+// components. Text is indexed by field (title, name, subject, date, note and container)
+// with word positions so phrases can be matched, and the years of the unit dates are
+// kept for date range filtering. The index is saved to and read from a single file.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+//   - Redistributions of source code must retain the above copyright notice, this
+//     list of conditions and the following disclaimer.
+//
+//   - Redistributions in binary form must reproduce the above copyright notice,
+//     this list of conditions and the following disclaimer in the documentation
+//     and/or other materials provided with the distribution.
+//
+//   - Neither the name of epgo nor the names of its
+//     contributors may be used to endorse or promote products derived from
+//     this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package index
+
+import (
+	"encoding/gob"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/caltechlibrary/ead3"
+)
+
+// Field names a part of a finding aid that is indexed separately
+type Field string
+
+const (
+	Title     Field = "title"
+	Name      Field = "name"
+	Subject   Field = "subject"
+	Date      Field = "date"
+	Note      Field = "note"
+	Container Field = "container"
+)
+
+// Fields lists the indexed fields
+var Fields = []Field{Title, Name, Subject, Date, Note, Container}
+
+// fieldWeights boosts matches in the more specific fields when scoring hits
+var fieldWeights = map[Field]float64{
+	Title:     3,
+	Name:      2,
+	Subject:   2,
+	Date:      1,
+	Note:      1,
+	Container: 1,
+}
+
+// positionGap separates the values of a field so a phrase can't match across two values
+const positionGap = 100
+
+// Version is the version of the on disk format
+const Version = 1
+
+// Document is the unit returned by a search, a collection or one of its components
+type Document struct {
+	RecordID string
+	// ComponentID is empty for the collection level document
+	ComponentID string
+	Level       string
+	Title       string
+	// Path holds the titles of the collection and the ancestors of the component
+	Path    []string
+	Years   []ead3.YearRange
+	Deleted bool
+}
+
+// Posting records the positions of a token in a field of a document
+type Posting struct {
+	Doc       int
+	Field     Field
+	Positions []int
+}
+
+// Index is an inverted index of tokens to the documents and fields they occur in
+type Index struct {
+	Version   int
+	Documents []*Document
+	Postings  map[string][]*Posting
+}
+
+// New returns an empty index
+func New() *Index {
+	return &Index{
+		Version:  Version,
+		Postings: map[string][]*Posting{},
+	}
+}
+
+// Open reads an index saved with Save
+func Open(fname string) (*Index, error) {
+	fp, err := os.Open(fname)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+	idx := New()
+	if err := gob.NewDecoder(fp).Decode(idx); err != nil {
+		return nil, fmt.Errorf("%s, %s", fname, err)
+	}
+	if idx.Version != Version {
+		return nil, fmt.Errorf("%s, unsupported index version %d", fname, idx.Version)
+	}
+	return idx, nil
+}
+
+// Save writes the index to fname, deleted documents are dropped
+func (idx *Index) Save(fname string) error {
+	idx.compact()
+	fp, err := os.Create(fname)
+	if err != nil {
+		return err
+	}
+	if err := gob.NewEncoder(fp).Encode(idx); err != nil {
+		fp.Close()
+		return err
+	}
+	return fp.Close()
+}
+
+// Tokenize splits text into lower case words
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return unicode.IsLetter(r) == false && unicode.IsNumber(r) == false
+	})
+}
+
+// fieldText collects the values of the fields of a document before they are tokenized
+type fieldText map[Field][]string
+
+func (ft fieldText) add(field Field, values ...string) {
+	for _, value := range values {
+		if value = ead3.StripMarkup(value); value != "" {
+			ft[field] = append(ft[field], value)
+		}
+	}
+}
+
+func partsValue(parts []*ead3.Part) string {
+	values := []string{}
+	for _, part := range parts {
+		values = append(values, part.Value)
+	}
+	return strings.Join(values, " ")
+}
+
+func (ft fieldText) addControlAccess(controlAccess *ead3.ControlAccess) {
+	if controlAccess == nil {
+		return
+	}
+	for _, name := range controlAccess.Persname {
+		ft.add(Name, partsValue(name.Part))
+	}
+	for _, name := range controlAccess.Famname {
+		ft.add(Name, partsValue(name.Part))
+	}
+	for _, name := range controlAccess.CorpName {
+		ft.add(Name, partsValue(name.Part))
+	}
+	for _, term := range controlAccess.Subject {
+		ft.add(Subject, partsValue(term.Part))
+	}
+	for _, term := range controlAccess.GenreForm {
+		ft.add(Subject, partsValue(term.Part))
+	}
+	for _, term := range controlAccess.GeogName {
+		ft.add(Subject, partsValue(term.Part))
+	}
+	for _, term := range controlAccess.Occupation {
+		ft.add(Subject, partsValue(term.Part))
+	}
+	for _, term := range controlAccess.Function {
+		ft.add(Subject, partsValue(term.Part))
+	}
+	for _, name := range controlAccess.Name {
+		ft.add(Name, partsValue(name.Part))
+	}
+	for _, child := range controlAccess.ControlAccess {
+		ft.addControlAccess(child)
+	}
+}
+
+func (ft fieldText) addDID(did *ead3.DID) {
+	if did == nil {
+		return
+	}
+	if did.UnitTitle != nil {
+		ft.add(Title, did.UnitTitle.Value)
+	}
+	if did.Origination != nil {
+		for _, name := range did.Origination.Persname {
+			ft.add(Name, partsValue(name.Part))
+		}
+		for _, name := range did.Origination.Famname {
+			ft.add(Name, partsValue(name.Part))
+		}
+		for _, name := range did.Origination.CorpName {
+			ft.add(Name, partsValue(name.Part))
+		}
+		for _, name := range did.Origination.Name {
+			ft.add(Name, partsValue(name.Part))
+		}
+	}
+	for _, unitDate := range did.UnitDate {
+		ft.add(Date, unitDate.Value)
+	}
+	for _, years := range did.Years() {
+		ft.add(Date, fmt.Sprintf("%d", years.From), fmt.Sprintf("%d", years.To))
+	}
+	if did.Abstract != nil {
+		ft.add(Note, did.Abstract.Value)
+	}
+	if did.DIDNote != nil {
+		ft.add(Note, did.DIDNote.Value)
+	}
+	if did.PhysDesc != nil {
+		ft.add(Note, did.PhysDesc.Value)
+	}
+	for _, container := range did.Container {
+		ft.add(Container, container.LocalType+" "+container.Value)
+	}
+}
+
+func (ft fieldText) addNotes(notes []*ead3.Note) {
+	for _, note := range notes {
+		if note.Head != "" {
+			ft.add(Note, note.Head)
+		}
+		for _, p := range note.P {
+			ft.add(Note, p.Value)
+		}
+		for _, list := range note.List {
+			ft.add(Note, list.Value)
+		}
+		for _, chronList := range note.ChronList {
+			for _, item := range chronList.ChronItem {
+				ft.add(Date, item.Date())
+				for _, event := range item.Events() {
+					ft.add(Note, event.Value)
+				}
+			}
+		}
+		for _, blockQuote := range note.BlockQuote {
+			ft.add(Note, blockQuote.Value)
+		}
+	}
+}
+
+// addDocument adds a document and the postings for its fields
+func (idx *Index) addDocument(doc *Document, ft fieldText) {
+	n := len(idx.Documents)
+	idx.Documents = append(idx.Documents, doc)
+	for _, field := range Fields {
+		positions := map[string][]int{}
+		pos := 0
+		for _, value := range ft[field] {
+			for _, token := range Tokenize(value) {
+				positions[token] = append(positions[token], pos)
+				pos++
+			}
+			pos += positionGap
+		}
+		for token, p := range positions {
+			idx.Postings[token] = append(idx.Postings[token], &Posting{Doc: n, Field: field, Positions: p})
+		}
+	}
+}
+
+// RecordID returns the identifier used for record in the index
+func RecordID(record *ead3.EAD3) string {
+	if record.Control != nil && record.Control.RecordID != nil {
+		return strings.TrimSpace(record.Control.RecordID.Value)
+	}
+	return ""
+}
+
+// Add indexes a finding aid, a document is added for the collection and each of its
+// components. A record already in the index is replaced.
+func (idx *Index) Add(record *ead3.EAD3) error {
+	recordID := RecordID(record)
+	if recordID == "" {
+		return fmt.Errorf("record has no recordid")
+	}
+	if record.ArchDesc == nil {
+		return fmt.Errorf("%s has no archdesc", recordID)
+	}
+	idx.Remove(recordID)
+
+	collection := &Document{RecordID: recordID, Level: record.ArchDesc.Level}
+	ft := fieldText{}
+	for _, did := range record.ArchDesc.DID {
+		ft.addDID(did)
+		collection.Years = append(collection.Years, did.Years()...)
+		if collection.Title == "" && did.UnitTitle != nil {
+			collection.Title = ead3.StripMarkup(did.UnitTitle.Value)
+		}
+	}
+	for _, controlAccess := range record.ArchDesc.ControlAccess {
+		ft.addControlAccess(controlAccess)
+	}
+	ft.addNotes(record.ArchDesc.Notes())
+	idx.addDocument(collection, ft)
+
+	return record.Walk(func(c *ead3.Component) error {
+		doc := &Document{
+			RecordID:    recordID,
+			ComponentID: c.ID(),
+			Level:       c.Level(),
+			Title:       c.Title(),
+			Path:        []string{collection.Title},
+			Years:       c.DID().Years(),
+		}
+		for _, ancestor := range c.Ancestors() {
+			doc.Path = append(doc.Path, ancestor.Title())
+		}
+		ft := fieldText{}
+		ft.addDID(c.DID())
+		for _, controlAccess := range c.ControlAccess() {
+			ft.addControlAccess(controlAccess)
+		}
+		ft.addNotes(c.Notes())
+		// the DID's containers come first and were added with the DID
+		containers := c.Containers()
+		if did := c.DID(); did != nil {
+			containers = containers[len(did.Container):]
+		}
+		for _, container := range containers {
+			ft.add(Container, container.LocalType+" "+container.Value)
+		}
+		idx.addDocument(doc, ft)
+		return nil
+	})
+}
+
+// Remove marks the documents of a record as deleted, they are dropped when the index is saved
+func (idx *Index) Remove(recordID string) {
+	for _, doc := range idx.Documents {
+		if doc.RecordID == recordID {
+			doc.Deleted = true
+		}
+	}
+}
+
+// compact drops deleted documents renumbering the postings
+func (idx *Index) compact() {
+	renumber := map[int]int{}
+	documents := []*Document{}
+	for i, doc := range idx.Documents {
+		if doc.Deleted == false {
+			renumber[i] = len(documents)
+			documents = append(documents, doc)
+		}
+	}
+	if len(documents) == len(idx.Documents) {
+		return
+	}
+	for token, postings := range idx.Postings {
+		kept := []*Posting{}
+		for _, posting := range postings {
+			if n, ok := renumber[posting.Doc]; ok == true {
+				posting.Doc = n
+				kept = append(kept, posting)
+			}
+		}
+		if len(kept) == 0 {
+			delete(idx.Postings, token)
+		} else {
+			idx.Postings[token] = kept
+		}
+	}
+	idx.Documents = documents
+}
+
+// Hit is a document matching a query
+type Hit struct {
+	*Document
+	Score float64
+	// Fields lists the fields the query matched in
+	Fields []Field
+}
+
+// match returns the positions where the phrase terms occur in order for each document and field
+func (idx *Index) match(clause *Clause) map[int]map[Field]int {
+	matches := map[int]map[Field]int{}
+	if len(clause.Terms) == 0 {
+		return matches
+	}
+	// positions[doc][field] holds the start positions still matching the phrase
+	positions := map[int]map[Field]map[int]bool{}
+	for i, term := range clause.Terms {
+		next := map[int]map[Field]map[int]bool{}
+		for _, posting := range idx.Postings[term] {
+			if clause.Field != "" && posting.Field != clause.Field {
+				continue
+			}
+			if idx.Documents[posting.Doc].Deleted == true {
+				continue
+			}
+			for _, pos := range posting.Positions {
+				start := pos - i
+				if i > 0 && positions[posting.Doc][posting.Field][start] == false {
+					continue
+				}
+				if next[posting.Doc] == nil {
+					next[posting.Doc] = map[Field]map[int]bool{}
+				}
+				if next[posting.Doc][posting.Field] == nil {
+					next[posting.Doc][posting.Field] = map[int]bool{}
+				}
+				next[posting.Doc][posting.Field][start] = true
+			}
+		}
+		positions = next
+	}
+	for doc, fields := range positions {
+		matches[doc] = map[Field]int{}
+		for field, starts := range fields {
+			matches[doc][field] = len(starts)
+		}
+	}
+	return matches
+}
+
+// inDateRange reports if a document has any years within the query's date range
+func inDateRange(doc *Document, q *Query) bool {
+	if q.From == 0 && q.To == 0 {
+		return true
+	}
+	from, to := q.From, q.To
+	if to == 0 {
+		to = 9999
+	}
+	for _, years := range doc.Years {
+		if years.Overlaps(from, to) == true {
+			return true
+		}
+	}
+	return false
+}
+
+// Search returns the documents matching every clause of the query ordered by score
+func (idx *Index) Search(q *Query) []*Hit {
+	scores := map[int]float64{}
+	fields := map[int]map[Field]bool{}
+	for i, clause := range q.Clauses {
+		matches := idx.match(clause)
+		for doc := range scores {
+			if _, ok := matches[doc]; ok == false {
+				delete(scores, doc)
+			}
+		}
+		for doc, counts := range matches {
+			if _, ok := scores[doc]; ok == false && i > 0 {
+				continue
+			}
+			if fields[doc] == nil {
+				fields[doc] = map[Field]bool{}
+			}
+			for field, count := range counts {
+				scores[doc] += fieldWeights[field] * float64(count)
+				fields[doc][field] = true
+			}
+		}
+	}
+	if len(q.Clauses) == 0 {
+		// NOTE: a query with only a date range matches every document in the range
+		for i, doc := range idx.Documents {
+			if doc.Deleted == false {
+				scores[i] = 1
+			}
+		}
+	}
+	hits := []*Hit{}
+	for i, score := range scores {
+		doc := idx.Documents[i]
+		if inDateRange(doc, q) == false {
+			continue
+		}
+		hit := &Hit{Document: doc, Score: score}
+		for _, field := range Fields {
+			if fields[i][field] == true {
+				hit.Fields = append(hit.Fields, field)
+			}
+		}
+		hits = append(hits, hit)
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if hits[i].RecordID != hits[j].RecordID {
+			return hits[i].RecordID < hits[j].RecordID
+		}
+		return hits[i].ComponentID < hits[j].ComponentID
+	})
+	return hits
+}
@@ -0,0 +1,184 @@
+//
+// index_test.go tests indexing and searching finding aids.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package index
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/caltechlibrary/ead3"
+)
+
+func readRecord(t *testing.T, fname string) *ead3.EAD3 {
+	src, err := ioutil.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	record := ead3.New()
+	if err := xml.Unmarshal(src, &record); err != nil {
+		t.Fatalf("%s, %s", fname, err)
+	}
+	return record
+}
+
+func testIndex(t *testing.T) *Index {
+	idx := New()
+	for _, fname := range []string{
+		"../testsamples/ead3/S.0001_valid.xml",
+		"../testsamples/ead3/NCSU/mc00019.xml",
+		"../testsamples/ead3/UMN/mss060.xml",
+	} {
+		if err := idx.Add(readRecord(t, fname)); err != nil {
+			t.Fatalf("%s, %s", fname, err)
+		}
+	}
+	return idx
+}
+
+func TestParseQuery(t *testing.T) {
+	q, err := ParseQuery(`title:"class notes" legilimency date:950-1050`)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(q.Clauses) != 2 || q.Clauses[0].Field != Title || len(q.Clauses[0].Terms) != 2 || q.Clauses[1].Field != "" {
+		t.Errorf("unexpected clauses %+v", q.Clauses)
+	}
+	if q.From != 950 || q.To != 1050 {
+		t.Errorf("expected 950 to 1050, got %d to %d", q.From, q.To)
+	}
+	if q, _ := ParseQuery("date:1945"); q.From != 1945 || q.To != 1945 {
+		t.Errorf("expected the single year 1945, got %d to %d", q.From, q.To)
+	}
+	for _, s := range []string{`title:"unterminated`, `color:red`, `date:1950-1900`} {
+		if _, err := ParseQuery(s); err == nil {
+			t.Errorf("expected an error for %q", s)
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	idx := testIndex(t)
+
+	hits, err := idx.SearchString(`title:"class notes for charms"`)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(hits) != 1 || hits[0].RecordID != "S.0001" || hits[0].Level != "file" {
+		t.Fatalf("expected one component hit in S.0001, got %+v", hits)
+	}
+	if len(hits[0].Path) != 2 || hits[0].Path[1] != "Class Notes" {
+		t.Errorf("expected the ancestor path to end with Class Notes, got %q", hits[0].Path)
+	}
+
+	// The words must be adjacent for a phrase
+	hits, _ = idx.SearchString(`title:"notes charms"`)
+	if len(hits) != 0 {
+		t.Errorf("expected no hits for a phrase out of order, got %d", len(hits))
+	}
+	hits, _ = idx.SearchString(`title:notes title:charms`)
+	if len(hits) != 1 {
+		t.Errorf("expected one hit for both words, got %d", len(hits))
+	}
+
+	hits, _ = idx.SearchString(`name:slytherin`)
+	if len(hits) == 0 || hits[0].ComponentID != "" {
+		t.Errorf("expected the collection to match the name slytherin")
+	}
+
+	// Date range filtering
+	all := idx.Search(new(Query))
+	filtered, _ := idx.SearchString(`date:1900-2020`)
+	if len(filtered) == 0 || len(filtered) >= len(all) {
+		t.Errorf("expected the date range to filter %d hits, got %d", len(all), len(filtered))
+	}
+	for _, hit := range filtered {
+		if hit.RecordID == "S.0001" {
+			t.Errorf("expected no S.0001 hits from the twentieth century, got %q", hit.Title)
+		}
+	}
+}
+
+func TestSaveOpen(t *testing.T) {
+	idx := testIndex(t)
+	dname, err := ioutil.TempDir("", "index")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer os.RemoveAll(dname)
+	fname := path.Join(dname, "findingaids.idx")
+
+	// Replacing a record must not duplicate its documents
+	if err := idx.Add(readRecord(t, "../testsamples/ead3/UMN/mss060.xml")); err != nil {
+		t.Fatalf("%s", err)
+	}
+	expected, _ := idx.SearchString("minnesota")
+	if err := idx.Save(fname); err != nil {
+		t.Fatalf("%s", err)
+	}
+	idx2, err := Open(fname)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	hits, _ := idx2.SearchString("minnesota")
+	if len(hits) == 0 || len(hits) != len(expected) {
+		t.Errorf("expected %d hits after reopening, got %d", len(expected), len(hits))
+	}
+	idx2.Remove("mss060")
+	hits, _ = idx2.SearchString("minnesota")
+	for _, hit := range hits {
+		if hit.RecordID == "mss060" {
+			t.Errorf("expected mss060 to be removed")
+		}
+	}
+}
+
+func TestContainersIndexedOnce(t *testing.T) {
+	idx := New()
+	if err := idx.Add(readRecord(t, "../testsamples/ead3/S.0001_valid.xml")); err != nil {
+		t.Fatalf("%s", err)
+	}
+	postings := idx.Postings["scrollboxes"]
+	if len(postings) == 0 {
+		t.Fatalf("expected postings for the scrollboxes containers")
+	}
+	for _, posting := range postings {
+		if posting.Field == Container && len(posting.Positions) != 1 {
+			t.Errorf("expected %s to be indexed once in %q, got %d positions", "scrollboxes", idx.Documents[posting.Doc].Title, len(posting.Positions))
+		}
+	}
+}
@@ -0,0 +1,122 @@
+//
+// query.go parses search queries.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package index
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Clause matches a word or phrase, optionally limited to one field
+type Clause struct {
+	// Field is empty to match in any field
+	Field Field
+	// Terms holds the tokens of the phrase, a single term for a word
+	Terms []string
+}
+
+// Query holds the clauses a document must match and an optional range of years
+type Query struct {
+	Clauses []*Clause
+	// From and To limit the hits to documents with unit dates in the range, zero when not set
+	From int
+	To   int
+}
+
+var yearRange = regexp.MustCompile(`^(\d{1,4})?(?:-|\.\.)?(\d{1,4})?$`)
+
+// ParseQuery parses a query string. Words and "quoted phrases" must all match, either may
+// be prefixed with a field name (e.g. title:"civil war" name:smith). A date range filters
+// the hits by their unit dates, e.g. date:1900-1950, date:1900- or date:1945.
+func ParseQuery(s string) (*Query, error) {
+	q := new(Query)
+	rest := strings.TrimSpace(s)
+	for rest != "" {
+		field := Field("")
+		if i := strings.Index(rest, ":"); i > 0 && strings.ContainsAny(rest[:i], " \"") == false {
+			field = Field(strings.ToLower(rest[:i]))
+			known := false
+			for _, f := range Fields {
+				known = known || f == field
+			}
+			if known == false {
+				return nil, fmt.Errorf("unknown field %q", field)
+			}
+			rest = rest[i+1:]
+		}
+		value := ""
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated phrase %s", rest)
+			}
+			value, rest = rest[1:end+1], rest[end+2:]
+		} else {
+			end := strings.IndexAny(rest, " \t")
+			if end < 0 {
+				end = len(rest)
+			}
+			value, rest = rest[:end], rest[end:]
+		}
+		rest = strings.TrimSpace(rest)
+		if field == Date && yearRange.MatchString(value) == true && value != "" {
+			m := yearRange.FindStringSubmatch(value)
+			q.From, _ = strconv.Atoi(m[1])
+			q.To, _ = strconv.Atoi(m[2])
+			if strings.ContainsAny(value, "-.") == false {
+				q.To = q.From
+			}
+			if q.From > q.To && q.To != 0 {
+				return nil, fmt.Errorf("date range %s ends before it starts", value)
+			}
+			continue
+		}
+		if terms := Tokenize(value); len(terms) > 0 {
+			q.Clauses = append(q.Clauses, &Clause{Field: field, Terms: terms})
+		}
+	}
+	return q, nil
+}
+
+// SearchString parses the query string and returns the matching documents
+func (idx *Index) SearchString(s string) ([]*Hit, error) {
+	q, err := ParseQuery(s)
+	if err != nil {
+		return nil, err
+	}
+	return idx.Search(q), nil
+}
@@ -0,0 +1,274 @@
+//
+// indexes.go resolves and generates back-of-book indexes (<index> and <indexentry>).
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"encoding/xml"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Headings returns the headings of the entry's access term or of its name group
+func (entry *IndexEntry) Headings() []*Heading {
+	headings := []*Heading{}
+	add := func(element interface{}) {
+		if heading := NewHeading(element); heading != nil {
+			headings = append(headings, heading)
+		}
+	}
+	if entry.Persname != nil {
+		add(entry.Persname)
+	}
+	if entry.Famname != nil {
+		add(entry.Famname)
+	}
+	if entry.CorpName != nil {
+		add(entry.CorpName)
+	}
+	if entry.Subject != nil {
+		add(entry.Subject)
+	}
+	if entry.GenreForm != nil {
+		add(entry.GenreForm)
+	}
+	if entry.GeogName != nil {
+		add(entry.GeogName)
+	}
+	if entry.Occupation != nil {
+		add(entry.Occupation)
+	}
+	if entry.Function != nil {
+		add(entry.Function)
+	}
+	if entry.Name != nil {
+		add(entry.Name)
+	}
+	if g := entry.NameGrp; g != nil {
+		for _, e := range g.Persname {
+			add(e)
+		}
+		for _, e := range g.Famname {
+			add(e)
+		}
+		for _, e := range g.CorpName {
+			add(e)
+		}
+		for _, e := range g.Subject {
+			add(e)
+		}
+		for _, e := range g.GenreForm {
+			add(e)
+		}
+		for _, e := range g.GeogName {
+			add(e)
+		}
+		for _, e := range g.Occupation {
+			add(e)
+		}
+		for _, e := range g.Function {
+			add(e)
+		}
+		for _, e := range g.Name {
+			add(e)
+		}
+	}
+	return headings
+}
+
+// Targets returns the ids the entry's ptr, ptrgrp and ref point at
+func (entry *IndexEntry) Targets() []string {
+	targets := []string{}
+	add := func(target string) {
+		if target = strings.TrimSpace(target); target != "" {
+			targets = append(targets, target)
+		}
+	}
+	if entry.Ptr != nil {
+		add(entry.Ptr.Target)
+	}
+	if g := entry.PtrGrp; g != nil {
+		for _, ptr := range g.Ptr {
+			add(ptr.Target)
+		}
+		for _, ref := range g.Ref {
+			add(ref.Target)
+		}
+	}
+	if entry.Ref != nil {
+		add(entry.Ref.Target)
+	}
+	return targets
+}
+
+// Entries returns the entries of the index, including nested entries and those of
+// nested indexes, in document order
+func (index *Index) Entries() []*IndexEntry {
+	entries := []*IndexEntry{}
+	var walk func(list []*IndexEntry)
+	walk = func(list []*IndexEntry) {
+		for _, entry := range list {
+			entries = append(entries, entry)
+			walk(entry.IndexEntry)
+		}
+	}
+	walk(index.IndexEntry)
+	for _, nested := range index.Index {
+		entries = append(entries, nested.Entries()...)
+	}
+	return entries
+}
+
+// Indexes returns the indexes of the collection followed by those of its components
+func (ead *EAD3) Indexes() []*Index {
+	indexes := []*Index{}
+	if ead == nil || ead.ArchDesc == nil {
+		return indexes
+	}
+	indexes = append(indexes, ead.ArchDesc.Index...)
+	ead.Walk(func(c *Component) error {
+		if f := c.fields(); f.Index != nil {
+			indexes = append(indexes, *f.Index...)
+		}
+		return nil
+	})
+	return indexes
+}
+
+// componentsByID maps the ids of the record's components to the components
+func (ead *EAD3) componentsByID() map[string]*Component {
+	byID := map[string]*Component{}
+	ead.Walk(func(c *Component) error {
+		if id := c.ID(); id != "" {
+			if _, ok := byID[id]; ok == false {
+				byID[id] = c
+			}
+		}
+		return nil
+	})
+	return byID
+}
+
+// ResolveTargets returns the components the entry points at and the targets that
+// do not match the id of any component
+func (ead *EAD3) ResolveTargets(entry *IndexEntry) ([]*Component, []string) {
+	components, unresolved := []*Component{}, []string{}
+	byID := ead.componentsByID()
+	for _, target := range entry.Targets() {
+		if c, ok := byID[target]; ok == true {
+			components = append(components, c)
+		} else {
+			unresolved = append(unresolved, target)
+		}
+	}
+	return components, unresolved
+}
+
+// setIndexTerm sets the access term of entry to a copy of element without its ids, so
+// the index neither repeats the ids of the heading nor shares its parts
+func setIndexTerm(entry *IndexEntry, element interface{}) {
+	src, err := xml.Marshal(element)
+	if err != nil {
+		return
+	}
+	v := reflect.New(reflect.TypeOf(element).Elem())
+	if err := xml.Unmarshal(src, v.Interface()); err != nil {
+		return
+	}
+	visitIDs(v, nil, func(attr, value, name string, element interface{}, embedded bool) string {
+		if attr == "id" {
+			return ""
+		}
+		return value
+	})
+	if f := reflect.ValueOf(entry).Elem().FieldByName(v.Elem().Type().Name()); f.IsValid() && f.Type() == v.Type() {
+		f.Set(v)
+	}
+}
+
+// GenerateIndex builds an index of the ControlAccess headings found in the components
+// of the Dsc, one entry per distinct heading sorted by term, pointing back at the ids of
+// the components using it. Components without an id cannot be pointed at and are skipped.
+// The result is usually appended to ArchDesc.Index.
+func GenerateIndex(ead *EAD3) *Index {
+	index := &Index{Head: &Head{Value: "Index"}}
+	type indexed struct {
+		heading *Heading
+		targets []string
+		seen    map[string]bool
+	}
+	byKey := map[string]*indexed{}
+	keys := []string{}
+	ead.Walk(func(c *Component) error {
+		id := c.ID()
+		if id == "" {
+			return nil
+		}
+		for _, controlAccess := range c.ControlAccess() {
+			for _, heading := range controlAccess.Headings() {
+				key := heading.key()
+				item, ok := byKey[key]
+				if ok == false {
+					item = &indexed{heading: heading, seen: map[string]bool{}}
+					byKey[key] = item
+					keys = append(keys, key)
+				}
+				if item.seen[id] == false {
+					item.targets = append(item.targets, id)
+					item.seen[id] = true
+				}
+			}
+		}
+		return nil
+	})
+	sort.SliceStable(keys, func(i, j int) bool {
+		return strings.ToLower(byKey[keys[i]].heading.Term()) < strings.ToLower(byKey[keys[j]].heading.Term())
+	})
+	for _, key := range keys {
+		item := byKey[key]
+		entry := new(IndexEntry)
+		setIndexTerm(entry, item.heading.Element)
+		if len(item.targets) == 1 {
+			entry.Ptr = &Ptr{Target: item.targets[0]}
+		} else {
+			entry.PtrGrp = new(PtrGrp)
+			for _, target := range item.targets {
+				entry.PtrGrp.Ptr = append(entry.PtrGrp.Ptr, &Ptr{Target: target})
+			}
+		}
+		index.IndexEntry = append(index.IndexEntry, entry)
+	}
+	return index
+}
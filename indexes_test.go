@@ -0,0 +1,161 @@
+//
+// indexes_test.go tests back-of-book indexes.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIndexes(t *testing.T) {
+	record := readTestRecord(t, "testsamples/ead3/S.0001_valid.xml")
+	indexes := record.Indexes()
+	if len(indexes) != 2 {
+		t.Fatalf("expected 2 indexes, got %d", len(indexes))
+	}
+	if indexes[0].Head == nil || indexes[0].Head.Value != "Index of Correspondents" {
+		t.Errorf("expected Index of Correspondents, got %+v", indexes[0].Head)
+	}
+	entries := indexes[0].Entries()
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+	if headings := entries[1].Headings(); len(headings) != 1 || headings[0].Term() != "Hufflepuff, Helga" {
+		t.Errorf("expected Hufflepuff, Helga, got %+v", headings)
+	}
+	// the pointers target relations rather than components
+	if targets := entries[1].Targets(); len(targets) != 1 || targets[0] != "hhuf" {
+		t.Errorf("expected target hhuf, got %+v", targets)
+	}
+	if components, unresolved := record.ResolveTargets(entries[1]); len(components) != 0 || len(unresolved) != 1 {
+		t.Errorf("expected hhuf to be unresolved, got %d components and %+v", len(components), unresolved)
+	}
+	entries = indexes[1].Entries()
+	if len(entries) != 3 || len(entries[1].Headings()) != 3 || entries[1].Ref == nil {
+		t.Errorf("expected a namegrp of 3 names with a ref, got %+v", entries)
+	}
+
+	numberComponents(record)
+	index := GenerateIndex(record)
+	if len(index.IndexEntry) == 0 {
+		t.Fatalf("expected a generated index")
+	}
+	terms := []string{}
+	for _, entry := range index.Entries() {
+		headings := entry.Headings()
+		if len(headings) != 1 {
+			t.Fatalf("expected one heading per entry, got %+v", headings)
+		}
+		terms = append(terms, strings.ToLower(headings[0].Term()))
+		components, unresolved := record.ResolveTargets(entry)
+		if len(components) == 0 || len(unresolved) != 0 {
+			t.Errorf("expected %q to point at components, got %+v", headings[0].Term(), unresolved)
+		}
+		for _, c := range components {
+			found := false
+			for _, heading := range c.ControlAccess()[0].Headings() {
+				if heading.Term() == headings[0].Term() {
+					found = true
+				}
+			}
+			if found == false {
+				t.Errorf("expected %s to use %q", c.ID(), headings[0].Term())
+			}
+		}
+	}
+	for i := 1; i < len(terms); i++ {
+		if terms[i-1] > terms[i] {
+			t.Errorf("expected entries sorted by term, %q before %q", terms[i-1], terms[i])
+		}
+	}
+	record.ArchDesc.Index = append(record.ArchDesc.Index, index)
+	src, err := xml.Marshal(record)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if strings.Contains(string(src), `<ptr target="c1"></ptr>`) == false {
+		t.Errorf("expected a pointer to c1 in the archdesc index")
+	}
+	if len(record.Indexes()) != 3 {
+		t.Errorf("expected the generated index among the record's indexes")
+	}
+}
+
+func TestGenerateIndexCopies(t *testing.T) {
+	record := readTestRecord(t, "testsamples/ead3/S.0001_valid.xml")
+	numberComponents(record)
+	terms := func() []string {
+		s := []string{}
+		record.Walk(func(c *Component) error {
+			for _, controlAccess := range c.ControlAccess() {
+				for _, heading := range controlAccess.Headings() {
+					s = append(s, heading.Term())
+				}
+			}
+			return nil
+		})
+		return s
+	}
+	// give the headings ids as an editor might
+	i := 0
+	record.Walk(func(c *Component) error {
+		for _, controlAccess := range c.ControlAccess() {
+			for _, heading := range controlAccess.Headings() {
+				i++
+				reflect.ValueOf(heading.Element).Elem().FieldByName("ID").SetString(fmt.Sprintf("h%d", i))
+			}
+		}
+		return nil
+	})
+	before := terms()
+
+	index := GenerateIndex(record)
+	record.ArchDesc.Index = append(record.ArchDesc.Index, index)
+	if duplicates := record.IDs().Duplicates(); len(duplicates) != 0 {
+		t.Errorf("expected no duplicate ids after indexing, got %+v", duplicates)
+	}
+	for _, entry := range index.Entries() {
+		parts := reflect.ValueOf(entry.Headings()[0].Element).Elem().FieldByName("Part")
+		for j := 0; j < parts.Len(); j++ {
+			parts.Index(j).Interface().(*Part).Value = "changed"
+		}
+	}
+	if after := terms(); strings.Join(after, "|") != strings.Join(before, "|") {
+		t.Errorf("expected the component headings to be independent of the index, got %+v", after)
+	}
+}
@@ -0,0 +1,373 @@
+//
+// json.go provides a stable, versioned JSON representation of EAD3 documents.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	// JSONVersion is the current version of the JSON representation produced by ToJSON
+	JSONVersion = "7.0"
+
+	// JSONSchemaID is the identifier used in the JSON Schema describing JSONVersion
+	JSONSchemaID = "https://github.com/caltechlibrary/ead3/ead3.schema.json"
+)
+
+// JSONVersions lists the versions of the JSON representation this package can read,
+// older versions are upgraded to JSONVersion when read
+var JSONVersions = []string{"1.0", "2.0", "3.0", "4.0", "5.0", "6.0", "7.0"}
+
+// JSONDocument is the versioned envelope wrapping an EAD3 record when rendered as JSON.
+// See JSON.md for the description of the representation.
+type JSONDocument struct {
+	Version string `json:"version"`
+	EAD     *EAD3  `json:"ead"`
+}
+
+func supportedJSONVersion(version string) bool {
+	for _, v := range JSONVersions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonUpgrade rewrites the "ead" value of a document in version From
+// into the shape used by version To
+type jsonUpgrade struct {
+	From    string
+	To      string
+	Upgrade func(ead interface{})
+}
+
+// jsonUpgrades are applied in order to bring an older document up to JSONVersion
+var jsonUpgrades = []jsonUpgrade{
+	// 2.0 holds relationentry as an array of objects
+	{From: "1.0", To: "2.0", Upgrade: func(ead interface{}) {
+		walkJSON(ead, func(obj map[string]interface{}) {
+			if s, ok := obj["relationentry"].(string); ok == true {
+				obj["relationentry"] = []interface{}{map[string]interface{}{"value": s}}
+			}
+		})
+	}},
+	// 3.0 holds the language of langmaterial as an array
+	{From: "2.0", To: "3.0", Upgrade: func(ead interface{}) {
+		walkJSON(ead, func(obj map[string]interface{}) {
+			if langMaterial, ok := obj["langmaterial"].(map[string]interface{}); ok == true {
+				if language, ok := langMaterial["language"].(map[string]interface{}); ok == true {
+					langMaterial["language"] = []interface{}{language}
+				}
+			}
+		})
+	}},
+	// 4.0 models index as an array of structured indexes instead of embedded XML
+	{From: "3.0", To: "4.0", Upgrade: func(ead interface{}) {
+		walkJSON(ead, func(obj map[string]interface{}) {
+			old, ok := obj["index"].(map[string]interface{})
+			if ok == false {
+				return
+			}
+			s, _ := old["value"].(string)
+			index := new(Index)
+			if err := xml.Unmarshal([]byte("<index>"+s+"</index>"), index); err != nil {
+				return
+			}
+			var v interface{}
+			if src, err := json.Marshal(index); err == nil && json.Unmarshal(src, &v) == nil {
+				obj["index"] = []interface{}{v}
+			}
+		})
+	}},
+	// 5.0 holds the notes of components as arrays
+	{From: "4.0", To: "5.0", Upgrade: func(ead interface{}) {
+		walkJSON(ead, func(obj map[string]interface{}) {
+			for _, name := range []string{"c", "c01", "c02", "c03", "c04"} {
+				components, _ := obj[name].([]interface{})
+				for _, component := range components {
+					c, ok := component.(map[string]interface{})
+					if ok == false {
+						continue
+					}
+					for _, key := range []string{"scopecontent", "accessrestrict", "userestrict", "phystech", "odd", "controlaccess", "originalsloc", "altformavail"} {
+						if note, ok := c[key].(map[string]interface{}); ok == true {
+							c[key] = []interface{}{note}
+						}
+					}
+				}
+			}
+		})
+	}},
+	// 6.0 holds the notes of archdesc as arrays and every note head as an object
+	{From: "5.0", To: "6.0", Upgrade: func(ead interface{}) {
+		walkJSON(ead, func(obj map[string]interface{}) {
+			archDesc, isArchDesc := obj["archdesc"].(map[string]interface{})
+			for _, key := range noteKeys {
+				if isArchDesc == true {
+					if note, ok := archDesc[key].(map[string]interface{}); ok == true {
+						archDesc[key] = []interface{}{note}
+					}
+				}
+				notes, _ := obj[key].([]interface{})
+				for _, note := range notes {
+					if n, ok := note.(map[string]interface{}); ok == true {
+						if head, ok := n["head"].(string); ok == true {
+							n["head"] = map[string]interface{}{"value": head}
+						}
+					}
+				}
+			}
+		})
+	}},
+	// 7.0 holds the list, chronlist and table of every note, the archref of
+	// relatedmaterial and the relations of archdesc as arrays
+	{From: "6.0", To: "7.0", Upgrade: func(ead interface{}) {
+		walkJSON(ead, func(obj map[string]interface{}) {
+			if archDesc, ok := obj["archdesc"].(map[string]interface{}); ok == true {
+				if relations, ok := archDesc["relations"].(map[string]interface{}); ok == true {
+					archDesc["relations"] = []interface{}{relations}
+				}
+			}
+			for _, key := range noteKeys {
+				notes, _ := obj[key].([]interface{})
+				for _, note := range notes {
+					n, ok := note.(map[string]interface{})
+					if ok == false {
+						continue
+					}
+					for _, block := range []string{"list", "chronlist", "table", "archref"} {
+						if v, ok := n[block].(map[string]interface{}); ok == true {
+							n[block] = []interface{}{v}
+						}
+					}
+				}
+			}
+		})
+	}},
+}
+
+// noteKeys are the property names of the narrative notes
+var noteKeys = []string{
+	"accessrestrict", "accruals", "acqinfo", "altformavail", "appraisal", "arrangement",
+	"bibliography", "bioghist", "custodhist", "fileplan", "legalstatus", "odd",
+	"originalsloc", "otherfindaid", "phystech", "prefercite", "processinfo",
+	"relatedmaterial", "scopecontent", "separatedmaterial", "userestrict",
+}
+
+// walkJSON calls fn for every object in v, parents before children
+func walkJSON(v interface{}, fn func(map[string]interface{})) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		fn(t)
+		for _, child := range t {
+			walkJSON(child, fn)
+		}
+	case []interface{}:
+		for _, child := range t {
+			walkJSON(child, fn)
+		}
+	}
+}
+
+// upgradeJSON converts the "ead" value of a document in version to JSONVersion
+func upgradeJSON(version string, src json.RawMessage) (json.RawMessage, error) {
+	var ead interface{}
+	if err := json.Unmarshal(src, &ead); err != nil {
+		return nil, err
+	}
+	for _, step := range jsonUpgrades {
+		if step.From == version {
+			step.Upgrade(ead)
+			version = step.To
+		}
+	}
+	if version != JSONVersion {
+		return nil, fmt.Errorf("cannot upgrade JSON version %q to %q", version, JSONVersion)
+	}
+	return json.Marshal(ead)
+}
+
+// ToJSON renders the EAD3 record as JSON using the requested version of the
+// representation. An empty version means the current JSONVersion, which is
+// also the only version written.
+func (ead *EAD3) ToJSON(version string) ([]byte, error) {
+	if version == "" {
+		version = JSONVersion
+	}
+	if version != JSONVersion {
+		return nil, fmt.Errorf("unsupported JSON version %q, only %q is written", version, JSONVersion)
+	}
+	return encodeJSON(&JSONDocument{Version: version, EAD: ead})
+}
+
+// encodeJSON renders v as indented JSON leaving embedded markup unescaped
+func encodeJSON(v interface{}) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// FromJSON reads a JSON document produced by ToJSON and returns the EAD3 record,
+// documents in an older version listed in JSONVersions are upgraded as they are read
+func FromJSON(src []byte) (*EAD3, error) {
+	envelope := struct {
+		Version string          `json:"version"`
+		EAD     json.RawMessage `json:"ead"`
+	}{}
+	if err := json.Unmarshal(src, &envelope); err != nil {
+		return nil, err
+	}
+	if envelope.Version == "" {
+		return nil, fmt.Errorf("missing JSON version")
+	}
+	if supportedJSONVersion(envelope.Version) == false {
+		return nil, fmt.Errorf("unsupported JSON version %q", envelope.Version)
+	}
+	if len(envelope.EAD) == 0 || string(envelope.EAD) == "null" {
+		return nil, fmt.Errorf("missing ead")
+	}
+	raw := envelope.EAD
+	if envelope.Version != JSONVersion {
+		var err error
+		if raw, err = upgradeJSON(envelope.Version, raw); err != nil {
+			return nil, err
+		}
+	}
+	doc := &JSONDocument{Version: JSONVersion, EAD: new(EAD3)}
+	if err := json.Unmarshal(raw, doc.EAD); err != nil {
+		return nil, err
+	}
+	if doc.EAD.XMLNameSpace == "" {
+		doc.EAD.XMLNameSpace = New().XMLNameSpace
+	}
+	return doc.EAD, nil
+}
+
+// JSONSchema returns a JSON Schema (draft 2020-12) describing the JSON representation
+// for the requested version. The schema is derived from the struct definitions so it
+// always matches what ToJSON produces.
+func JSONSchema(version string) ([]byte, error) {
+	if version == "" {
+		version = JSONVersion
+	}
+	if version != JSONVersion {
+		return nil, fmt.Errorf("unsupported JSON version %q, only the schema for %q is available", version, JSONVersion)
+	}
+	defs := map[string]interface{}{}
+	schemaDefinition(reflect.TypeOf(EAD3{}), defs)
+	schema := map[string]interface{}{
+		"$schema":     "https://json-schema.org/draft/2020-12/schema",
+		"$id":         JSONSchemaID,
+		"title":       "EAD3 JSON representation",
+		"description": fmt.Sprintf("Version %s of the JSON representation of an EAD version 3 document", version),
+		"type":        "object",
+		"properties": map[string]interface{}{
+			"version": map[string]interface{}{"const": version},
+			"ead":     map[string]interface{}{"$ref": "#/$defs/EAD3"},
+		},
+		"required":             []string{"version", "ead"},
+		"additionalProperties": false,
+		"$defs":                defs,
+	}
+	return encodeJSON(schema)
+}
+
+// schemaDefinition adds a definition for the struct type t (and the types it references) to defs
+func schemaDefinition(t reflect.Type, defs map[string]interface{}) {
+	if _, ok := defs[t.Name()]; ok == true {
+		return
+	}
+	properties := map[string]interface{}{}
+	required := []string{}
+	def := map[string]interface{}{
+		"type":                 "object",
+		"additionalProperties": false,
+	}
+	defs[t.Name()] = def
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || field.PkgPath != "" {
+			continue
+		}
+		name, opts := tag, ""
+		if j := strings.Index(tag, ","); j >= 0 {
+			name, opts = tag[:j], tag[j+1:]
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = schemaType(field.Type, defs)
+		if strings.Contains(opts, "omitempty") == false {
+			required = append(required, name)
+		}
+	}
+	def["properties"] = properties
+	if len(required) > 0 {
+		def["required"] = required
+	}
+}
+
+// schemaType returns the schema fragment describing values of type t
+func schemaType(t reflect.Type, defs map[string]interface{}) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaType(t.Elem(), defs)
+	case reflect.Slice:
+		return map[string]interface{}{
+			"type":  "array",
+			"items": schemaType(t.Elem(), defs),
+		}
+	case reflect.Struct:
+		schemaDefinition(t, defs)
+		return map[string]interface{}{"$ref": "#/$defs/" + t.Name()}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int64, reflect.Int32:
+		return map[string]interface{}{"type": "integer"}
+	}
+	return map[string]interface{}{"type": "string"}
+}
@@ -0,0 +1,233 @@
+//
+// json_test.go tests the JSON representation against golden files.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+var (
+	updateGolden = flag.Bool("update", false, "rewrite the golden files in testsamples/json and ead3.schema.json")
+
+	// jsonGoldenFiles are the NCSU and UMN samples small enough to keep golden JSON copies of,
+	// the remaining samples are only checked for round tripping.
+	jsonGoldenFiles = []string{
+		"testsamples/ead3/NCSU/mc00019.xml",
+		"testsamples/ead3/NCSU/mc00022.xml",
+		"testsamples/ead3/NCSU/mc00185.xml",
+		"testsamples/ead3/NCSU/mc00192.xml",
+		"testsamples/ead3/NCSU/mc00212.xml",
+		"testsamples/ead3/NCSU/mc00462.xml",
+		"testsamples/ead3/NCSU/mc00496.xml",
+		"testsamples/ead3/NCSU/rbc00001.xml",
+		"testsamples/ead3/NCSU/rbc00008.xml",
+		"testsamples/ead3/NCSU/ua012_004.xml",
+		"testsamples/ead3/UMN/CLRC-2155.xml",
+		"testsamples/ead3/UMN/mss060.xml",
+		"testsamples/ead3/UMN/naa213.xml",
+		"testsamples/ead3/UMN/yusa0009x2x16-ead3.xml",
+	}
+)
+
+func hasGolden(fname string) bool {
+	for _, s := range jsonGoldenFiles {
+		if s == fname {
+			return true
+		}
+	}
+	return false
+}
+
+// goldenName maps a sample under testsamples/ead3 to its golden JSON file
+func goldenName(fname string) string {
+	return path.Join("testsamples", "json", strings.TrimSuffix(strings.TrimPrefix(fname, "testsamples/ead3/"), ".xml")+".json")
+}
+
+func checkGolden(t *testing.T, fname string, src []byte) {
+	if *updateGolden == true {
+		if err := os.MkdirAll(path.Dir(fname), 0775); err != nil {
+			t.Fatalf("%s", err)
+		}
+		if err := ioutil.WriteFile(fname, src, 0664); err != nil {
+			t.Fatalf("%s", err)
+		}
+		return
+	}
+	expected, err := ioutil.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("%s (run go test -update to create it)", err)
+	}
+	if bytes.Compare(expected, src) != 0 {
+		t.Errorf("output does not match golden file %s (run go test -update after reviewing changes)", fname)
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	src, err := JSONSchema(JSONVersion)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	checkGolden(t, "ead3.schema.json", src)
+	if _, err := JSONSchema("0.9"); err == nil {
+		t.Errorf("expected an error for an unsupported version")
+	}
+}
+
+func TestJSONGolden(t *testing.T) {
+	for _, fname := range testEAD3Files {
+		if strings.Contains(fname, "/NCSU/") == false && strings.Contains(fname, "/UMN/") == false {
+			continue
+		}
+		input, err := ioutil.ReadFile(fname)
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+		record := New()
+		if err := xml.Unmarshal(input, &record); err != nil {
+			t.Fatalf("%s, %s", fname, err)
+		}
+		src, err := record.ToJSON(JSONVersion)
+		if err != nil {
+			t.Fatalf("%s, %s", fname, err)
+		}
+		if hasGolden(fname) == true {
+			checkGolden(t, goldenName(fname), src)
+		}
+
+		// Round trip back to XML
+		record2, err := FromJSON(src)
+		if err != nil {
+			t.Fatalf("%s, %s", fname, err)
+		}
+		expected, _ := xml.Marshal(record)
+		result, _ := xml.Marshal(record2)
+		if bytes.Compare(expected, result) != 0 {
+			t.Errorf("%s, JSON does not round trip to the same XML", fname)
+		}
+	}
+}
+
+func TestFromJSON(t *testing.T) {
+	if _, err := FromJSON([]byte(`{"ead":{}}`)); err == nil {
+		t.Errorf("expected an error for a missing version")
+	}
+	if _, err := FromJSON([]byte(`{"version":"0.9","ead":{}}`)); err == nil {
+		t.Errorf("expected an error for an unsupported version")
+	}
+	record, err := FromJSON([]byte(`{"version":"1.0","ead":{"control":{"recordid":{"value":"mc00003"}}}}`))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if record.XMLNameSpace != New().XMLNameSpace {
+		t.Errorf("expected default namespace, got %q", record.XMLNameSpace)
+	}
+	if record.Control.RecordID.Value != "mc00003" {
+		t.Errorf("expected recordid mc00003, got %q", record.Control.RecordID.Value)
+	}
+	if _, err := record.ToJSON("0.9"); err == nil {
+		t.Errorf("expected an error for an unsupported version")
+	}
+	if _, err := record.ToJSON("1.0"); err == nil {
+		t.Errorf("expected an error writing an older version")
+	}
+}
+
+func TestUpgradeJSON(t *testing.T) {
+	src := []byte(`{"version":"1.0","ead":{"archdesc":{"level":"collection","relations":{"relation":[{"relationtype":"cpfrelation","relationentry":"Doe, Jane"}]}}}}`)
+	record, err := FromJSON(src)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	relation := record.ArchDesc.Relations[0].Relation[0]
+	if len(relation.RelationEntry) != 1 || relation.RelationEntry[0].Value != "Doe, Jane" {
+		t.Errorf("expected relationentry Doe, Jane, got %+v", relation.RelationEntry)
+	}
+	src = []byte(`{"version":"2.0","ead":{"archdesc":{"level":"collection","did":[{"langmaterial":{"language":{"langcode":"eng","value":"English"}}}]}}}`)
+	if record, err = FromJSON(src); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if languages := record.ArchDesc.DID[0].LangMaterial.Languages(); len(languages) != 1 || languages[0].LangCode != "eng" {
+		t.Errorf("expected language eng, got %+v", languages)
+	}
+	src = []byte(`{"version":"3.0","ead":{"archdesc":{"level":"collection","dsc":{"c":[{"index":{"value":"<head>Names</head><indexentry><name><part>Imp</part></name></indexentry>"}}]}}}}`)
+	if record, err = FromJSON(src); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if index := record.ArchDesc.Dsc.C[0].Index; len(index) != 1 || index[0].Head.Value != "Names" || len(index[0].IndexEntry) != 1 {
+		t.Errorf("expected a structured index, got %+v", index)
+	}
+	src = []byte(`{"version":"4.0","ead":{"archdesc":{"level":"collection","dsc":{"c01":[{"scopecontent":{"p":[{"text":"Letters"}]},"c02":[{"controlaccess":{"subject":[{"part":[{"value":"Owls"}]}]}}]}]}}}}`)
+	if record, err = FromJSON(src); err != nil {
+		t.Fatalf("%s", err)
+	}
+	c01 := record.ArchDesc.Dsc.C01[0]
+	if len(c01.ScopeContent) != 1 || len(c01.ScopeContent[0].P) != 1 || c01.ScopeContent[0].P[0].Value != "Letters" {
+		t.Errorf("expected an array of scopecontent, got %+v", c01.ScopeContent)
+	}
+	if len(c01.C02) != 1 || len(c01.C02[0].ControlAccess) != 1 {
+		t.Errorf("expected an array of controlaccess, got %+v", c01.C02)
+	}
+	src = []byte(`{"version":"5.0","ead":{"archdesc":{"level":"collection","odd":{"head":"Note","p":[{"text":"Owls"}]},"dsc":{"c":[{"odd":[{"head":"Item note"}]}]}}}}`)
+	if record, err = FromJSON(src); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if odd := record.ArchDesc.Odd; len(odd) != 1 || odd[0].Head == nil || odd[0].Head.Value != "Note" {
+		t.Errorf("expected an array of odd with a structured head, got %+v", odd)
+	}
+	if odd := record.ArchDesc.Dsc.C[0].Odd; len(odd) != 1 || odd[0].Head == nil || odd[0].Head.Value != "Item note" {
+		t.Errorf("expected a component odd with a structured head, got %+v", odd)
+	}
+	src = []byte(`{"version":"6.0","ead":{"archdesc":{"level":"collection","bioghist":[{"chronlist":{"chronitem":[{"event":{"value":"Founded"}}]},"bioghist":[{"table":{"frame":"all"}}]}],"arrangement":[{"list":{"value":"<item>Series 1</item>"}}]}}}`)
+	if record, err = FromJSON(src); err != nil {
+		t.Fatalf("%s", err)
+	}
+	biogHist := record.ArchDesc.BiogHist[0]
+	if len(biogHist.ChronList) != 1 || len(biogHist.ChronList[0].ChronItem) != 1 {
+		t.Errorf("expected an array of chronlist, got %+v", biogHist.ChronList)
+	}
+	if len(biogHist.BiogHist) != 1 || len(biogHist.BiogHist[0].Table) != 1 || biogHist.BiogHist[0].Table[0].Frame != "all" {
+		t.Errorf("expected an array of table in the nested bioghist, got %+v", biogHist.BiogHist)
+	}
+	if list := record.ArchDesc.Arrangement[0].List; len(list) != 1 || list[0].Value != "<item>Series 1</item>" {
+		t.Errorf("expected an array of list, got %+v", list)
+	}
+}
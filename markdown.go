@@ -0,0 +1,480 @@
+//
+// markdown.go renders EAD3 records as Markdown or plain text.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"fmt"
+	"strings"
+)
+
+// textRenderer renders a record as Markdown, or plain text when markdown is false
+type textRenderer struct {
+	markdown bool
+	buf      *strings.Builder
+}
+
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "`", "\\`", "<", `\<`)
+
+func (r *textRenderer) escape(s string) string {
+	if r.markdown {
+		return markdownEscaper.Replace(s)
+	}
+	return s
+}
+
+// inline converts mixed content nodes, emphasis and titles become Markdown emphasis
+// and references become links.
+func (r *textRenderer) inline(nodes []*MarkupNode) string {
+	buf := new(strings.Builder)
+	for _, node := range nodes {
+		switch node.Name {
+		case "":
+			// NOTE: line breaks in the source are white space, only <lb/> breaks a line
+			buf.WriteString(r.escape(strings.Map(func(c rune) rune {
+				if c == '\n' || c == '\r' {
+					return ' '
+				}
+				return c
+			}, node.Text)))
+		case "lb":
+			if r.markdown {
+				buf.WriteString("  ")
+			}
+			buf.WriteString("\n")
+		case "emph", "title":
+			s := strings.TrimSpace(r.inline(node.Children))
+			if r.markdown && s != "" {
+				switch node.Attr["render"] {
+				case "bold", "boldunderline", "boldsmcaps":
+					s = "**" + s + "**"
+				case "bolditalic":
+					s = "***" + s + "***"
+				default:
+					s = "*" + s + "*"
+				}
+			}
+			buf.WriteString(s)
+		case "ref", "extref", "ptr", "extptr":
+			href := node.Attr["href"]
+			if href == "" && node.Attr["target"] != "" {
+				href = "#" + node.Attr["target"]
+			}
+			s := strings.TrimSpace(r.inline(node.Children))
+			if s == "" {
+				s = node.Attr["linktitle"]
+			}
+			switch {
+			case href == "":
+				buf.WriteString(s)
+			case r.markdown && s == "":
+				buf.WriteString("<" + href + ">")
+			case r.markdown:
+				buf.WriteString("[" + s + "](" + strings.Replace(href, " ", "%20", -1) + ")")
+			case s == "":
+				buf.WriteString(href)
+			default:
+				buf.WriteString(s + " (" + href + ")")
+			}
+		default:
+			buf.WriteString(r.inline(node.Children))
+		}
+	}
+	return buf.String()
+}
+
+// text converts embedded XML to a single line of Markdown or plain text
+func (r *textRenderer) text(src string) string {
+	nodes, err := ParseMarkup(src)
+	if err != nil {
+		return r.escape(StripMarkup(src))
+	}
+	lines := []string{}
+	for _, line := range strings.Split(r.inline(nodes), "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if r.markdown {
+		return strings.Join(lines, "  \n")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r *textRenderer) heading(level int, s string) {
+	if s == "" {
+		return
+	}
+	if r.markdown {
+		fmt.Fprintf(r.buf, "%s %s\n\n", strings.Repeat("#", level), s)
+		return
+	}
+	underline := "-"
+	if level == 1 {
+		underline = "="
+	}
+	fmt.Fprintf(r.buf, "%s\n%s\n\n", s, strings.Repeat(underline, len([]rune(s))))
+}
+
+func (r *textRenderer) paragraph(s string) {
+	if s != "" {
+		fmt.Fprintf(r.buf, "%s\n\n", s)
+	}
+}
+
+func (r *textRenderer) list(list *List) {
+	nodes, err := ParseMarkup(list.Value)
+	if err != nil {
+		return
+	}
+	i := 0
+	for _, node := range nodes {
+		switch node.Name {
+		case "head":
+			r.paragraph(r.text(innerMarkup(node)))
+		case "item":
+			i++
+			bullet := "-"
+			if list.ListType == "ordered" {
+				bullet = fmt.Sprintf("%d.", i)
+			}
+			fmt.Fprintf(r.buf, "%s %s\n", bullet, r.text(innerMarkup(node)))
+		case "defitem":
+			label, item := "", ""
+			for _, child := range node.Children {
+				switch child.Name {
+				case "label":
+					label = r.text(innerMarkup(child))
+				case "item":
+					item = r.text(innerMarkup(child))
+				}
+			}
+			if r.markdown && label != "" {
+				label = "**" + label + "**"
+			}
+			fmt.Fprintf(r.buf, "- %s\n", strings.Trim(label+": "+item, ": "))
+		}
+	}
+	r.buf.WriteString("\n")
+}
+
+// innerMarkup rebuilds the embedded XML of a node's children so it can be rendered with text()
+func innerMarkup(node *MarkupNode) string {
+	buf := new(strings.Builder)
+	for _, child := range node.Children {
+		writeMarkup(buf, child)
+	}
+	return buf.String()
+}
+
+func writeMarkup(buf *strings.Builder, node *MarkupNode) {
+	if node.Name == "" {
+		buf.WriteString(xmlEscaper.Replace(node.Text))
+		return
+	}
+	buf.WriteString("<" + node.Name)
+	for k, v := range node.Attr {
+		buf.WriteString(" " + k + `="` + xmlEscaper.Replace(v) + `"`)
+	}
+	buf.WriteString(">")
+	for _, child := range node.Children {
+		writeMarkup(buf, child)
+	}
+	buf.WriteString("</" + node.Name + ">")
+}
+
+var xmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+// table renders rows as a Markdown pipe table, or tab separated columns as plain text
+func (r *textRenderer) table(head []string, rows [][]string) {
+	if len(head) == 0 && len(rows) == 0 {
+		return
+	}
+	cols := len(head)
+	for _, row := range rows {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+	line := func(cells []string) {
+		values := make([]string, cols)
+		copy(values, cells)
+		if r.markdown {
+			for i, v := range values {
+				values[i] = strings.Replace(v, "|", `\|`, -1)
+			}
+			fmt.Fprintf(r.buf, "| %s |\n", strings.Join(values, " | "))
+		} else {
+			fmt.Fprintf(r.buf, "%s\n", strings.TrimRight(strings.Join(values, "\t"), "\t"))
+		}
+	}
+	if r.markdown {
+		// NOTE: a pipe table needs a header row, use an empty one if the source has none
+		line(head)
+		line(strings.Split(strings.Repeat("---,", cols-1)+"---", ","))
+	} else if len(head) > 0 {
+		line(head)
+	}
+	for _, row := range rows {
+		line(row)
+	}
+	r.buf.WriteString("\n")
+}
+
+func (r *textRenderer) note(note *Note, level int) {
+	r.heading(level, r.escape(note.Label()))
+	for _, p := range note.P {
+		r.paragraph(r.text(p.Value))
+	}
+	for _, list := range note.List {
+		r.list(list)
+	}
+	for _, chronList := range note.ChronList {
+		rows := [][]string{}
+		for _, item := range chronList.ChronItem {
+			events := []string{}
+			for _, event := range item.Events() {
+				events = append(events, r.text(event.Value))
+			}
+			rows = append(rows, []string{r.escape(item.Date()), strings.Join(events, " ")})
+		}
+		r.table([]string{"Date", "Event"}, rows)
+	}
+	for _, table := range note.Table {
+		if table.TGroup == nil {
+			continue
+		}
+		head := []string{}
+		rows := [][]string{}
+		cells := func(row *Row) []string {
+			values := []string{}
+			for _, entry := range row.Entry {
+				values = append(values, r.escape(strings.Join(strings.Fields(entry.Value), " ")))
+			}
+			return values
+		}
+		if tHead := table.TGroup.THead; tHead != nil && len(tHead.Row) > 0 {
+			head = cells(tHead.Row[0])
+			for _, row := range tHead.Row[1:] {
+				rows = append(rows, cells(row))
+			}
+		}
+		if tBody := table.TGroup.TBody; tBody != nil {
+			for _, row := range tBody.Row {
+				rows = append(rows, cells(row))
+			}
+		}
+		r.table(head, rows)
+	}
+	for _, blockQuote := range note.BlockQuote {
+		if s := r.text(blockQuote.Value); s != "" && r.markdown {
+			r.paragraph("> " + s)
+		} else {
+			r.paragraph(s)
+		}
+	}
+}
+
+func didDates(did *DID) string {
+	values := []string{}
+	for _, unitDate := range did.UnitDate {
+		values = append(values, strings.TrimSpace(unitDate.Value))
+	}
+	for _, structured := range did.UnitDateStructured {
+		for _, single := range structured.Singles() {
+			values = append(values, strings.TrimSpace(single.Value))
+		}
+		for _, dateRange := range structured.Ranges() {
+			from, to := "", ""
+			if dateRange.FromDate != nil {
+				from = strings.TrimSpace(dateRange.FromDate.Value)
+			}
+			if dateRange.ToDate != nil {
+				to = strings.TrimSpace(dateRange.ToDate.Value)
+			}
+			values = append(values, strings.Trim(from+"-"+to, "-"))
+		}
+	}
+	return strings.Join(strings.Fields(strings.Join(values, ", ")), " ")
+}
+
+func partsValue(parts []*Part) string {
+	values := []string{}
+	for _, part := range parts {
+		values = append(values, strings.TrimSpace(part.Value))
+	}
+	return strings.Join(values, ", ")
+}
+
+func (r *textRenderer) summary(did *DID) {
+	field := func(label, value string) {
+		if value == "" {
+			return
+		}
+		if r.markdown {
+			fmt.Fprintf(r.buf, "- **%s:** %s\n", label, value)
+		} else {
+			fmt.Fprintf(r.buf, "%s: %s\n", label, value)
+		}
+	}
+	if did.Origination != nil {
+		values := []string{}
+		for _, name := range did.Origination.Persname {
+			values = append(values, partsValue(name.Part))
+		}
+		for _, name := range did.Origination.Famname {
+			values = append(values, partsValue(name.Part))
+		}
+		for _, name := range did.Origination.CorpName {
+			values = append(values, partsValue(name.Part))
+		}
+		for _, name := range did.Origination.Name {
+			values = append(values, partsValue(name.Part))
+		}
+		field("Creator", r.escape(strings.Join(values, "; ")))
+	}
+	if did.UnitTitle != nil {
+		field("Title", r.text(did.UnitTitle.Value))
+	}
+	if did.UnitID != nil {
+		field("Identifier", r.text(did.UnitID.Value))
+	}
+	field("Dates", r.escape(didDates(did)))
+	if did.PhysDesc != nil {
+		field("Extent", r.text(did.PhysDesc.Value))
+	}
+	for _, language := range did.LangMaterial.Languages() {
+		field("Language", r.text(language.Value))
+	}
+	if did.Abstract != nil {
+		field("Abstract", r.text(did.Abstract.Value))
+	}
+	r.buf.WriteString("\n")
+}
+
+// component renders a component of the Dsc as a nested list item
+func (r *textRenderer) component(c *Component) {
+	indent := strings.Repeat("  ", c.Depth-1)
+	line := r.escape(c.Title())
+	if r.markdown && len(c.Children) > 0 {
+		line = "**" + line + "**"
+	}
+	if did := c.DID(); did != nil {
+		if dates := didDates(did); dates != "" {
+			line += ", " + r.escape(dates)
+		}
+	}
+	containers := []string{}
+	for _, container := range c.Containers() {
+		containers = append(containers, r.escape(strings.TrimSpace(container.LocalType+" "+strings.TrimSpace(container.Value))))
+	}
+	if len(containers) > 0 {
+		line += " (" + strings.Join(containers, ", ") + ")"
+	}
+	fmt.Fprintf(r.buf, "%s- %s\n", indent, line)
+	for _, dao := range c.DAOs() {
+		if dao.HRef != "" {
+			label := "Digital object"
+			if dao.LinkTitle != "" {
+				label = dao.LinkTitle
+			}
+			if r.markdown {
+				fmt.Fprintf(r.buf, "%s  - [%s](%s)\n", indent, r.escape(label), dao.HRef)
+			} else {
+				fmt.Fprintf(r.buf, "%s  - %s: %s\n", indent, label, dao.HRef)
+			}
+		}
+	}
+	for _, child := range c.Children {
+		r.component(child)
+	}
+}
+
+func (r *textRenderer) render(ead *EAD3) string {
+	r.buf = new(strings.Builder)
+	title := ""
+	if ead.Control != nil && ead.Control.FileDesc != nil && ead.Control.FileDesc.TitleStmt != nil {
+		titleStmt := ead.Control.FileDesc.TitleStmt
+		if titleStmt.TitleProper != nil {
+			title = r.text(titleStmt.TitleProper.Value)
+		}
+		r.heading(1, title)
+		if titleStmt.Subtitle != nil {
+			r.paragraph(r.text(titleStmt.Subtitle.Value))
+		}
+		if titleStmt.Author != nil {
+			r.paragraph(r.text(titleStmt.Author.Value))
+		}
+	}
+	archDesc := ead.ArchDesc
+	if archDesc == nil {
+		return r.buf.String()
+	}
+	for _, did := range archDesc.DID {
+		if title == "" && did.UnitTitle != nil {
+			title = r.text(did.UnitTitle.Value)
+			r.heading(1, title)
+		}
+		r.heading(2, "Collection Overview")
+		r.summary(did)
+	}
+	for _, note := range archDesc.Notes() {
+		r.note(note, 2+note.Depth)
+	}
+	if components := archDesc.Dsc.Components(); len(components) > 0 {
+		head := "Collection Inventory"
+		if archDesc.Dsc.Head != nil {
+			head = r.text(archDesc.Dsc.Head.Value)
+		}
+		r.heading(2, head)
+		for _, c := range components {
+			r.component(c)
+		}
+		r.buf.WriteString("\n")
+	}
+	return strings.TrimRight(r.buf.String(), "\n") + "\n"
+}
+
+// Markdown renders the record as a Markdown document: the title, collection overview,
+// the ArchDesc notes and the Dsc as a nested list.
+func (ead *EAD3) Markdown() string {
+	r := &textRenderer{markdown: true}
+	return r.render(ead)
+}
+
+// Text renders the record as plain text following the same outline as Markdown
+func (ead *EAD3) Text() string {
+	r := &textRenderer{markdown: false}
+	return r.render(ead)
+}
@@ -0,0 +1,87 @@
+//
+// markdown_test.go tests rendering records as Markdown and plain text.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdownInline(t *testing.T) {
+	r := &textRenderer{markdown: true}
+	testData := map[string]string{
+		`<emph render="italic">Dissendium</emph>, <emph render="bold">Protego</emph>`: `*Dissendium*, **Protego**`,
+		`see <ref href="http://example.edu/a b">the guide</ref>`:                      `see [the guide](http://example.edu/a%20b)`,
+		`see <ref target="s1">series 1</ref>`:                                         `see [series 1](#s1)`,
+		`one<lb/>two`:                                                                 "one  \ntwo",
+		"a*b_c\n\t d":                                                                 `a\*b\_c d`,
+	}
+	for src, expected := range testData {
+		if result := r.text(src); result != expected {
+			t.Errorf("text(%q), expected %q, got %q", src, expected, result)
+		}
+	}
+	r.markdown = false
+	if result := r.text(`see <ref href="http://example.edu">the guide</ref>`); result != "see the guide (http://example.edu)" {
+		t.Errorf("unexpected plain text %q", result)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	record := readTestRecord(t, "testsamples/ead3/S.0001_valid.xml")
+	src := record.Markdown()
+	for _, expected := range []string{
+		"# Manuscripts of Salazar Slytherin: Finding Aid\n",
+		"## Collection Overview\n",
+		"## Biography of Salazar Slytherin\n",
+		"| Material Type | Use Type | Permission Required |\n",
+		"- **Class Notes**, 975-1050 (scrollboxes 1-38)\n  - Class Notes for Legilimency",
+	} {
+		if strings.Contains(src, expected) == false {
+			t.Errorf("expected %q in Markdown", expected)
+		}
+	}
+	record = readTestRecord(t, "testsamples/ead3/NCSU/mc00042.xml")
+	if strings.Contains(record.Markdown(), "| Date | Event |\n| --- | --- |\n") == false {
+		t.Errorf("expected the chronology as a table")
+	}
+	txt := record.Text()
+	if strings.Contains(txt, "Collection Overview\n-------------------\n") == false {
+		t.Errorf("expected an underlined heading in plain text")
+	}
+	if strings.ContainsAny(txt, "*#|") == true {
+		t.Errorf("expected no Markdown syntax in plain text")
+	}
+}
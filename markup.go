@@ -0,0 +1,133 @@
+//
+// markup.go parses the mixed content EAD3 keeps as embedded XML (e.g. <p>, <unittitle>).
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// MarkupNode is an element or run of text found in mixed content such as the Value of a P
+type MarkupNode struct {
+	// Name is the element name, empty for text
+	Name     string
+	Attr     map[string]string
+	Text     string
+	Children []*MarkupNode
+}
+
+// ParseMarkup parses embedded XML (e.g. the Value of a P or UnitTitle) into a list of nodes.
+// Character entities are resolved, comments and processing instructions are dropped.
+func ParseMarkup(src string) ([]*MarkupNode, error) {
+	dec := xml.NewDecoder(strings.NewReader("<markup>" + src + "</markup>"))
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+	var root *MarkupNode
+	stack := []*MarkupNode{}
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			node := &MarkupNode{Name: t.Name.Local, Attr: map[string]string{}}
+			for _, attr := range t.Attr {
+				node.Attr[attr.Name.Local] = attr.Value
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, node)
+			} else {
+				root = node
+			}
+			stack = append(stack, node)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, &MarkupNode{Text: string(t)})
+			}
+		}
+	}
+	if root == nil {
+		return []*MarkupNode{}, nil
+	}
+	return root.Children, nil
+}
+
+// Content returns the text of the node and its descendants
+func (node *MarkupNode) Content() string {
+	if node.Name == "" {
+		return node.Text
+	}
+	if node.Name == "lb" {
+		return " "
+	}
+	parts := []string{}
+	for _, child := range node.Children {
+		parts = append(parts, child.Content())
+	}
+	return strings.Join(parts, "")
+}
+
+// StripMarkup returns the text of embedded XML with the markup removed and the
+// white space collapsed. If the markup can't be parsed tags are cut out as is.
+func StripMarkup(src string) string {
+	nodes, err := ParseMarkup(src)
+	if err != nil {
+		for strings.Contains(src, "<") && strings.Contains(src, ">") {
+			start := strings.Index(src, "<")
+			end := strings.Index(src[start:], ">")
+			if end < 0 {
+				break
+			}
+			src = src[:start] + " " + src[start+end+1:]
+		}
+		return strings.Join(strings.Fields(src), " ")
+	}
+	parts := []string{}
+	for _, node := range nodes {
+		parts = append(parts, node.Content())
+	}
+	return strings.Join(strings.Fields(strings.Join(parts, "")), " ")
+}
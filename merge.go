@@ -0,0 +1,768 @@
+//
+// merge.go combines concurrent revisions of a finding aid.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Kinds of Conflict
+const (
+	// ConflictModified is a field or component changed differently on both sides
+	ConflictModified = "modified"
+	// ConflictMoved is a component moved to different places on both sides
+	ConflictMoved = "moved"
+	// ConflictDeleted is a component removed on one side and changed on the other
+	ConflictDeleted = "deleted"
+	// ConflictAdded is a component added on both sides with the same id but different content
+	ConflictAdded = "added"
+)
+
+// Conflict records an edit Merge could not reconcile. Path locates the field's parent
+// ("control", "archdesc", "dsc") or the component as in a Change. Base, Ours and Theirs
+// hold the competing values as XML (or parent paths for a move), empty where the version
+// lacks them, and Resolution names the version kept in the merged record ("ours",
+// "theirs" or "base"), or "removed" if the component could not be placed.
+type Conflict struct {
+	Kind       string `json:"kind"`
+	Path       string `json:"path"`
+	Title      string `json:"title,omitempty"`
+	Field      string `json:"field,omitempty"`
+	Base       string `json:"base,omitempty"`
+	Ours       string `json:"ours,omitempty"`
+	Theirs     string `json:"theirs,omitempty"`
+	Resolution string `json:"resolution"`
+}
+
+// MergeAgent is recorded as the agent of the maintenance event Merge appends
+var MergeAgent = "github.com/caltechlibrary/ead3"
+
+// cloneRecord returns a deep copy of record
+func cloneRecord(record *EAD3) (*EAD3, error) {
+	clone := New()
+	if record == nil {
+		return clone, nil
+	}
+	src, err := xml.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	if err := xml.Unmarshal(src, clone); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// jsonValue returns the JSON encoding of v used to compare versions
+func jsonValue(v reflect.Value) string {
+	src, err := json.Marshal(v.Interface())
+	if err != nil {
+		return ""
+	}
+	return string(src)
+}
+
+// xmlValue returns v as it is reported in a Conflict
+func xmlValue(v interface{}) string {
+	if s, ok := v.(string); ok == true {
+		return s
+	}
+	src, err := xml.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(src)
+}
+
+// fieldName returns the element or attribute name of a struct field
+func fieldName(field reflect.StructField) string {
+	if name := strings.Split(field.Tag.Get("xml"), ",")[0]; name != "" && name != "-" {
+		return name
+	}
+	return strings.ToLower(field.Name)
+}
+
+// maintenanceEvents returns the maintenance events of control
+func maintenanceEvents(control *Control) []*MaintenanceEvent {
+	if control == nil || control.MaintenanceHistory == nil {
+		return nil
+	}
+	return control.MaintenanceHistory.MaintenanceEvent
+}
+
+// mergeMaintenanceEvents gives ours and theirs the union of the maintenance events of
+// the three versions, those of base followed by the events ours and then theirs added.
+// Both sides usually record an event so the history is not compared as a whole.
+func mergeMaintenanceEvents(base, ours, theirs *Control) {
+	merged := []*MaintenanceEvent{}
+	seen := map[string]bool{}
+	for _, events := range [][]*MaintenanceEvent{maintenanceEvents(base), maintenanceEvents(ours), maintenanceEvents(theirs)} {
+		for _, event := range events {
+			if key := xmlValue(event); seen[key] == false {
+				merged = append(merged, event)
+				seen[key] = true
+			}
+		}
+	}
+	if len(merged) == 0 {
+		return
+	}
+	for _, control := range []*Control{ours, theirs} {
+		if control == nil {
+			continue
+		}
+		if control.MaintenanceHistory == nil {
+			control.MaintenanceHistory = new(MaintenanceHistory)
+		}
+		control.MaintenanceHistory.MaintenanceEvent = merged
+	}
+}
+
+// mergeStruct merges the fields of three versions of a struct into ours, a pointer to
+// a struct. Nil pointers stand for the zero value, skip names fields left alone.
+func mergeStruct(conflicts []Conflict, path string, base, ours, theirs interface{}, skip ...string) []Conflict {
+	o := reflect.ValueOf(ours).Elem()
+	t := o.Type()
+	value := func(v interface{}) reflect.Value {
+		if rv := reflect.ValueOf(v); rv.IsNil() == false {
+			return rv.Elem()
+		}
+		return reflect.New(t).Elem()
+	}
+	b, th := value(base), value(theirs)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Name == "XMLName" || field.PkgPath != "" {
+			continue
+		}
+		skipped := false
+		for _, name := range skip {
+			if field.Name == name {
+				skipped = true
+			}
+		}
+		if skipped == true {
+			continue
+		}
+		bv, ov, tv := jsonValue(b.Field(i)), jsonValue(o.Field(i)), jsonValue(th.Field(i))
+		switch {
+		case ov == tv || tv == bv:
+		case ov == bv:
+			o.Field(i).Set(th.Field(i))
+		default:
+			conflicts = append(conflicts, Conflict{
+				Kind:       ConflictModified,
+				Path:       path,
+				Field:      fieldName(field),
+				Base:       xmlValue(b.Field(i).Interface()),
+				Ours:       xmlValue(o.Field(i).Interface()),
+				Theirs:     xmlValue(th.Field(i).Interface()),
+				Resolution: "ours",
+			})
+		}
+	}
+	return conflicts
+}
+
+// withoutComponents returns copies of the dscs without their components
+func withoutComponents(dscs []*Dsc) []*Dsc {
+	if dscs == nil {
+		return nil
+	}
+	copies := []*Dsc{}
+	for _, dsc := range dscs {
+		d := *dsc
+		d.C, d.C01 = nil, nil
+		copies = append(copies, &d)
+	}
+	return copies
+}
+
+// withoutChildren returns a shallow copy of a component element without its nested
+// components, including those of a nested dsc
+func withoutChildren(element interface{}) interface{} {
+	switch e := element.(type) {
+	case *C:
+		c := *e
+		c.C, c.Dsc = nil, withoutComponents(e.Dsc)
+		return &c
+	case *C01:
+		c := *e
+		c.C02, c.Dsc = nil, withoutComponents(e.Dsc)
+		return &c
+	case *C02:
+		c := *e
+		c.C03, c.Dsc = nil, withoutComponents(e.Dsc)
+		return &c
+	case *C03:
+		c := *e
+		c.C04, c.Dsc = nil, withoutComponents(e.Dsc)
+		return &c
+	case *C04:
+		c := *e
+		c.Dsc = withoutComponents(e.Dsc)
+		return &c
+	}
+	return element
+}
+
+// directChild reports if child can be nested in the component parent without a dsc
+func directChild(parent, child interface{}) bool {
+	ok := false
+	switch parent.(type) {
+	case *C:
+		_, ok = child.(*C)
+	case *C01:
+		_, ok = child.(*C02)
+	case *C02:
+		_, ok = child.(*C03)
+	case *C03:
+		_, ok = child.(*C04)
+	}
+	return ok
+}
+
+// nestable reports if child can be nested in parent, a nil parent is the Dsc. The
+// top level components of a dsc can be nested in any component through a nested dsc.
+func nestable(parent, child interface{}) bool {
+	switch parent.(type) {
+	case nil, *Dsc, *C, *C01, *C02, *C03, *C04:
+		switch child.(type) {
+		case *C, *C01:
+			return true
+		}
+	}
+	return directChild(parent, child)
+}
+
+// nestedDscs returns the nested dscs of a component element, nil for a *Dsc
+func nestedDscs(element interface{}) *[]*Dsc {
+	switch e := element.(type) {
+	case *C:
+		return &e.Dsc
+	case *C01:
+		return &e.Dsc
+	case *C02:
+		return &e.Dsc
+	case *C03:
+		return &e.Dsc
+	case *C04:
+		return &e.Dsc
+	}
+	return nil
+}
+
+// holdingDsc returns the dsc of dscs holding child, or nil
+func holdingDsc(dscs []*Dsc, child interface{}) *Dsc {
+	for _, dsc := range dscs {
+		for _, c := range dsc.C {
+			if c == child {
+				return dsc
+			}
+		}
+		for _, c := range dsc.C01 {
+			if c == child {
+				return dsc
+			}
+		}
+	}
+	return nil
+}
+
+// setChildElements replaces the components nested in parent, a *Dsc or component element.
+// The components of a component's nested dsc stay in it, others that can only be nested
+// through a dsc go to its first dsc.
+func setChildElements(parent interface{}, children []interface{}) {
+	if dscs := nestedDscs(parent); dscs != nil {
+		nested := map[*Dsc][]interface{}{}
+		direct := []interface{}{}
+		for _, child := range children {
+			dsc := holdingDsc(*dscs, child)
+			if dsc == nil && directChild(parent, child) == true {
+				direct = append(direct, child)
+				continue
+			}
+			if dsc == nil {
+				if len(*dscs) == 0 {
+					*dscs = append(*dscs, new(Dsc))
+				}
+				dsc = (*dscs)[0]
+			}
+			nested[dsc] = append(nested[dsc], child)
+		}
+		for _, dsc := range *dscs {
+			setChildElements(dsc, nested[dsc])
+		}
+		children = direct
+	}
+	switch p := parent.(type) {
+	case *Dsc:
+		p.C, p.C01 = nil, nil
+		for _, child := range children {
+			switch e := child.(type) {
+			case *C:
+				p.C = append(p.C, e)
+			case *C01:
+				p.C01 = append(p.C01, e)
+			}
+		}
+	case *C:
+		p.C = nil
+		for _, child := range children {
+			p.C = append(p.C, child.(*C))
+		}
+	case *C01:
+		p.C02 = nil
+		for _, child := range children {
+			p.C02 = append(p.C02, child.(*C02))
+		}
+	case *C02:
+		p.C03 = nil
+		for _, child := range children {
+			p.C03 = append(p.C03, child.(*C03))
+		}
+	case *C03:
+		p.C04 = nil
+		for _, child := range children {
+			p.C04 = append(p.C04, child.(*C04))
+		}
+	}
+}
+
+// mergeNode is a component of one version being merged, key identifies it across versions
+type mergeNode struct {
+	*diffComponent
+	key     string
+	parent  string
+	content string
+}
+
+// mergeTree holds the components of one version by key and the keys of their children,
+// the top level components are the children of ""
+type mergeTree struct {
+	name     string
+	nodes    map[string]*mergeNode
+	children map[string][]string
+	keys     map[*diffComponent]string
+}
+
+// newMergeTree keys the components of a version by keyOf, falling back to their id and
+// then their position
+func newMergeTree(name string, list []*diffComponent, byComponent map[*Component]*diffComponent, keyOf func(*diffComponent) string) *mergeTree {
+	tree := &mergeTree{
+		name:     name,
+		nodes:    map[string]*mergeNode{},
+		children: map[string][]string{},
+		keys:     map[*diffComponent]string{},
+	}
+	for _, dc := range list {
+		key := keyOf(dc)
+		if id := dc.ID(); key == "" && id != "" {
+			key = "id:" + id
+		}
+		if _, exists := tree.nodes[key]; key == "" || exists == true {
+			key = name + ":" + dc.position
+		}
+		tree.keys[dc] = key
+		parent := ""
+		if dc.Parent != nil {
+			parent = tree.keys[byComponent[dc.Parent]]
+		}
+		tree.nodes[key] = &mergeNode{
+			diffComponent: dc,
+			key:           key,
+			parent:        parent,
+			content:       jsonValue(reflect.ValueOf(withoutChildren(dc.Element))),
+		}
+		tree.children[parent] = append(tree.children[parent], key)
+	}
+	return tree
+}
+
+// parentPath returns the path of the parent of key in the tree
+func (tree *mergeTree) parentPath(parent string) string {
+	if node, ok := tree.nodes[parent]; ok == true {
+		return node.path()
+	}
+	return "dsc"
+}
+
+// merger holds the state of a three-way merge of the components
+type merger struct {
+	base, ours, theirs *mergeTree
+	// order lists every key, base components first
+	order []string
+	// source is the node whose element is kept for a key, parent its merged parent
+	source    map[string]*mergeNode
+	parent    map[string]string
+	conflicts []Conflict
+}
+
+func (m *merger) conflict(kind string, node *mergeNode, field, base, ours, theirs, resolution string) {
+	m.conflicts = append(m.conflicts, Conflict{
+		Kind:       kind,
+		Path:       node.path(),
+		Title:      node.Title(),
+		Field:      field,
+		Base:       base,
+		Ours:       ours,
+		Theirs:     theirs,
+		Resolution: resolution,
+	})
+}
+
+func content(node *mergeNode) string {
+	if node == nil {
+		return ""
+	}
+	return xmlValue(withoutChildren(node.Element))
+}
+
+// mergeFields merges the fields of a component changed on both sides into ours, the
+// fields of its nested dscs are compared without their components
+func (m *merger) mergeFields(b, o, t *mergeNode) {
+	kind := reflect.TypeOf(b.Element)
+	if reflect.TypeOf(o.Element) != kind || reflect.TypeOf(t.Element) != kind {
+		m.conflict(ConflictModified, o, "", content(b), content(o), content(t), "ours")
+		return
+	}
+	conflicts := mergeStruct(nil, o.path(), b.Element, o.Element, t.Element, "C", "C02", "C03", "C04", "Dsc")
+	for _, conflict := range conflicts {
+		conflict.Title = o.Title()
+		m.conflicts = append(m.conflicts, conflict)
+	}
+	bd, od, td := withoutComponents(*nestedDscs(b.Element)), withoutComponents(*nestedDscs(o.Element)), withoutComponents(*nestedDscs(t.Element))
+	bv, ov, tv := jsonValue(reflect.ValueOf(bd)), jsonValue(reflect.ValueOf(od)), jsonValue(reflect.ValueOf(td))
+	switch {
+	case ov == tv || tv == bv:
+	case ov == bv:
+		// the components are placed in the dscs of theirs by setChildElements
+		*nestedDscs(o.Element) = td
+	default:
+		m.conflict(ConflictModified, o, "dsc", xmlValue(bd), xmlValue(od), xmlValue(td), "ours")
+	}
+}
+
+// mergeComponent decides if and from which version the component is kept, and where
+func (m *merger) mergeComponent(key string) {
+	b, o, t := m.base.nodes[key], m.ours.nodes[key], m.theirs.nodes[key]
+	switch {
+	case b != nil && o != nil && t != nil:
+		m.source[key] = o
+		switch {
+		case o.content == t.content || t.content == b.content:
+		case o.content == b.content:
+			m.source[key] = t
+		default:
+			m.mergeFields(b, o, t)
+		}
+		m.parent[key] = o.parent
+		switch {
+		case o.parent == t.parent || t.parent == b.parent:
+		case o.parent == b.parent:
+			m.parent[key] = t.parent
+		default:
+			m.conflict(ConflictMoved, o, "", m.base.parentPath(b.parent), m.ours.parentPath(o.parent), m.theirs.parentPath(t.parent), "ours")
+		}
+	case b != nil && o != nil:
+		if o.content != b.content || o.parent != b.parent {
+			m.conflict(ConflictDeleted, o, "", content(b), content(o), "", "ours")
+			m.source[key], m.parent[key] = o, o.parent
+		}
+	case b != nil && t != nil:
+		if t.content != b.content || t.parent != b.parent {
+			m.conflict(ConflictDeleted, t, "", content(b), "", content(t), "theirs")
+			m.source[key], m.parent[key] = t, t.parent
+		}
+	case o != nil:
+		m.source[key], m.parent[key] = o, o.parent
+		if t != nil && (o.content != t.content || o.parent != t.parent) {
+			m.conflict(ConflictAdded, o, "", "", content(o), content(t), "ours")
+		}
+	case t != nil:
+		m.source[key], m.parent[key] = t, t.parent
+	}
+}
+
+// restoreAncestors keeps the removed ancestors of kept components
+func (m *merger) restoreAncestors() {
+	for _, key := range m.order {
+		if _, ok := m.source[key]; ok == false {
+			continue
+		}
+		for parent := m.parent[key]; parent != ""; parent = m.parent[parent] {
+			if _, ok := m.source[parent]; ok == true {
+				break
+			}
+			node, resolution := m.ours.nodes[parent], "ours"
+			if node == nil {
+				node, resolution = m.theirs.nodes[parent], "theirs"
+			}
+			if node == nil {
+				node, resolution = m.base.nodes[parent], "base"
+			}
+			m.conflict(ConflictDeleted, node, "", content(m.base.nodes[parent]), content(m.ours.nodes[parent]), content(m.theirs.nodes[parent]), resolution)
+			m.source[parent], m.parent[parent] = node, node.parent
+		}
+	}
+}
+
+// placeComponents returns kept components to their parent in the version their element
+// comes from when the merged parent is one of their descendants or cannot hold the element
+func (m *merger) placeComponents() {
+	for _, key := range m.order {
+		node, ok := m.source[key]
+		if ok == false {
+			continue
+		}
+		cyclic := false
+		seen := map[string]bool{key: true}
+		for parent := m.parent[key]; parent != ""; parent = m.parent[parent] {
+			if seen[parent] == true {
+				cyclic = true
+				break
+			}
+			seen[parent] = true
+		}
+		var parentElement interface{}
+		if parent, ok := m.source[m.parent[key]]; ok == true {
+			parentElement = parent.Element
+		}
+		if cyclic == true || nestable(parentElement, node.Element) == false {
+			m.parent[key] = node.parent
+		}
+	}
+}
+
+// sameOrder reports if the keys a and b share are in the same order
+func sameOrder(a, b []string) bool {
+	in := func(keys []string) map[string]bool {
+		set := map[string]bool{}
+		for _, key := range keys {
+			set[key] = true
+		}
+		return set
+	}
+	inA, inB := in(a), in(b)
+	x, y := []string{}, []string{}
+	for _, key := range a {
+		if inB[key] == true {
+			x = append(x, key)
+		}
+	}
+	for _, key := range b {
+		if inA[key] == true {
+			y = append(y, key)
+		}
+	}
+	return strings.Join(x, "\x00") == strings.Join(y, "\x00")
+}
+
+// orderChildren returns the merged children of parent. The order comes from ours unless
+// only theirs reordered them, the other side's additions follow their preceding sibling.
+func (m *merger) orderChildren(parent string) []string {
+	members := map[string]bool{}
+	for _, key := range m.order {
+		if _, ok := m.source[key]; ok == true && m.parent[key] == parent {
+			members[key] = true
+		}
+	}
+	skeleton, other := m.ours.children[parent], m.theirs.children[parent]
+	if sameOrder(skeleton, m.base.children[parent]) == true {
+		skeleton, other = other, skeleton
+	}
+	list := []string{}
+	placed := map[string]bool{}
+	for _, key := range skeleton {
+		if members[key] == true && placed[key] == false {
+			list = append(list, key)
+			placed[key] = true
+		}
+	}
+	at := 0
+	for _, key := range other {
+		if placed[key] == true {
+			for i, k := range list {
+				if k == key {
+					at = i + 1
+				}
+			}
+			continue
+		}
+		if members[key] == false {
+			continue
+		}
+		list = append(list[:at], append([]string{key}, list[at:]...)...)
+		placed[key] = true
+		at++
+	}
+	for _, key := range m.order {
+		if members[key] == true && placed[key] == false {
+			list = append(list, key)
+		}
+	}
+	return list
+}
+
+// Merge combines two revisions, ours and theirs, of a common base version of a finding
+// aid. Components are aligned by id as in Diff. Edits made on one side to the control,
+// the collection's fields and notes, and the components of the Dsc (their content,
+// parent and order, additions and removals) are applied to a copy of ours. The fields of
+// a component are merged one by one. Where both sides changed the same field or placed a
+// component differently ours is kept and a Conflict recorded. A
+// "revised" maintenance event describing the merge is appended to the result.
+func Merge(base, ours, theirs *EAD3) (*EAD3, []Conflict, error) {
+	var err error
+	if base, err = cloneRecord(base); err != nil {
+		return nil, nil, err
+	}
+	if ours, err = cloneRecord(ours); err != nil {
+		return nil, nil, err
+	}
+	if theirs, err = cloneRecord(theirs); err != nil {
+		return nil, nil, err
+	}
+	conflicts := []Conflict{}
+	if ours.Control == nil {
+		ours.Control = new(Control)
+	}
+	mergeMaintenanceEvents(base.Control, ours.Control, theirs.Control)
+	conflicts = mergeStruct(conflicts, "control", base.Control, ours.Control, theirs.Control)
+	if ours.ArchDesc == nil {
+		ours.ArchDesc = new(ArchDesc)
+	}
+	if base.ArchDesc == nil {
+		base.ArchDesc = new(ArchDesc)
+	}
+	if theirs.ArchDesc == nil {
+		theirs.ArchDesc = new(ArchDesc)
+	}
+	conflicts = mergeStruct(conflicts, "archdesc", base.ArchDesc, ours.ArchDesc, theirs.ArchDesc, "Dsc")
+	if ours.ArchDesc.Dsc == nil && theirs.ArchDesc.Dsc != nil {
+		ours.ArchDesc.Dsc = new(Dsc)
+	}
+	if dsc := ours.ArchDesc.Dsc; dsc != nil {
+		conflicts = mergeStruct(conflicts, "dsc", base.ArchDesc.Dsc, dsc, theirs.ArchDesc.Dsc, "C", "C01")
+
+		_, listBase, byBase := flattenComponents(base)
+		topBase, listBaseT, byBaseT := flattenComponents(base)
+		topOurs, listOurs, byOurs := flattenComponents(ours)
+		_, listTheirs, byTheirs := flattenComponents(theirs)
+		alignComponents(topOurs, listOurs, byOurs, listBase, byBase)
+		alignComponents(topBase, listBaseT, byBaseT, listTheirs, byTheirs)
+		none := func(*diffComponent) string { return "" }
+		m := &merger{
+			base:   newMergeTree("base", listBase, byBase, none),
+			source: map[string]*mergeNode{},
+			parent: map[string]string{},
+		}
+		baseKeys := newMergeTree("base", listBaseT, byBaseT, none).keys
+		m.ours = newMergeTree("ours", listOurs, byOurs, func(dc *diffComponent) string {
+			if dc.match != nil {
+				return m.base.keys[dc.match]
+			}
+			return ""
+		})
+		m.theirs = newMergeTree("theirs", listTheirs, byTheirs, func(dc *diffComponent) string {
+			if dc.match != nil {
+				return baseKeys[dc.match]
+			}
+			return ""
+		})
+		seen := map[string]bool{}
+		for _, tree := range []struct {
+			list []*diffComponent
+			keys map[*diffComponent]string
+		}{{listBase, m.base.keys}, {listOurs, m.ours.keys}, {listTheirs, m.theirs.keys}} {
+			for _, dc := range tree.list {
+				if key := tree.keys[dc]; seen[key] == false {
+					m.order = append(m.order, key)
+					seen[key] = true
+				}
+			}
+		}
+		for _, key := range m.order {
+			m.mergeComponent(key)
+		}
+		m.restoreAncestors()
+		m.placeComponents()
+		for _, key := range append([]string{""}, m.order...) {
+			var element interface{} = dsc
+			if key != "" {
+				node, ok := m.source[key]
+				if ok == false {
+					continue
+				}
+				element = node.Element
+			}
+			children := []interface{}{}
+			for _, child := range m.orderChildren(key) {
+				node := m.source[child]
+				if nestable(element, node.Element) == false {
+					m.conflict(ConflictMoved, node, "", "", "", "", "removed")
+					continue
+				}
+				children = append(children, node.Element)
+			}
+			setChildElements(element, children)
+		}
+		conflicts = append(conflicts, m.conflicts...)
+	}
+
+	if ours.Control.MaintenanceHistory == nil {
+		ours.Control.MaintenanceHistory = new(MaintenanceHistory)
+	}
+	now := time.Now()
+	description := "Merged concurrent revisions"
+	if len(conflicts) > 0 {
+		description = fmt.Sprintf("Merged concurrent revisions with %d conflicts", len(conflicts))
+	}
+	ours.Control.MaintenanceHistory.MaintenanceEvent = append(ours.Control.MaintenanceHistory.MaintenanceEvent, &MaintenanceEvent{
+		EventType: &EventType{Value: "revised"},
+		EventDateTime: &EventDateTime{
+			StandardDateTime: now.Format(time.RFC3339),
+			Value:            now.Format("2006-01-02"),
+		},
+		AgentType:        &AgentType{Value: "machine"},
+		Agent:            MergeAgent,
+		EventDescription: description,
+	})
+	return ours, conflicts, nil
+}
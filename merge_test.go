@@ -0,0 +1,222 @@
+//
+// merge_test.go tests three-way merges of finding aids.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"strings"
+	"testing"
+)
+
+func findConflict(conflicts []Conflict, kind, path, field string) *Conflict {
+	for i, conflict := range conflicts {
+		if conflict.Kind == kind && conflict.Path == path && conflict.Field == field {
+			return &conflicts[i]
+		}
+	}
+	return nil
+}
+
+func TestMerge(t *testing.T) {
+	fname := "testsamples/ead3/NCSU/mc00019.xml"
+	base, ours, theirs := readTestRecord(t, fname), readTestRecord(t, fname), readTestRecord(t, fname)
+	numberComponents(base)
+	numberComponents(ours)
+	numberComponents(theirs)
+
+	merged, conflicts, err := Merge(base, ours, theirs)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("expected no conflicts, got %+v", conflicts)
+	}
+	if changes := Diff(base, merged); len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+
+	// ours revises the biographical note, a title, and removes Leigh H. Hammond
+	ours.ArchDesc.BiogHist[0].P[0].Value = strings.Replace(ours.ArchDesc.BiogHist[0].P[0].Value, "2003 and 2004", "2003, 2004 and 2005", 1)
+	ours.ArchDesc.Dsc.C[0].DID.UnitTitle.Value = "Harry Allen, Junior"
+	ours.ArchDesc.Dsc.C = append(ours.ArchDesc.Dsc.C[0:1], ours.ArchDesc.Dsc.C[2:]...)
+	// theirs moves Sion H. Harrington's tape log to Charles J. McCann and adds a series
+	dsc := theirs.ArchDesc.Dsc
+	tapeLog := dsc.C[2].C[2]
+	dsc.C[2].C = dsc.C[2].C[0:2]
+	dsc.C[3].C = append(dsc.C[3].C, tapeLog)
+	dsc.C = append(dsc.C[0:1], append([]*C{{ID: "new1", DID: &DID{UnitTitle: &UnitTitle{Value: "Jane Doe"}}}}, dsc.C[1:]...)...)
+	// both retitle the master tape of Harry Allen, theirs also edits the removed master tape
+	ours.ArchDesc.Dsc.C[0].C[0].DID.UnitTitle.Value = "Master Tape (ours)"
+	dsc.C[0].C[0].DID.UnitTitle.Value = "Master Tape (theirs)"
+	dsc.C[2].C[0].DID.UnitTitle.Value = "Master Audiocassette"
+	// and the scope note differently
+	ours.ArchDesc.ScopeContent[0].P[0].Value = "Ours"
+	theirs.ArchDesc.ScopeContent[0].P[0].Value = "Theirs"
+
+	merged, conflicts, err = Merge(base, ours, theirs)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	expectedConflicts := []struct {
+		kind, path, field, resolution string
+	}{
+		{ConflictModified, "archdesc", "scopecontent", "ours"},
+		{ConflictModified, "c[c2]", "did", "ours"},
+		{ConflictDeleted, "c[c6]", "", "theirs"},
+		{ConflictDeleted, "c[c5]", "", "theirs"},
+	}
+	for _, e := range expectedConflicts {
+		if conflict := findConflict(conflicts, e.kind, e.path, e.field); conflict == nil {
+			t.Errorf("expected %s conflict on %s %s", e.kind, e.path, e.field)
+		} else if conflict.Resolution != e.resolution {
+			t.Errorf("expected %s conflict on %s resolved as %s, got %s", e.kind, e.path, e.resolution, conflict.Resolution)
+		}
+	}
+	if len(conflicts) != len(expectedConflicts) {
+		t.Errorf("expected %d conflicts, got %+v", len(expectedConflicts), conflicts)
+	}
+	if conflict := findConflict(conflicts, ConflictModified, "c[c2]", "did"); conflict != nil && (strings.Contains(conflict.Ours, "Master Tape (ours)") == false || strings.Contains(conflict.Theirs, "Master Tape (theirs)") == false) {
+		t.Errorf("expected both titles in the conflict, got %+v", conflict)
+	}
+
+	changes := Diff(base, merged)
+	expected := []struct {
+		kind, path, field string
+	}{
+		{ChangeModified, "archdesc", "bioghist"},
+		{ChangeModified, "archdesc", "scopecontent"},
+		{ChangeModified, "c[c1]", "did/unittitle"},
+		{ChangeModified, "c[c2]", "did/unittitle"},
+		{ChangeModified, "c[c6]", "did/unittitle"},
+		{ChangeMoved, "c[c10]", ""},
+		{ChangeAdded, "c[new1]", ""},
+	}
+	for _, e := range expected {
+		if findChange(changes, e.kind, e.path, e.field) == nil {
+			t.Errorf("expected %s %s %s", e.kind, e.path, e.field)
+		}
+	}
+	if len(changes) != len(expected) {
+		t.Errorf("expected %d changes, got %d", len(expected), len(changes))
+		WriteDiffText(&testWriter{t}, changes)
+	}
+	if c := merged.ArchDesc.Dsc.C; len(c) != 13 || c[1].ID != "new1" || c[2].ID != "c5" {
+		t.Errorf("expected new1 after c1 and c5 kept, got %d components", len(c))
+	}
+	if base.ArchDesc.ScopeContent[0].P[0].Value == "Ours" || len(ours.ArchDesc.Dsc.C) != 11 {
+		t.Errorf("expected the merged versions to be left unchanged")
+	}
+
+	events := merged.Control.MaintenanceHistory.MaintenanceEvent
+	if event := events[len(events)-1]; event.EventType.Value != "revised" || event.AgentType.Value != "machine" || strings.Contains(event.EventDescription, "4 conflicts") == false {
+		t.Errorf("expected a merge maintenance event, got %+v", event)
+	}
+}
+
+func TestMergeComponentFields(t *testing.T) {
+	fname := "testsamples/ead3/NCSU/mc00019.xml"
+	for _, ids := range []bool{true, false} {
+		base, ours, theirs := readTestRecord(t, fname), readTestRecord(t, fname), readTestRecord(t, fname)
+		if ids == true {
+			numberComponents(base)
+			numberComponents(ours)
+			numberComponents(theirs)
+		}
+		// ours retitles the first series and theirs describes it
+		ours.ArchDesc.Dsc.C[0].DID.UnitTitle.Value = "Interview with an Engineering Student"
+		theirs.ArchDesc.Dsc.C[0].ScopeContent = []*ScopeContent{{P: []*P{{Value: "Interviewed by Anna Dahlstein"}}}}
+		// and a tape that shares nothing but its position and container
+		ours.ArchDesc.Dsc.C[1].C[0].DID.UnitTitle.Value = "Original Cassette"
+		theirs.ArchDesc.Dsc.C[1].C[0].ScopeContent = []*ScopeContent{{P: []*P{{Value: "Recorded 2004"}}}}
+
+		merged, conflicts, err := Merge(base, ours, theirs)
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+		if len(conflicts) != 0 {
+			t.Errorf("expected no conflicts with ids %t, got %+v", ids, conflicts)
+		}
+		c := merged.ArchDesc.Dsc.C
+		if len(c) != 12 {
+			t.Fatalf("expected 12 series with ids %t, got %d", ids, len(c))
+		}
+		if c[0].DID.UnitTitle.Value != "Interview with an Engineering Student" || len(c[0].ScopeContent) != 1 || len(c[0].C) != 3 {
+			t.Errorf("expected both edits of the first series with ids %t, got %q and %d scope notes", ids, c[0].DID.UnitTitle.Value, len(c[0].ScopeContent))
+		}
+		if tape := c[1].C; len(tape) != 1 || tape[0].DID.UnitTitle.Value != "Original Cassette" || len(tape[0].ScopeContent) != 1 {
+			t.Errorf("expected both edits of the tape with ids %t, got %d tapes", ids, len(tape))
+		}
+	}
+
+	// the same field edited on both sides conflicts
+	base, ours, theirs := readTestRecord(t, fname), readTestRecord(t, fname), readTestRecord(t, fname)
+	ours.ArchDesc.Dsc.C[0].ScopeContent = []*ScopeContent{{P: []*P{{Value: "Ours"}}}}
+	theirs.ArchDesc.Dsc.C[0].ScopeContent = []*ScopeContent{{P: []*P{{Value: "Theirs"}}}}
+	_, conflicts, err := Merge(base, ours, theirs)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(conflicts) != 1 || findConflict(conflicts, ConflictModified, "c[1]", "scopecontent") == nil {
+		t.Errorf("expected a scopecontent conflict, got %+v", conflicts)
+	}
+}
+
+func TestMergeMaintenanceEvents(t *testing.T) {
+	fname := "testsamples/ead3/NCSU/mc00019.xml"
+	base, ours, theirs := readTestRecord(t, fname), readTestRecord(t, fname), readTestRecord(t, fname)
+	baseEvents := len(base.Control.MaintenanceHistory.MaintenanceEvent)
+	event := func(agent string) *MaintenanceEvent {
+		return &MaintenanceEvent{
+			EventType:     &EventType{Value: "revised"},
+			EventDateTime: &EventDateTime{StandardDateTime: "2026-10-19", Value: "2026-10-19"},
+			AgentType:     &AgentType{Value: "human"},
+			Agent:         agent,
+		}
+	}
+	ours.Control.MaintenanceHistory.MaintenanceEvent = append(ours.Control.MaintenanceHistory.MaintenanceEvent, event("Ours"))
+	theirs.Control.MaintenanceHistory.MaintenanceEvent = append(theirs.Control.MaintenanceHistory.MaintenanceEvent, event("Theirs"))
+
+	merged, conflicts, err := Merge(base, ours, theirs)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("expected no conflicts, got %+v", conflicts)
+	}
+	// base events, ours, theirs and the merge
+	events := merged.Control.MaintenanceHistory.MaintenanceEvent
+	if len(events) != baseEvents+3 || events[baseEvents].Agent != "Ours" || events[baseEvents+1].Agent != "Theirs" || events[baseEvents+2].Agent != MergeAgent {
+		t.Errorf("expected the events of both sides followed by the merge, got %d events", len(events))
+	}
+}
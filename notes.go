@@ -0,0 +1,256 @@
+//
+// notes.go provides a common view of the narrative notes of an archival description.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"reflect"
+)
+
+// Note is a common view of the narrative notes (e.g. <bioghist>, <scopecontent>,
+// <accessrestrict>) found in an ArchDesc or component.
+type Note struct {
+	// Name is the element name of the note, e.g. "bioghist"
+	Name string
+	ID   string
+	// Head is the note's heading as embedded XML, empty if the note has none
+	Head string
+	// P, List, ChronList, Table and BlockQuote are the blocks of the note, each kind
+	// in document order
+	P          []*P
+	List       []*List
+	ChronList  []*ChronList
+	Table      []*Table
+	BlockQuote []*BlockQuote
+	// Depth is 0 for a note given directly on the ArchDesc or component, 1 for a note
+	// nested inside it and so on
+	Depth int
+	// Element is the underlying note (e.g. *BiogHist)
+	Element interface{}
+}
+
+// NoteLabels are the display labels used for notes without a head
+var NoteLabels = map[string]string{
+	"accessrestrict":    "Conditions Governing Access",
+	"accruals":          "Accruals",
+	"acqinfo":           "Immediate Source of Acquisition",
+	"altformavail":      "Existence and Location of Copies",
+	"appraisal":         "Appraisal",
+	"arrangement":       "Arrangement",
+	"bibliography":      "Bibliography",
+	"bioghist":          "Biographical / Historical",
+	"custodhist":        "Custodial History",
+	"fileplan":          "File Plan",
+	"legalstatus":       "Legal Status",
+	"odd":               "Other Descriptive Data",
+	"originalsloc":      "Existence and Location of Originals",
+	"otherfindaid":      "Other Finding Aids",
+	"phystech":          "Physical Characteristics and Technical Requirements",
+	"prefercite":        "Preferred Citation",
+	"processinfo":       "Processing Information",
+	"relatedmaterial":   "Related Material",
+	"scopecontent":      "Scope and Contents",
+	"separatedmaterial": "Separated Material",
+	"userestrict":       "Conditions Governing Use",
+}
+
+// Label returns the note's head as plain text or the default label for the note
+func (note *Note) Label() string {
+	if note.Head != "" {
+		return StripMarkup(note.Head)
+	}
+	return NoteLabels[note.Name]
+}
+
+func headValue(head *Head) string {
+	if head == nil {
+		return ""
+	}
+	return head.Value
+}
+
+// references returns the paragraphs followed by the archival and bibliographic
+// references as paragraphs
+func references(p []*P, archRefs []*ArchRef, bibRefs []*BibRef) []*P {
+	paragraphs := append([]*P{}, p...)
+	for _, archRef := range archRefs {
+		paragraphs = append(paragraphs, &P{Value: archRef.Value})
+	}
+	for _, bibRef := range bibRefs {
+		paragraphs = append(paragraphs, &P{Value: bibRef.Value})
+	}
+	return paragraphs
+}
+
+// newNote returns the common view of a narrative note element, nil if element
+// is not a note
+func newNote(element interface{}) *Note {
+	var note *Note
+	switch n := element.(type) {
+	case *BiogHist:
+		note = &Note{Name: "bioghist", ID: n.ID, Head: headValue(n.Head), P: n.P, Element: n}
+	case *ScopeContent:
+		note = &Note{Name: "scopecontent", ID: n.ID, Head: headValue(n.Head), P: n.P, Element: n}
+	case *Arrangement:
+		note = &Note{Name: "arrangement", ID: n.ID, Head: headValue(n.Head), P: n.P, Element: n}
+	case *AccessRestrict:
+		note = &Note{Name: "accessrestrict", ID: n.ID, Head: headValue(n.Head), P: n.P, Element: n}
+	case *UseRestrict:
+		note = &Note{Name: "userestrict", ID: n.ID, Head: headValue(n.Head), P: n.P, Element: n}
+	case *AcqInfo:
+		note = &Note{Name: "acqinfo", ID: n.ID, Head: headValue(n.Head), P: n.P, Element: n}
+	case *CustodHist:
+		note = &Note{Name: "custodhist", ID: n.ID, Head: headValue(n.Head), P: n.P, Element: n}
+	case *Appraisal:
+		note = &Note{Name: "appraisal", ID: n.ID, Head: headValue(n.Head), P: n.P, Element: n}
+	case *Accruals:
+		note = &Note{Name: "accruals", ID: n.ID, Head: headValue(n.Head), P: n.P, Element: n}
+	case *ProcessInfo:
+		note = &Note{Name: "processinfo", ID: n.ID, Head: headValue(n.Head), P: n.P, Element: n}
+	case *RelatedMaterial:
+		note = &Note{Name: "relatedmaterial", ID: n.ID, Head: headValue(n.Head), P: references(n.P, n.ArchRef, n.BibRef), Element: n}
+	case *SeparatedMaterial:
+		note = &Note{Name: "separatedmaterial", ID: n.ID, Head: headValue(n.Head), P: references(n.P, n.ArchRef, n.BibRef), Element: n}
+	case *OtherFindAID:
+		note = &Note{Name: "otherfindaid", ID: n.ID, Head: headValue(n.Head), P: references(n.P, n.ArchRef, n.BibRef), Element: n}
+	case *OriginalsLoc:
+		note = &Note{Name: "originalsloc", ID: n.ID, Head: headValue(n.Head), P: n.P, Element: n}
+	case *AltFormAvail:
+		note = &Note{Name: "altformavail", ID: n.ID, Head: headValue(n.Head), P: n.P, Element: n}
+	case *PhysTech:
+		note = &Note{Name: "phystech", ID: n.ID, Head: headValue(n.Head), P: n.P, Element: n}
+	case *FilePlan:
+		note = &Note{Name: "fileplan", ID: n.ID, Head: headValue(n.Head), P: n.P, Element: n}
+	case *LegalStatus:
+		note = &Note{Name: "legalstatus", ID: n.ID, Head: headValue(n.Head), P: n.P, Element: n}
+	case *PreferCite:
+		note = &Note{Name: "prefercite", ID: n.ID, Head: headValue(n.Head), P: n.P, Element: n}
+	case *Bibliography:
+		note = &Note{Name: "bibliography", ID: n.ID, Head: headValue(n.Head), P: references(n.P, n.ArchRef, n.BibRef), Element: n}
+	case *Odd:
+		note = &Note{Name: "odd", ID: n.ID, Head: headValue(n.Head), P: n.P, Element: n}
+	}
+	if note == nil {
+		return nil
+	}
+	v := reflect.ValueOf(element).Elem()
+	note.List = v.FieldByName("List").Interface().([]*List)
+	note.ChronList = v.FieldByName("ChronList").Interface().([]*ChronList)
+	note.Table = v.FieldByName("Table").Interface().([]*Table)
+	note.BlockQuote = v.FieldByName("BlockQuote").Interface().([]*BlockQuote)
+	return note
+}
+
+// appendNotes appends the notes held in each slice of note elements, every note
+// followed by the notes nested inside it
+func appendNotes(notes []*Note, depth int, slices ...interface{}) []*Note {
+	for _, slice := range slices {
+		v := reflect.ValueOf(slice)
+		for i := 0; i < v.Len(); i++ {
+			element := v.Index(i)
+			if element.IsNil() == true {
+				continue
+			}
+			note := newNote(element.Interface())
+			note.Depth = depth
+			notes = append(notes, note)
+			nested := element.Elem().FieldByName(element.Elem().Type().Name())
+			notes = appendNotes(notes, depth+1, nested.Interface())
+		}
+	}
+	return notes
+}
+
+// Notes returns the narrative notes of the ArchDesc in display order, nested notes
+// follow the note holding them
+func (archDesc *ArchDesc) Notes() []*Note {
+	if archDesc == nil {
+		return []*Note{}
+	}
+	return appendNotes([]*Note{}, 0,
+		archDesc.BiogHist,
+		archDesc.ScopeContent,
+		archDesc.Arrangement,
+		archDesc.AccessRestrict,
+		archDesc.UseRestrict,
+		archDesc.AcqInfo,
+		archDesc.CustodHist,
+		archDesc.Appraisal,
+		archDesc.Accruals,
+		archDesc.ProcessInfo,
+		archDesc.RelatedMaterial,
+		archDesc.SeparatedMaterial,
+		archDesc.OtherFindAID,
+		archDesc.OriginalsLoc,
+		archDesc.AltFormAvail,
+		archDesc.PhysTech,
+		archDesc.FilePlan,
+		archDesc.LegalStatus,
+		archDesc.PreferCite,
+		archDesc.Bibliography,
+		archDesc.Odd,
+	)
+}
+
+// Notes returns the narrative notes of the component in display order, nested notes
+// follow the note holding them
+func (c *Component) Notes() []*Note {
+	f := c.fields()
+	if f.DID == nil {
+		return []*Note{}
+	}
+	return appendNotes([]*Note{}, 0,
+		*f.BiogHist,
+		*f.ScopeContent,
+		*f.Arrangement,
+		*f.AccessRestrict,
+		*f.UseRestrict,
+		*f.AcqInfo,
+		*f.CustodHist,
+		*f.Appraisal,
+		*f.Accruals,
+		*f.ProcessInfo,
+		*f.RelatedMaterial,
+		*f.SeparatedMaterial,
+		*f.OtherFindAID,
+		*f.OriginalsLoc,
+		*f.AltFormAvail,
+		*f.PhysTech,
+		*f.FilePlan,
+		*f.LegalStatus,
+		*f.PreferCite,
+		*f.Bibliography,
+		*f.Odd,
+	)
+}
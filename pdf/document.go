@@ -0,0 +1,219 @@
+// Package pdf writes printable finding aids as PDF documents without depending on
+// anything outside the standard library. Text is set in the PDF standard Helvetica
+// fonts so no fonts need to be embedded.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+//   - Redistributions of source code must retain the above copyright notice, this
+//     list of conditions and the following disclaimer.
+//
+//   - Redistributions in binary form must reproduce the above copyright notice,
+//     this list of conditions and the following disclaimer in the documentation
+//     and/or other materials provided with the distribution.
+//
+//   - Neither the name of epgo nor the names of its
+//     contributors may be used to endorse or promote products derived from
+//     this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Font identifies one of the standard fonts available to a Document
+type Font int
+
+const (
+	Regular Font = iota
+	Bold
+	Italic
+)
+
+// fontNames are the PDF base font names for each Font
+var fontNames = []string{"Helvetica", "Helvetica-Bold", "Helvetica-Oblique"}
+
+const (
+	// LetterWidth and LetterHeight are the dimensions of US Letter paper in points
+	LetterWidth  = 612.0
+	LetterHeight = 792.0
+)
+
+// Document is a minimal PDF document made of pages of text and lines
+type Document struct {
+	Title  string
+	Width  float64
+	Height float64
+	Pages  []*Page
+}
+
+// Page holds the content stream of a single page
+type Page struct {
+	content bytes.Buffer
+}
+
+// NewDocument returns an empty document with US Letter pages
+func NewDocument(title string) *Document {
+	return &Document{
+		Title:  title,
+		Width:  LetterWidth,
+		Height: LetterHeight,
+	}
+}
+
+// AddPage appends a new blank page to the document and returns it
+func (doc *Document) AddPage() *Page {
+	page := new(Page)
+	doc.Pages = append(doc.Pages, page)
+	return page
+}
+
+// Text draws s with its baseline starting at x, y (points from the bottom left corner)
+func (page *Page) Text(x, y float64, font Font, size float64, s string) {
+	fmt.Fprintf(&page.content, "BT /F%d %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font+1, size, x, y, escape(s))
+}
+
+// Line draws a line from x1, y1 to x2, y2
+func (page *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&page.content, "%.2f w %.2f %.2f m %.2f %.2f l S\n", width, x1, y1, x2, y2)
+}
+
+// escape encodes s as WinAnsi (the encoding of the standard fonts) and escapes the
+// characters that are special in a PDF string
+func escape(s string) string {
+	buf := new(strings.Builder)
+	for _, r := range s {
+		b, ok := winAnsi(r)
+		if ok == false {
+			b = '?'
+		}
+		switch b {
+		case '(', ')', '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(b)
+		default:
+			if b < 32 || b > 126 {
+				fmt.Fprintf(buf, "\\%03o", b)
+			} else {
+				buf.WriteByte(b)
+			}
+		}
+	}
+	return buf.String()
+}
+
+// winAnsiExtra maps the characters WinAnsiEncoding places in 0x80-0x9f
+var winAnsiExtra = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8a, '‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	'˜': 0x98, '™': 0x99, 'š': 0x9a, '›': 0x9b, 'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+}
+
+func winAnsi(r rune) (byte, bool) {
+	switch {
+	case r == '\t' || r == '\n' || r == '\r':
+		return ' ', true
+	case r >= 32 && r <= 126:
+		return byte(r), true
+	case r >= 0xa0 && r <= 0xff:
+		return byte(r), true
+	}
+	b, ok := winAnsiExtra[r]
+	return b, ok
+}
+
+// Width returns the width of s in points when set in font at size
+func Width(s string, font Font, size float64) float64 {
+	widths := helveticaWidths
+	if font == Bold {
+		widths = helveticaBoldWidths
+	}
+	total := 0
+	for _, r := range s {
+		if r >= 32 && r <= 126 {
+			total += widths[r-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000.0
+}
+
+// WriteTo writes the document in PDF format to w
+func (doc *Document) WriteTo(w io.Writer) (int64, error) {
+	buf := new(bytes.Buffer)
+	offsets := []int{}
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// NOTE: objects 1 and 2 are the catalog and page tree, 3 to 5 the fonts, 6 the info
+	// dictionary, each page is followed by its content stream.
+	firstPage := 7
+	kids := []string{}
+	for i := range doc.Pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", firstPage+i*2))
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(doc.Pages)))
+	for _, name := range fontNames {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name))
+	}
+	object(fmt.Sprintf("<< /Title (%s) /Producer (caltechlibrary/ead3) >>", escape(doc.Title)))
+	for i, page := range doc.Pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R /F3 5 0 R >> >> /Contents %d 0 R >>",
+			doc.Width, doc.Height, firstPage+i*2+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.content.Len(), page.content.String()))
+	}
+	xref := buf.Len()
+	fmt.Fprintf(buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(buf, "trailer\n<< /Size %d /Root 1 0 R /Info 6 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+// helveticaWidths are the widths of the printable ASCII characters (space through tilde)
+// in Helvetica from the Adobe font metrics, Helvetica-Oblique shares them.
+var helveticaWidths = []int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// helveticaBoldWidths are the widths of the printable ASCII characters in Helvetica-Bold
+var helveticaBoldWidths = []int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
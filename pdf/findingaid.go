@@ -0,0 +1,619 @@
+//
+// findingaid.go lays out an EAD3 record as a printable finding aid.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package pdf
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/caltechlibrary/ead3"
+)
+
+const (
+	margin     = 54.0
+	bodySize   = 10.0
+	leading    = 13.0
+	headerSize = 8.0
+)
+
+// layout places text on the pages of a document keeping track of the current position
+type layout struct {
+	doc   *Document
+	page  *Page
+	y     float64
+	title string
+	// pageHeader is called each time the container list breaks onto a new page
+	pageHeader func()
+	// index maps a name to the pages it appears on
+	index map[string]map[int]bool
+}
+
+func (l *layout) left() float64 {
+	return margin
+}
+
+func (l *layout) width() float64 {
+	return l.doc.Width - 2*margin
+}
+
+func (l *layout) pageNumber() int {
+	return len(l.doc.Pages)
+}
+
+func (l *layout) newPage() {
+	l.page = l.doc.AddPage()
+	l.y = l.doc.Height - margin - leading
+	if l.pageHeader != nil {
+		l.pageHeader()
+	}
+}
+
+// ensure starts a new page when there isn't room for height points
+func (l *layout) ensure(height float64) {
+	if l.page == nil || l.y-height < margin+leading {
+		l.newPage()
+	}
+}
+
+// wrap breaks text into lines no wider than width
+func wrap(text string, font Font, size, width float64) []string {
+	lines := []string{}
+	line := ""
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if line != "" && Width(candidate, font, size) > width {
+			lines = append(lines, line)
+			line = word
+		} else {
+			line = candidate
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// paragraph sets text as wrapped lines starting at indent followed by a blank half line
+func (l *layout) paragraph(text string, font Font, size, indent float64) {
+	for _, line := range wrap(text, font, size, l.width()-indent) {
+		l.ensure(leading)
+		l.page.Text(l.left()+indent, l.y, font, size, line)
+		l.y -= leading
+	}
+	l.y -= leading / 2
+}
+
+func (l *layout) heading(text string, size float64) {
+	l.ensure(leading * 4)
+	l.y -= leading / 2
+	l.paragraph(text, Bold, size, 0)
+}
+
+// columns sets a row of cells, each cell wraps within its column, and returns after the tallest cell.
+// The optional indents give the indentation of the text in each column.
+func (l *layout) columns(cells []string, widths []float64, font Font, indents ...float64) {
+	indent := func(i int) float64 {
+		if i < len(indents) {
+			return indents[i]
+		}
+		return 0
+	}
+	wrapped := [][]string{}
+	rows := 1
+	for i, cell := range cells {
+		lines := wrap(cell, font, bodySize, widths[i]-indent(i)-6)
+		if len(lines) > rows {
+			rows = len(lines)
+		}
+		wrapped = append(wrapped, lines)
+	}
+	for row := 0; row < rows; row++ {
+		l.ensure(leading)
+		x := l.left()
+		for i, lines := range wrapped {
+			if row < len(lines) {
+				l.page.Text(x+indent(i), l.y, font, bodySize, lines[row])
+			}
+			x += widths[i]
+		}
+		l.y -= leading
+	}
+}
+
+func (l *layout) addToIndex(name string) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return
+	}
+	if _, ok := l.index[name]; ok == false {
+		l.index[name] = map[int]bool{}
+	}
+	l.index[name][l.pageNumber()] = true
+}
+
+func partsText(parts []*ead3.Part) string {
+	values := []string{}
+	for _, part := range parts {
+		values = append(values, strings.TrimSpace(part.Value))
+	}
+	return strings.Join(values, ", ")
+}
+
+// names returns the personal, family and corporate names in a ControlAccess and its children
+func names(controlAccess *ead3.ControlAccess) []string {
+	values := []string{}
+	if controlAccess == nil {
+		return values
+	}
+	for _, name := range controlAccess.Persname {
+		values = append(values, partsText(name.Part))
+	}
+	for _, name := range controlAccess.Famname {
+		values = append(values, partsText(name.Part))
+	}
+	for _, name := range controlAccess.CorpName {
+		values = append(values, partsText(name.Part))
+	}
+	for _, name := range controlAccess.Name {
+		values = append(values, partsText(name.Part))
+	}
+	for _, child := range controlAccess.ControlAccess {
+		values = append(values, names(child)...)
+	}
+	return values
+}
+
+func dates(did *ead3.DID) string {
+	values := []string{}
+	for _, unitDate := range did.UnitDate {
+		values = append(values, strings.TrimSpace(unitDate.Value))
+	}
+	for _, structured := range did.UnitDateStructured {
+		for _, single := range structured.Singles() {
+			values = append(values, strings.TrimSpace(single.Value))
+		}
+		for _, dateRange := range structured.Ranges() {
+			from, to := "", ""
+			if dateRange.FromDate != nil {
+				from = strings.TrimSpace(dateRange.FromDate.Value)
+			}
+			if dateRange.ToDate != nil {
+				to = strings.TrimSpace(dateRange.ToDate.Value)
+			}
+			values = append(values, strings.Trim(from+"-"+to, "-"))
+		}
+	}
+	return strings.Join(values, ", ")
+}
+
+func (l *layout) titlePage(record *ead3.EAD3) {
+	l.newPage()
+	l.y = l.doc.Height - 200
+	fileDesc := (*ead3.FileDesc)(nil)
+	if record.Control != nil {
+		fileDesc = record.Control.FileDesc
+	}
+	l.paragraph(l.title, Bold, 20, 0)
+	if fileDesc == nil {
+		return
+	}
+	if titleStmt := fileDesc.TitleStmt; titleStmt != nil {
+		if titleStmt.Subtitle != nil {
+			l.paragraph(ead3.StripMarkup(titleStmt.Subtitle.Value), Regular, 14, 0)
+		}
+		if titleStmt.Author != nil {
+			l.y -= leading
+			l.paragraph(ead3.StripMarkup(titleStmt.Author.Value), Italic, 12, 0)
+		}
+		if titleStmt.Sponsor != nil {
+			l.paragraph(ead3.StripMarkup(titleStmt.Sponsor.Value), Regular, 12, 0)
+		}
+	}
+	if editionStmt := fileDesc.EditionStmt; editionStmt != nil {
+		l.paragraph(ead3.StripMarkup(editionStmt.Edition), Regular, 12, 0)
+	}
+	if publicationStmt := fileDesc.PublicationStmt; publicationStmt != nil {
+		l.y -= leading * 4
+		if publicationStmt.Publisher != nil {
+			l.paragraph(ead3.StripMarkup(publicationStmt.Publisher.Value), Bold, 12, 0)
+		}
+		if publicationStmt.Address != nil {
+			for _, line := range publicationStmt.Address.AddressLine {
+				l.paragraph(ead3.StripMarkup(line), Regular, bodySize, 0)
+			}
+		}
+		for _, p := range publicationStmt.P {
+			l.paragraph(ead3.StripMarkup(p.Value), Regular, bodySize, 0)
+		}
+		if publicationStmt.Date != nil {
+			l.paragraph(ead3.StripMarkup(publicationStmt.Date.Value), Regular, bodySize, 0)
+		}
+	}
+}
+
+func (l *layout) summary(did *ead3.DID) {
+	widths := []float64{120, l.width() - 120}
+	field := func(label, value string) {
+		if value = strings.TrimSpace(ead3.StripMarkup(value)); value != "" {
+			l.columns([]string{label, value}, widths, Regular)
+			l.y -= leading / 2
+		}
+	}
+	if did.Repository != nil {
+		values := []string{}
+		for _, name := range did.Repository.CorpName {
+			values = append(values, partsText(name.Part))
+		}
+		field("Repository", strings.Join(values, "; "))
+	}
+	if did.Origination != nil {
+		values := []string{}
+		for _, name := range did.Origination.Persname {
+			values = append(values, partsText(name.Part))
+		}
+		for _, name := range did.Origination.Famname {
+			values = append(values, partsText(name.Part))
+		}
+		for _, name := range did.Origination.CorpName {
+			values = append(values, partsText(name.Part))
+		}
+		for _, name := range did.Origination.Name {
+			values = append(values, partsText(name.Part))
+		}
+		field("Creator", strings.Join(values, "; "))
+	}
+	if did.UnitTitle != nil {
+		field("Title", did.UnitTitle.Value)
+	}
+	if did.UnitID != nil {
+		field("Identifier", did.UnitID.Value)
+	}
+	field("Dates", dates(did))
+	if did.PhysDesc != nil {
+		field("Extent", did.PhysDesc.Value)
+	}
+	for _, physDesc := range did.PhysDescStructured {
+		extent := []string{}
+		if physDesc.Quantity != nil {
+			extent = append(extent, physDesc.Quantity.Value)
+		}
+		if physDesc.UnitType != nil {
+			extent = append(extent, physDesc.UnitType.Value)
+		}
+		field("Extent", strings.Join(extent, " "))
+	}
+	for _, language := range did.LangMaterial.Languages() {
+		field("Language", language.Value)
+	}
+	if did.Abstract != nil {
+		field("Abstract", did.Abstract.Value)
+	}
+	if did.PhysLoc != nil {
+		field("Location", did.PhysLoc.Value)
+	}
+}
+
+// listItems returns the text of the items in a list, definition items are given as "label: item"
+func listItems(list *ead3.List) []string {
+	items := []string{}
+	nodes, err := ead3.ParseMarkup(list.Value)
+	if err != nil {
+		return items
+	}
+	for _, node := range nodes {
+		switch node.Name {
+		case "item":
+			items = append(items, strings.Join(strings.Fields(node.Content()), " "))
+		case "defitem":
+			label, item := "", ""
+			for _, child := range node.Children {
+				switch child.Name {
+				case "label":
+					label = strings.Join(strings.Fields(child.Content()), " ")
+				case "item":
+					item = strings.Join(strings.Fields(child.Content()), " ")
+				}
+			}
+			items = append(items, strings.Trim(label+": "+item, ": "))
+		}
+	}
+	return items
+}
+
+func (l *layout) note(note *ead3.Note, size float64) {
+	l.heading(note.Label(), size)
+	for _, p := range note.P {
+		l.paragraph(ead3.StripMarkup(p.Value), Regular, bodySize, 0)
+	}
+	for _, list := range note.List {
+		for i, item := range listItems(list) {
+			bullet := "•"
+			if list.ListType == "ordered" {
+				bullet = fmt.Sprintf("%d.", i+1)
+			}
+			l.ensure(leading)
+			l.page.Text(l.left()+6, l.y, Regular, bodySize, bullet)
+			l.paragraph(item, Regular, bodySize, 24)
+		}
+	}
+	for _, chronList := range note.ChronList {
+		widths := []float64{100, l.width() - 100}
+		for _, item := range chronList.ChronItem {
+			events := []string{}
+			for _, event := range item.Events() {
+				events = append(events, ead3.StripMarkup(event.Value))
+			}
+			l.columns([]string{item.Date(), strings.Join(events, " ")}, widths, Regular)
+			l.y -= leading / 4
+		}
+		l.y -= leading / 2
+	}
+	for _, table := range note.Table {
+		if table.TGroup != nil {
+			l.table(table.TGroup)
+		}
+	}
+	for _, blockQuote := range note.BlockQuote {
+		l.paragraph(ead3.StripMarkup(blockQuote.Value), Regular, bodySize, 24)
+	}
+}
+
+func (l *layout) table(tgroup *ead3.TGroup) {
+	cols := 0
+	rows := [][]*ead3.Row{}
+	if tgroup.THead != nil {
+		rows = append(rows, tgroup.THead.Row)
+	}
+	if tgroup.TBody != nil {
+		rows = append(rows, tgroup.TBody.Row)
+	}
+	for _, group := range rows {
+		for _, row := range group {
+			if len(row.Entry) > cols {
+				cols = len(row.Entry)
+			}
+		}
+	}
+	if cols == 0 {
+		return
+	}
+	widths := make([]float64, cols)
+	for i := range widths {
+		widths[i] = l.width() / float64(cols)
+	}
+	cells := func(row *ead3.Row) []string {
+		values := make([]string, cols)
+		for i, entry := range row.Entry {
+			values[i] = strings.TrimSpace(entry.Value)
+		}
+		return values
+	}
+	if tgroup.THead != nil {
+		for _, row := range tgroup.THead.Row {
+			l.columns(cells(row), widths, Bold)
+		}
+		l.page.Line(l.left(), l.y+leading-3, l.left()+l.width(), l.y+leading-3, 0.5)
+	}
+	if tgroup.TBody != nil {
+		for _, row := range tgroup.TBody.Row {
+			l.columns(cells(row), widths, Regular)
+		}
+	}
+	l.y -= leading / 2
+}
+
+// containerTypes returns the container localtypes used in order of first use
+func containerTypes(components []*ead3.Component) []string {
+	types := []string{}
+	seen := map[string]bool{}
+	ead3.Walk(components, func(c *ead3.Component) error {
+		for _, container := range c.Containers() {
+			localType := strings.ToLower(container.LocalType)
+			if seen[localType] == false {
+				seen[localType] = true
+				types = append(types, localType)
+			}
+		}
+		return nil
+	})
+	return types
+}
+
+func (l *layout) containerList(head string, components []*ead3.Component) {
+	types := containerTypes(components)
+	widths := []float64{}
+	for range types {
+		widths = append(widths, 54)
+	}
+	used := 54.0 * float64(len(types))
+	widths = append(widths, l.width()-used-90, 90)
+	columnHeads := []string{}
+	for _, localType := range types {
+		if localType == "" {
+			localType = "container"
+		}
+		columnHeads = append(columnHeads, strings.ToUpper(localType[:1])+localType[1:])
+	}
+	columnHeads = append(columnHeads, "Description", "Dates")
+	header := func() {
+		l.columns(columnHeads, widths, Bold)
+		l.page.Line(l.left(), l.y+leading-3, l.left()+l.width(), l.y+leading-3, 0.5)
+	}
+
+	l.newPage()
+	l.heading(head, 14)
+	header()
+	l.pageHeader = header
+	ead3.Walk(components, func(c *ead3.Component) error {
+		cells := make([]string, len(types))
+		for _, container := range c.Containers() {
+			for i, localType := range types {
+				if strings.ToLower(container.LocalType) == localType {
+					cells[i] = strings.TrimSpace(cells[i] + " " + strings.TrimSpace(container.Value))
+				}
+			}
+		}
+		font := Regular
+		if len(c.Children) > 0 {
+			font = Bold
+			l.ensure(leading * 3)
+		}
+		// NOTE: indent the description by depth with non-breaking spaces so it survives wrapping
+		description := strings.Repeat("   ", c.Depth-1) + c.Title()
+		date := ""
+		if did := c.DID(); did != nil {
+			date = dates(did)
+		}
+		l.columns(append(cells, description, date), widths, font)
+		for _, controlAccess := range c.ControlAccess() {
+			for _, name := range names(controlAccess) {
+				l.addToIndex(name)
+			}
+		}
+		return nil
+	})
+	l.pageHeader = nil
+}
+
+func (l *layout) nameIndex() {
+	if len(l.index) == 0 {
+		return
+	}
+	l.newPage()
+	l.heading("Index of Names", 14)
+	keys := []string{}
+	for name := range l.index {
+		keys = append(keys, name)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return strings.ToLower(keys[i]) < strings.ToLower(keys[j])
+	})
+	for _, name := range keys {
+		pages := []int{}
+		for page := range l.index[name] {
+			pages = append(pages, page)
+		}
+		sort.Ints(pages)
+		refs := []string{}
+		for _, page := range pages {
+			refs = append(refs, fmt.Sprintf("%d", page))
+		}
+		l.paragraph(name+", "+strings.Join(refs, ", "), Regular, bodySize, 0)
+		l.y += leading / 2
+	}
+}
+
+// runningHeaders adds the collection title and page numbers to every page after the title page
+func (l *layout) runningHeaders() {
+	total := len(l.doc.Pages)
+	for i, page := range l.doc.Pages {
+		if i == 0 {
+			continue
+		}
+		title := l.title
+		for len(title) > 0 && Width(title, Italic, headerSize) > l.width()-80 {
+			title = title[:len(title)-1]
+		}
+		page.Text(l.left(), l.doc.Height-margin/2-headerSize, Italic, headerSize, title)
+		footer := fmt.Sprintf("Page %d of %d", i+1, total)
+		page.Text(l.doc.Width-margin-Width(footer, Regular, headerSize), margin/2, Regular, headerSize, footer)
+	}
+}
+
+// title returns the collection title from the title statement or the ArchDesc DID
+func title(record *ead3.EAD3) string {
+	if record.Control != nil && record.Control.FileDesc != nil && record.Control.FileDesc.TitleStmt != nil && record.Control.FileDesc.TitleStmt.TitleProper != nil {
+		if s := ead3.StripMarkup(record.Control.FileDesc.TitleStmt.TitleProper.Value); s != "" {
+			return s
+		}
+	}
+	if record.ArchDesc != nil {
+		for _, did := range record.ArchDesc.DID {
+			if did.UnitTitle != nil {
+				return ead3.StripMarkup(did.UnitTitle.Value)
+			}
+		}
+	}
+	return "Finding Aid"
+}
+
+// NewFindingAid lays out the record as a finding aid: a title page from the FileDesc,
+// the collection summary, the narrative notes, the container list and an index of
+// the names found in the controlled access headings.
+func NewFindingAid(record *ead3.EAD3) *Document {
+	l := &layout{
+		title: title(record),
+		index: map[string]map[int]bool{},
+	}
+	l.doc = NewDocument(l.title)
+	l.titlePage(record)
+	if archDesc := record.ArchDesc; archDesc != nil {
+		l.newPage()
+		l.heading("Collection Summary", 14)
+		for _, did := range archDesc.DID {
+			l.summary(did)
+		}
+		for _, controlAccess := range archDesc.ControlAccess {
+			for _, name := range names(controlAccess) {
+				l.addToIndex(name)
+			}
+		}
+		for _, note := range archDesc.Notes() {
+			l.note(note, 12-float64(note.Depth))
+		}
+		if components := archDesc.Dsc.Components(); len(components) > 0 {
+			head := "Container List"
+			if archDesc.Dsc.Head != nil {
+				head = ead3.StripMarkup(archDesc.Dsc.Head.Value)
+			}
+			l.containerList(head, components)
+		}
+	}
+	l.nameIndex()
+	l.runningHeaders()
+	return l.doc
+}
+
+// Write writes the finding aid for record as a PDF document to w
+func Write(w io.Writer, record *ead3.EAD3) error {
+	_, err := NewFindingAid(record).WriteTo(w)
+	return err
+}
@@ -0,0 +1,121 @@
+//
+// pdf_test.go tests writing finding aids as PDF.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package pdf
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/caltechlibrary/ead3"
+)
+
+func TestWrap(t *testing.T) {
+	lines := wrap("The quick brown fox jumps over the lazy dog", Regular, 10, 80)
+	if len(lines) < 2 {
+		t.Fatalf("expected the text to wrap, got %q", lines)
+	}
+	for _, line := range lines {
+		if Width(line, Regular, 10) > 80 {
+			t.Errorf("line %q is wider than 80 points", line)
+		}
+	}
+	if Width("MMM", Bold, 10) <= Width("iii", Bold, 10) {
+		t.Errorf("expected M to be wider than i")
+	}
+}
+
+func TestEscape(t *testing.T) {
+	testData := map[string]string{
+		`plain`:      `plain`,
+		`(paren) \ `: `\(paren\) \\ `,
+		"café":       `caf\351`,
+		"“q”":        `\223q\224`,
+		"中":          `?`,
+	}
+	for src, expected := range testData {
+		if result := escape(src); result != expected {
+			t.Errorf("escape(%q), expected %q, got %q", src, expected, result)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	src, err := ioutil.ReadFile("../testsamples/ead3/S.0001_valid.xml")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	record := ead3.New()
+	if err := xml.Unmarshal(src, &record); err != nil {
+		t.Fatalf("%s", err)
+	}
+	doc := NewFindingAid(record)
+	if len(doc.Pages) < 4 {
+		t.Errorf("expected title, summary, container list and index pages, got %d pages", len(doc.Pages))
+	}
+	buf := new(bytes.Buffer)
+	if _, err := doc.WriteTo(buf); err != nil {
+		t.Fatalf("%s", err)
+	}
+	out := buf.String()
+	if strings.HasPrefix(out, "%PDF-1.4") == false || strings.HasSuffix(out, "%%EOF\n") == false {
+		t.Errorf("expected a PDF header and trailer")
+	}
+	for _, expected := range []string{"(Collection Summary)", "(Index of Names)", "/Count " + fmt.Sprintf("%d", len(doc.Pages)), "(Page 2 of "} {
+		if strings.Contains(out, expected) == false {
+			t.Errorf("expected %q in PDF", expected)
+		}
+	}
+	// Each object listed in the cross reference table must start at its offset
+	xref := out[strings.LastIndex(out, "\nxref\n")+1:]
+	checked := 0
+	for i, line := range strings.Split(xref, "\n")[3:] {
+		if strings.HasSuffix(line, " n ") == false {
+			break
+		}
+		offset, _ := strconv.Atoi(line[:10])
+		checked++
+		if strings.HasPrefix(out[offset:], fmt.Sprintf("%d", i+1)+" 0 obj") == false {
+			t.Errorf("object %d is not at offset %d", i+1, offset)
+		}
+	}
+	if checked != 6+2*len(doc.Pages) {
+		t.Errorf("expected %d objects in the cross reference table, got %d", 6+2*len(doc.Pages), checked)
+	}
+}
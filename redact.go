@@ -0,0 +1,417 @@
+//
+// redact.go applies redaction rules to a finding aid before publication, keeping an audit log.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Redaction actions
+const (
+	// RedactRemove removes the matched element and everything inside it
+	RedactRemove = "remove"
+	// RedactMask keeps a matched component as a placeholder holding its head, unitid,
+	// dates, containers, conditions governing access and child components with its title
+	// replaced by RedactedText.
+	// A masked note keeps its head and a masked name reads RedactedText.
+	RedactMask = "mask"
+	// RedactLinks removes the digital archival objects of a matched component
+	RedactLinks = "links"
+)
+
+var (
+	// RedactedText replaces the text of masked titles, notes and names
+	RedactedText = "[Redacted]"
+	// RedactAgent is recorded as the agent of the maintenance event Redact appends
+	RedactAgent = "github.com/caltechlibrary/ead3"
+)
+
+// RedactionRule selects what Redact removes or masks, a rule gives one of Audience,
+// Status, LocalType or Names
+type RedactionRule struct {
+	// Name identifies the rule in the audit log
+	Name string `json:"name"`
+	// Audience removes the elements meant for another audience, see FilterAudience
+	Audience string `json:"audience,omitempty"`
+	// Status matches the components whose effective access is Status (AccessRestricted
+	// or AccessClosed) or more restrictive
+	Status string `json:"status,omitempty"`
+	// LocalType matches the notes with one of these localtype values
+	LocalType []string `json:"localtype,omitempty"`
+	// Names matches the persname, famname, corpname and name headings in controlaccess,
+	// compared as a LooseKey
+	Names []string `json:"names,omitempty"`
+	// Action is applied to what Status, LocalType and Names match, RedactRemove if empty.
+	// Audience rules always remove and take no action.
+	Action string `json:"action,omitempty"`
+}
+
+// Redaction is an entry in the audit log kept by Redact
+type Redaction struct {
+	Rule   string `json:"rule"`
+	Action string `json:"action"`
+	// Element is the element name, e.g. "c", "persname" or "dao"
+	Element string `json:"element"`
+	// Path locates the component redacted or holding the element as in a Change, empty
+	// outside the components or where the component is not known
+	Path string `json:"path,omitempty"`
+	// Title is the title of the component or, for a name, the heading
+	Title string `json:"title,omitempty"`
+	// Reason says why the rule matched
+	Reason string `json:"reason"`
+}
+
+type redactor struct {
+	record *EAD3
+	asOf   time.Time
+	// paths holds the Path of each component element
+	paths map[interface{}]string
+	log   []Redaction
+}
+
+func (r *redactor) add(rule, action, element string, owner interface{}, title, reason string) {
+	if title == "" && owner != nil {
+		title = (&Component{Element: owner}).Title()
+	}
+	r.log = append(r.log, Redaction{Rule: rule, Action: action, Element: element, Path: r.paths[owner], Title: title, Reason: reason})
+}
+
+// redactElements calls fn for every element held in a slice inside v along with the
+// component holding it (nil outside the components). fn returns the element to keep,
+// or an invalid Value to drop it.
+func (r *redactor) redactElements(v reflect.Value, owner interface{}, fn func(item reflect.Value, owner interface{}) reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() == false {
+			if _, ok := r.paths[v.Interface()]; ok == true {
+				owner = v.Interface()
+			}
+			r.redactElements(v.Elem(), owner, fn)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := v.Field(i)
+			if t.Field(i).PkgPath != "" {
+				continue
+			}
+			if f.Kind() != reflect.Slice || f.Type().Elem().Kind() != reflect.Ptr {
+				r.redactElements(f, owner, fn)
+				continue
+			}
+			kept := reflect.MakeSlice(f.Type(), 0, f.Len())
+			for j := 0; j < f.Len(); j++ {
+				item := f.Index(j)
+				if item.IsNil() == false {
+					if item = fn(item, owner); item.IsValid() == false {
+						continue
+					}
+					r.redactElements(item, owner, fn)
+				}
+				kept = reflect.Append(kept, item)
+			}
+			if kept.Len() == 0 {
+				kept = reflect.Zero(f.Type())
+			}
+			f.Set(kept)
+		}
+	}
+}
+
+// audience removes the elements meant for an audience other than audience
+func (r *redactor) audience(rule, audience string) {
+	filterAudience(reflect.ValueOf(r.record), audience, func(name string, element interface{}) {
+		reason := "embedded markup for another audience"
+		if element != nil {
+			reason = fmt.Sprintf("audience is %q", reflect.ValueOf(element).Elem().FieldByName("Audience").String())
+		}
+		r.add(rule, RedactRemove, name, element, "", reason)
+	})
+}
+
+// maskComponent empties the component element c but for its identification, head,
+// containers, conditions governing access and child components. The components of a
+// nested dsc are kept without the dsc's paragraphs.
+func maskComponent(c reflect.Value) {
+	v := c.Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		switch t.Field(i).Name {
+		case "XMLName", "Level", "ID", "Audience", "Head", "AccessRestrict", "Container", "C", "C02", "C03", "C04":
+		case "Dsc":
+			dscs := []*Dsc{}
+			for _, dsc := range v.Field(i).Interface().([]*Dsc) {
+				d := *dsc
+				d.P = nil
+				dscs = append(dscs, &d)
+			}
+			if len(dscs) > 0 {
+				v.Field(i).Set(reflect.ValueOf(dscs))
+			}
+		case "DID":
+			if did, ok := v.Field(i).Interface().(*DID); ok == true && did != nil {
+				v.Field(i).Set(reflect.ValueOf(&DID{
+					UnitTitle:          &UnitTitle{Value: RedactedText},
+					UnitID:             did.UnitID,
+					UnitDate:           did.UnitDate,
+					UnitDateStructured: did.UnitDateStructured,
+					Container:          did.Container,
+				}))
+			}
+		default:
+			v.Field(i).Set(reflect.Zero(v.Field(i).Type()))
+		}
+	}
+}
+
+// status applies action to the components whose effective access is at least as
+// restrictive as status
+func (r *redactor) status(rule, action, status string) {
+	reasons := map[interface{}]string{}
+	r.record.Walk(func(c *Component) error {
+		access := EffectiveAccess(r.record, c, r.asOf)
+		if accessRank[access.Status] < accessRank[status] {
+			return nil
+		}
+		reason := "access is " + access.Status
+		if access.Restriction != nil && access.Restriction.Until.IsZero() == false {
+			reason += " until " + access.Restriction.Until.Format("2006-01-02")
+		}
+		if access.Inherited == true {
+			reason += " (inherited)"
+		}
+		reasons[c.Element] = reason
+		return nil
+	})
+	r.redactElements(reflect.ValueOf(r.record), nil, func(item reflect.Value, owner interface{}) reflect.Value {
+		reason, ok := reasons[item.Interface()]
+		if ok == false {
+			return item
+		}
+		element := elementName(item.Type().Elem())
+		switch action {
+		case RedactRemove:
+			r.add(rule, action, element, item.Interface(), "", reason)
+			return reflect.Value{}
+		case RedactMask:
+			r.add(rule, action, element, item.Interface(), "", reason)
+			maskComponent(item)
+		case RedactLinks:
+			c := &Component{Element: item.Interface()}
+			if did := c.DID(); did != nil {
+				for range did.DAO {
+					r.add(rule, action, "dao", item.Interface(), "", reason)
+				}
+				for range did.DAOSet {
+					r.add(rule, action, "daoset", item.Interface(), "", reason)
+				}
+				did.DAO, did.DAOSet = nil, nil
+			}
+		}
+		return item
+	})
+}
+
+// localType applies action to the notes with one of the localtype values
+func (r *redactor) localType(rule, action string, localTypes []string) {
+	r.redactElements(reflect.ValueOf(r.record), nil, func(item reflect.Value, owner interface{}) reflect.Value {
+		note := newNote(item.Interface())
+		if note == nil {
+			return item
+		}
+		localType := item.Elem().FieldByName("LocalType").String()
+		for _, s := range localTypes {
+			if strings.EqualFold(s, localType) == false {
+				continue
+			}
+			r.add(rule, action, note.Name, owner, "", fmt.Sprintf("localtype is %q", localType))
+			if action == RedactRemove {
+				return reflect.Value{}
+			}
+			masked := reflect.New(item.Type().Elem())
+			for _, name := range []string{"ID", "LocalType", "Audience", "Head"} {
+				masked.Elem().FieldByName(name).Set(item.Elem().FieldByName(name))
+			}
+			if p := masked.Elem().FieldByName("P"); p.IsValid() == true {
+				p.Set(reflect.ValueOf([]*P{{Value: RedactedText}}))
+			}
+			return masked
+		}
+		return item
+	})
+}
+
+// names applies action to the names in controlaccess matching one of names
+func (r *redactor) names(rule, action string, names []string) {
+	keys := map[string]bool{}
+	for _, name := range names {
+		keys[LooseKey(name)] = true
+	}
+	var redact func(owner interface{}, controlAccess *ControlAccess)
+	redact = func(owner interface{}, controlAccess *ControlAccess) {
+		v := reflect.ValueOf(controlAccess).Elem()
+		for _, field := range []string{"Persname", "Famname", "CorpName", "Name"} {
+			f := v.FieldByName(field)
+			kept := reflect.MakeSlice(f.Type(), 0, f.Len())
+			for i := 0; i < f.Len(); i++ {
+				item := f.Index(i)
+				heading := NewHeading(item.Interface())
+				if heading == nil || (keys[LooseKey(heading.Term())] == false && keys[LooseKey(heading.Value)] == false) {
+					kept = reflect.Append(kept, item)
+					continue
+				}
+				r.add(rule, action, heading.Type, owner, heading.Term(), "on the name list")
+				if action == RedactMask {
+					e := item.Elem()
+					e.FieldByName("Part").Set(reflect.ValueOf([]*Part{{Value: RedactedText}}))
+					for _, attr := range []string{"Normal", "Identifier", "Source"} {
+						e.FieldByName(attr).SetString("")
+					}
+					kept = reflect.Append(kept, item)
+				}
+			}
+			if kept.Len() == 0 {
+				kept = reflect.Zero(f.Type())
+			}
+			f.Set(kept)
+		}
+		for _, child := range controlAccess.ControlAccess {
+			redact(owner, child)
+		}
+	}
+	if r.record.ArchDesc != nil {
+		for _, controlAccess := range r.record.ArchDesc.ControlAccess {
+			redact(nil, controlAccess)
+		}
+	}
+	r.record.Walk(func(c *Component) error {
+		for _, controlAccess := range c.ControlAccess() {
+			redact(c.Element, controlAccess)
+		}
+		return nil
+	})
+}
+
+// Redact returns a copy of ead with rules applied in order along with an audit log of
+// what was removed or masked and why, and records the redaction as a maintenance event
+// in the copy. Access restrictions are evaluated as of asOf, ead itself is left unchanged.
+func Redact(ead *EAD3, rules []*RedactionRule, asOf time.Time) (*EAD3, []Redaction, error) {
+	names := []string{}
+	for i, rule := range rules {
+		name := rule.Name
+		if name == "" {
+			name = fmt.Sprintf("rule %d", i+1)
+		}
+		switch rule.Action {
+		case "", RedactRemove, RedactMask, RedactLinks:
+		default:
+			return nil, nil, fmt.Errorf("%s, unknown action %q", name, rule.Action)
+		}
+		selectors := []string{}
+		if rule.Audience != "" {
+			selectors = append(selectors, "audience")
+		}
+		if rule.Status != "" {
+			selectors = append(selectors, "status")
+		}
+		if len(rule.LocalType) > 0 {
+			selectors = append(selectors, "localtype")
+		}
+		if len(rule.Names) > 0 {
+			selectors = append(selectors, "names")
+		}
+		switch {
+		case len(selectors) == 0:
+			return nil, nil, fmt.Errorf("%s gives no audience, status, localtype or names", name)
+		case len(selectors) > 1:
+			return nil, nil, fmt.Errorf("%s combines %s, a rule gives one of them", name, strings.Join(selectors, " and "))
+		case rule.Audience != "" && rule.Action != "":
+			return nil, nil, fmt.Errorf("%s, an audience rule always removes, it takes no action", name)
+		case rule.Status != "" && rule.Status != AccessRestricted && rule.Status != AccessClosed:
+			return nil, nil, fmt.Errorf("%s, unknown status %q", name, rule.Status)
+		case rule.Status == "" && rule.Action == RedactLinks:
+			return nil, nil, fmt.Errorf("%s, %s applies to components matched by status", name, RedactLinks)
+		}
+		names = append(names, name)
+	}
+
+	clone, err := cloneRecord(ead)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := &redactor{record: clone, asOf: asOf, paths: map[interface{}]string{}, log: []Redaction{}}
+	_, list, _ := flattenComponents(clone)
+	for _, dc := range list {
+		r.paths[dc.Element] = dc.path()
+	}
+	for i, rule := range rules {
+		action := rule.Action
+		if action == "" {
+			action = RedactRemove
+		}
+		switch {
+		case rule.Audience != "":
+			r.audience(names[i], rule.Audience)
+		case rule.Status != "":
+			r.status(names[i], action, rule.Status)
+		case len(rule.LocalType) > 0:
+			r.localType(names[i], action, rule.LocalType)
+		case len(rule.Names) > 0:
+			r.names(names[i], action, rule.Names)
+		}
+	}
+
+	if clone.Control == nil {
+		clone.Control = new(Control)
+	}
+	if clone.Control.MaintenanceHistory == nil {
+		clone.Control.MaintenanceHistory = new(MaintenanceHistory)
+	}
+	now := time.Now()
+	clone.Control.MaintenanceHistory.MaintenanceEvent = append(clone.Control.MaintenanceHistory.MaintenanceEvent, &MaintenanceEvent{
+		EventType: &EventType{Value: "revised"},
+		EventDateTime: &EventDateTime{
+			StandardDateTime: now.Format(time.RFC3339),
+			Value:            now.Format("2006-01-02"),
+		},
+		AgentType:        &AgentType{Value: "machine"},
+		Agent:            RedactAgent,
+		EventDescription: fmt.Sprintf("Redacted %d elements applying %s", len(r.log), strings.Join(names, ", ")),
+	})
+	return clone, r.log, nil
+}
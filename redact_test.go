@@ -0,0 +1,139 @@
+//
+// redact_test.go tests rule-driven redaction and its audit log.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRedact(t *testing.T) {
+	record := readTestRecord(t, "testsamples/ead3/EAD3test.xml")
+	asOf := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	series := record.Components()
+	series[1].Element.(*C01).Audience = "internal"
+	first := series[0].Children[0].Element.(*C02)
+	first.AccessRestrict = []*AccessRestrict{{LocalType: "closed", P: []*P{{Value: "Closed to researchers."}}}}
+	first.DID.DAO = []*DAO{{HRef: "http://example.edu/scans/1"}}
+	first.Head = &Head{Value: "Closed File"}
+	first.Dsc = []*Dsc{{P: []*P{{Value: "Letters of the donor."}}, C: []*C{{DID: &DID{UnitTitle: &UnitTitle{Value: "Enclosure"}}}}}}
+	record.ArchDesc.ProcessInfo = append(record.ArchDesc.ProcessInfo, &ProcessInfo{LocalType: "staff", P: []*P{{Value: "Weeded duplicates."}}})
+	events := len(record.Control.MaintenanceHistory.MaintenanceEvent)
+
+	rules := []*RedactionRule{
+		{Name: "public", Audience: "external"},
+		{Name: "links", Status: AccessClosed, Action: RedactLinks},
+		{Name: "restricted", Status: AccessRestricted, Action: RedactMask},
+		{Name: "staff notes", LocalType: []string{"staff"}},
+		{Name: "privacy", Names: []string{"gusdorf, ima may"}},
+	}
+	redacted, log, err := Redact(record, rules, asOf)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	byRule := map[string][]Redaction{}
+	for _, entry := range log {
+		byRule[entry.Rule] = append(byRule[entry.Rule], entry)
+	}
+
+	// the internal series is removed
+	if n := len(redacted.ArchDesc.Dsc.C01); n != len(series)-1 {
+		t.Errorf("expected %d series, got %d", len(series)-1, n)
+	}
+	if entries := byRule["public"]; len(entries) != 1 || entries[0].Path != "c[series2]" || entries[0].Element != "c01" {
+		t.Errorf("expected the internal series in the log, got %+v", entries)
+	}
+
+	// the closed file loses its links, then is masked along with the restricted file
+	files := redacted.ArchDesc.Dsc.C01[0].C02
+	if len(files[0].DID.DAO) != 0 {
+		t.Errorf("expected the closed file's links to be removed")
+	}
+	if entries := byRule["links"]; len(entries) != 1 || entries[0].Element != "dao" || entries[0].Reason != "access is closed" {
+		t.Errorf("expected the link removal in the log, got %+v", entries)
+	}
+	for _, file := range files[0:2] {
+		if file.DID.UnitTitle.Value != RedactedText || len(file.DID.Container) != 2 || len(file.AccessRestrict) != 1 {
+			t.Errorf("expected a masked file keeping its containers and restriction, got %+v", file.DID)
+		}
+	}
+	if entries := byRule["restricted"]; len(entries) != 3 || entries[1].Title != "Enclosure" || entries[2].Title != "Gusdorf, Ima" {
+		t.Errorf("expected two masked files and the enclosure in the log, got %+v", entries)
+	}
+	// the masked file keeps its head and the masked components of its nested dsc
+	if files[0].Head == nil || len(files[0].Dsc) != 1 || len(files[0].Dsc[0].P) != 0 || len(files[0].Dsc[0].C) != 1 || files[0].Dsc[0].C[0].DID.UnitTitle.Value != RedactedText {
+		t.Errorf("expected the closed file to keep its head and masked enclosure, got %+v", files[0])
+	}
+	if files[2].DID.UnitTitle.Value == RedactedText {
+		t.Errorf("expected the open file to be kept")
+	}
+
+	// the staff note and the named person are removed
+	for _, note := range redacted.ArchDesc.Notes() {
+		if note.Name == "processinfo" && strings.Contains(note.P[0].Value, "Weeded") == true {
+			t.Errorf("expected the staff note to be removed")
+		}
+	}
+	if entries := byRule["privacy"]; len(entries) != 1 || entries[0].Element != "persname" || entries[0].Title != "Gusdorf, Ima May" {
+		t.Errorf("expected the name in the log, got %+v", entries)
+	}
+	for _, heading := range redacted.ArchDesc.ControlAccess[0].Headings() {
+		if strings.HasPrefix(heading.Term(), "Gusdorf") == true {
+			t.Errorf("expected the name to be removed from controlaccess")
+		}
+	}
+
+	// the redaction is recorded and the master record left unchanged
+	if n := len(redacted.Control.MaintenanceHistory.MaintenanceEvent); n != events+1 {
+		t.Errorf("expected a maintenance event to be added, got %d events", n)
+	}
+	if len(record.ArchDesc.Dsc.C01) != len(series) || len(first.DID.DAO) != 1 {
+		t.Errorf("expected the record to be left unchanged")
+	}
+
+	for _, rule := range []*RedactionRule{
+		{Name: "unknown status", Status: "sealed"},
+		{Name: "no selector", Action: RedactMask},
+		{Name: "combined", Status: AccessClosed, Names: []string{"gusdorf, ima may"}},
+		{Name: "audience and localtype", Audience: "external", LocalType: []string{"staff"}},
+		{Name: "audience action", Audience: "external", Action: RedactMask},
+		{Name: "links without status", LocalType: []string{"staff"}, Action: RedactLinks},
+	} {
+		if _, _, err := Redact(record, []*RedactionRule{rule}, asOf); err == nil {
+			t.Errorf("expected an error for rule %q", rule.Name)
+		}
+	}
+}
@@ -0,0 +1,359 @@
+//
+// relations.go links finding aids, agents and functions across a corpus and exports the graph.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Kinds of node in a relation graph
+const (
+	NodeFindingAid = "findingaid"
+	NodeAgent      = "agent"
+	NodeFunction   = "function"
+	NodeResource   = "resource"
+	NodeOther      = "other"
+)
+
+// Kinds of edge in a relation graph besides the relation types
+const (
+	EdgeCreatorOf = "creatorOf"
+	EdgeSubjectOf = "subjectOf"
+)
+
+// GraphNode is a finding aid, agent, function or resource in a relation graph
+type GraphNode struct {
+	ID    string `json:"id"`
+	Kind  string `json:"kind"`
+	Label string `json:"label"`
+	URI   string `json:"uri,omitempty"`
+}
+
+// GraphEdge links a finding aid to a related node. Kind is creatorOf, subjectOf
+// or the relationtype of a <relation>, Label is the relation's arcrole when given.
+type GraphEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Kind   string `json:"kind"`
+	Label  string `json:"label,omitempty"`
+}
+
+// Graph holds the nodes and edges built by RelationGraph
+type Graph struct {
+	Nodes []*GraphNode `json:"nodes"`
+	Edges []*GraphEdge `json:"edges"`
+
+	// byKey finds nodes by URI or by kind and normalized label
+	byKey map[string]*GraphNode
+	edges map[string]bool
+}
+
+// NewGraph returns an empty graph
+func NewGraph() *Graph {
+	return &Graph{
+		Nodes: []*GraphNode{},
+		Edges: []*GraphEdge{},
+		byKey: map[string]*GraphNode{},
+		edges: map[string]bool{},
+	}
+}
+
+// labelKey normalizes a label so the same name found in different records
+// shares a node
+func labelKey(kind, label string) string {
+	return kind + "\x00" + strings.ToLower(strings.TrimSuffix(strings.Join(strings.Fields(label), " "), "."))
+}
+
+// Node returns the node of kind for the uri or label, adding it when it is new.
+// A node found by label picks up the uri when it did not have one.
+func (graph *Graph) Node(kind, label, uri string) *GraphNode {
+	label = strings.TrimSpace(label)
+	uri = strings.TrimSpace(uri)
+	if label == "" && uri == "" {
+		return nil
+	}
+	if label == "" {
+		label = uri
+	}
+	var node *GraphNode
+	if uri != "" {
+		node = graph.byKey[uri]
+	}
+	if node == nil {
+		node = graph.byKey[labelKey(kind, label)]
+	}
+	if node == nil {
+		node = &GraphNode{
+			ID:    fmt.Sprintf("n%d", len(graph.Nodes)),
+			Kind:  kind,
+			Label: label,
+		}
+		graph.Nodes = append(graph.Nodes, node)
+	}
+	if node.URI == "" && uri != "" {
+		node.URI = uri
+	}
+	if uri != "" {
+		graph.byKey[uri] = node
+	}
+	graph.byKey[labelKey(node.Kind, label)] = node
+	return node
+}
+
+// Link adds an edge from source to target unless the same edge is already present
+func (graph *Graph) Link(source, target *GraphNode, kind, label string) {
+	if source == nil || target == nil || source == target {
+		return
+	}
+	key := source.ID + "\x00" + target.ID + "\x00" + kind + "\x00" + label
+	if graph.edges[key] == true {
+		return
+	}
+	graph.edges[key] = true
+	graph.Edges = append(graph.Edges, &GraphEdge{Source: source.ID, Target: target.ID, Kind: kind, Label: label})
+}
+
+// headingNode returns the node for a controlled access or origination heading
+func (graph *Graph) headingNode(heading *Heading) *GraphNode {
+	kind := ""
+	switch heading.Type {
+	case "persname", "corpname", "famname", "name":
+		kind = NodeAgent
+	case "function":
+		kind = NodeFunction
+	default:
+		return nil
+	}
+	return graph.Node(kind, heading.Term(), heading.Identifier)
+}
+
+// relationKind maps a relationtype to the kind of node it points to
+func relationKind(relationType string) string {
+	switch relationType {
+	case CPFRelation:
+		return NodeAgent
+	case FunctionRelation:
+		return NodeFunction
+	case ResourceRelation:
+		return NodeResource
+	}
+	return NodeOther
+}
+
+// Label returns the text of the relation's first relationentry
+func (relation *Relation) Label() string {
+	for _, entry := range relation.RelationEntry {
+		if s := StripMarkup(entry.Value); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// findingAidTitle returns the title of the collection described by a record
+func findingAidTitle(record *EAD3) string {
+	if record.ArchDesc != nil {
+		for _, did := range record.ArchDesc.DID {
+			if did.UnitTitle != nil {
+				if s := StripMarkup(did.UnitTitle.Value); s != "" {
+					return s
+				}
+			}
+		}
+	}
+	if record.Control != nil && record.Control.FileDesc != nil && record.Control.FileDesc.TitleStmt != nil &&
+		record.Control.FileDesc.TitleStmt.TitleProper != nil {
+		return StripMarkup(record.Control.FileDesc.TitleStmt.TitleProper.Value)
+	}
+	return ""
+}
+
+// RelationGraph links the finding aids in records to their creators (origination),
+// the agents and functions in their controlled access headings, and the entities
+// named in their <relations>. Agents and functions found in several records share
+// a node, matched by identifier or href when present and by name otherwise. A
+// resourcerelation pointing at the recordid or instanceurl of another record in
+// the corpus links the two finding aids.
+func RelationGraph(records []*EAD3) *Graph {
+	graph := NewGraph()
+	findingAids := make([]*GraphNode, len(records))
+	for i, record := range records {
+		recordID := facetRecordID(i, record)
+		uri := ""
+		if record.Control != nil && record.Control.RecordID != nil {
+			uri = record.Control.RecordID.InstanceURL
+		}
+		node := graph.Node(NodeFindingAid, recordID, uri)
+		graph.byKey[recordID] = node
+		if title := findingAidTitle(record); title != "" {
+			node.Label = title
+		}
+		findingAids[i] = node
+	}
+	for i, record := range records {
+		if record.ArchDesc == nil {
+			continue
+		}
+		findingAid := findingAids[i]
+		addOrigination := func(origination *Origination) {
+			for _, heading := range origination.Headings() {
+				graph.Link(findingAid, graph.headingNode(heading), EdgeCreatorOf, "")
+			}
+		}
+		addControlAccess := func(controlAccess []*ControlAccess) {
+			for _, ca := range controlAccess {
+				for _, heading := range ca.Headings() {
+					graph.Link(findingAid, graph.headingNode(heading), EdgeSubjectOf, "")
+				}
+			}
+		}
+		for _, did := range record.ArchDesc.DID {
+			addOrigination(did.Origination)
+		}
+		addControlAccess(record.ArchDesc.ControlAccess)
+		for _, relations := range record.ArchDesc.Relations {
+			for _, relation := range relations.Relation {
+				graph.addRelation(findingAid, relation)
+			}
+		}
+		record.Walk(func(c *Component) error {
+			if did := c.DID(); did != nil {
+				addOrigination(did.Origination)
+			}
+			addControlAccess(c.ControlAccess())
+			return nil
+		})
+	}
+	return graph
+}
+
+// addRelation links the finding aid to the entity named by a <relation>
+func (graph *Graph) addRelation(findingAid *GraphNode, relation *Relation) {
+	kind := relation.RelationshipType
+	if kind == OtherRelationType && relation.OtherRelationType != "" {
+		kind = relation.OtherRelationType
+	}
+	var target *GraphNode
+	if relation.RelationshipType == ResourceRelation && relation.HRef != "" {
+		if node, ok := graph.byKey[relation.HRef]; ok == true && node.Kind == NodeFindingAid {
+			target = node
+		}
+	}
+	if target == nil {
+		target = graph.Node(relationKind(relation.RelationshipType), relation.Label(), relation.HRef)
+	}
+	if kind == "" {
+		kind = NodeOther
+	}
+	graph.Link(findingAid, target, kind, relation.ArcRole)
+}
+
+// WriteGraphML writes the graph as GraphML, nodes carry their kind, label and uri,
+// edges their kind and label
+func (graph *Graph) WriteGraphML(w io.Writer) error {
+	out := bufio.NewWriter(w)
+	esc := func(s string) string {
+		buf := new(strings.Builder)
+		xml.EscapeText(buf, []byte(s))
+		return buf.String()
+	}
+	fmt.Fprintln(out, `<?xml version="1.0" encoding="UTF-8"?>`)
+	fmt.Fprintln(out, `<graphml xmlns="http://graphml.graphdrawing.org/xmlns">`)
+	fmt.Fprintln(out, `  <key id="kind" for="node" attr.name="kind" attr.type="string"/>`)
+	fmt.Fprintln(out, `  <key id="label" for="node" attr.name="label" attr.type="string"/>`)
+	fmt.Fprintln(out, `  <key id="uri" for="node" attr.name="uri" attr.type="string"/>`)
+	fmt.Fprintln(out, `  <key id="edgekind" for="edge" attr.name="kind" attr.type="string"/>`)
+	fmt.Fprintln(out, `  <key id="edgelabel" for="edge" attr.name="label" attr.type="string"/>`)
+	fmt.Fprintln(out, `  <graph id="ead3" edgedefault="directed">`)
+	for _, node := range graph.Nodes {
+		fmt.Fprintf(out, "    <node id=\"%s\">\n", esc(node.ID))
+		fmt.Fprintf(out, "      <data key=\"kind\">%s</data>\n", esc(node.Kind))
+		fmt.Fprintf(out, "      <data key=\"label\">%s</data>\n", esc(node.Label))
+		if node.URI != "" {
+			fmt.Fprintf(out, "      <data key=\"uri\">%s</data>\n", esc(node.URI))
+		}
+		fmt.Fprintln(out, "    </node>")
+	}
+	for i, edge := range graph.Edges {
+		fmt.Fprintf(out, "    <edge id=\"e%d\" source=\"%s\" target=\"%s\">\n", i, esc(edge.Source), esc(edge.Target))
+		fmt.Fprintf(out, "      <data key=\"edgekind\">%s</data>\n", esc(edge.Kind))
+		if edge.Label != "" {
+			fmt.Fprintf(out, "      <data key=\"edgelabel\">%s</data>\n", esc(edge.Label))
+		}
+		fmt.Fprintln(out, "    </edge>")
+	}
+	fmt.Fprintln(out, "  </graph>")
+	fmt.Fprintln(out, "</graphml>")
+	return out.Flush()
+}
+
+// dotShapes distinguishes the kinds of node when drawn by Graphviz
+var dotShapes = map[string]string{
+	NodeFindingAid: "box",
+	NodeAgent:      "ellipse",
+	NodeFunction:   "hexagon",
+	NodeResource:   "note",
+	NodeOther:      "plaintext",
+}
+
+// dotQuote quotes s as a DOT string
+func dotQuote(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, `"`, `\"`, -1)
+	s = strings.Replace(s, "\n", `\n`, -1)
+	return `"` + s + `"`
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language
+func (graph *Graph) WriteDOT(w io.Writer) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprintln(out, "digraph ead3 {")
+	for _, node := range graph.Nodes {
+		fmt.Fprintf(out, "  %s [label=%s, shape=%s];\n", dotQuote(node.ID), dotQuote(node.Label), dotShapes[node.Kind])
+	}
+	for _, edge := range graph.Edges {
+		label := edge.Kind
+		if edge.Label != "" {
+			label = edge.Label
+		}
+		fmt.Fprintf(out, "  %s -> %s [label=%s];\n", dotQuote(edge.Source), dotQuote(edge.Target), dotQuote(label))
+	}
+	fmt.Fprintln(out, "}")
+	return out.Flush()
+}
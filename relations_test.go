@@ -0,0 +1,150 @@
+//
+// relations_test.go tests the relation model and RelationGraph.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestRelations(t *testing.T) {
+	record := readTestRecord(t, "testsamples/ead3/S.0001_valid.xml")
+	if len(record.ArchDesc.Relations) != 1 || len(record.ArchDesc.Relations[0].Relation) != 3 {
+		t.Fatalf("expected 3 relations, got %+v", record.ArchDesc.Relations)
+	}
+	relations := record.ArchDesc.Relations[0]
+	relation := relations.Relation[0]
+	if relation.RelationshipType != CPFRelation || relation.Label() != "Hufflepuff, Helga" {
+		t.Errorf("expected cpfrelation Hufflepuff, Helga, got %q %q", relation.RelationshipType, relation.Label())
+	}
+
+	src := []byte(`<relation relationtype="otherrelationtype" otherrelationtype="depicts" arcrole="http://example.org/depicts">
+<relationentry localtype="place">Raleigh (N.C.)</relationentry>
+<objectxmlwrap><place xmlns="http://example.org/">Raleigh</place></objectxmlwrap>
+<daterange><fromdate standarddate="1900">1900</fromdate><todate standarddate="1950">1950</todate></daterange>
+<geogname><part>Raleigh (N.C.)</part><geographiccoordinates coordinatesystem="WGS84">35.78,-78.64</geographiccoordinates></geogname>
+</relation>`)
+	relation = new(Relation)
+	if err := xml.Unmarshal(src, relation); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if relation.OtherRelationType != "depicts" || relation.RelationEntry[0].LocalType != "place" {
+		t.Errorf("expected otherrelationtype and localtype, got %+v", relation)
+	}
+	if relation.ObjectXMLWrap == nil || strings.Contains(relation.ObjectXMLWrap.Value, "Raleigh") == false {
+		t.Errorf("expected objectxmlwrap, got %+v", relation.ObjectXMLWrap)
+	}
+	if relation.DateRange == nil || relation.DateRange.ToDate == nil || relation.DateRange.ToDate.StandardDate != "1950" {
+		t.Errorf("expected daterange, got %+v", relation.DateRange)
+	}
+	if relation.GeogName == nil || len(relation.GeogName.GeographicCoordinates) != 1 {
+		t.Errorf("expected geogname with coordinates, got %+v", relation.GeogName)
+	}
+}
+
+func TestRelationGraph(t *testing.T) {
+	records := []*EAD3{
+		readTestRecord(t, "testsamples/ead3/S.0001_valid.xml"),
+		readTestRecord(t, "testsamples/ead3/NCSU/mc00246.xml"),
+		readTestRecord(t, "testsamples/ead3/NCSU/mc00312.xml"),
+	}
+	graph := RelationGraph(records)
+	kinds := map[string]int{}
+	for _, node := range graph.Nodes {
+		kinds[node.Kind]++
+	}
+	if kinds[NodeFindingAid] != 3 {
+		t.Errorf("expected 3 finding aids, got %d", kinds[NodeFindingAid])
+	}
+	if kinds[NodeAgent] == 0 {
+		t.Errorf("expected agents in the graph")
+	}
+
+	// Cornell University is a subject of both NCSU records
+	var cornell *GraphNode
+	for _, node := range graph.Nodes {
+		if node.Kind == NodeAgent && strings.HasPrefix(node.Label, "Cornell University") {
+			cornell = node
+		}
+	}
+	if cornell == nil {
+		t.Fatalf("expected a node for Cornell University")
+	}
+	linked := 0
+	for _, edge := range graph.Edges {
+		if edge.Target == cornell.ID && edge.Kind == EdgeSubjectOf {
+			linked++
+		}
+	}
+	if linked != 2 {
+		t.Errorf("expected Cornell University linked to 2 finding aids, got %d", linked)
+	}
+
+	// the cpfrelation carries its href as the agent's uri
+	found := false
+	for _, node := range graph.Nodes {
+		if node.URI == "http://eadiva.com/hogwarts-cpf/H.001.xml" && node.Label == "Hufflepuff, Helga" {
+			found = true
+		}
+	}
+	if found == false {
+		t.Errorf("expected a node for Hufflepuff, Helga")
+	}
+
+	buf := new(bytes.Buffer)
+	if err := graph.WriteGraphML(buf); err != nil {
+		t.Fatalf("%s", err)
+	}
+	doc := struct {
+		Nodes []struct{} `xml:"graph>node"`
+		Edges []struct{} `xml:"graph>edge"`
+	}{}
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid GraphML, %s", err)
+	}
+	if len(doc.Nodes) != len(graph.Nodes) || len(doc.Edges) != len(graph.Edges) {
+		t.Errorf("expected %d nodes and %d edges, got %d and %d", len(graph.Nodes), len(graph.Edges), len(doc.Nodes), len(doc.Edges))
+	}
+
+	buf.Reset()
+	if err := graph.WriteDOT(buf); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if strings.HasPrefix(buf.String(), "digraph ead3 {") == false || strings.Count(buf.String(), " -> ") != len(graph.Edges) {
+		t.Errorf("unexpected DOT output\n%s", buf.String())
+	}
+}
@@ -0,0 +1,449 @@
+//
+// walk.go provides a common view of the components in a Dsc.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+// Component provides a common view of the unnumbered <c> and numbered <c01> through <c04>
+// elements so the series, files and items of a Dsc can be walked without regard to how
+// they were encoded.
+type Component struct {
+	// Element is the underlying *C, *C01, *C02, *C03 or *C04
+	Element  interface{}
+	Parent   *Component
+	Children []*Component
+	// Depth is 1 for the components directly inside the Dsc
+	Depth int
+}
+
+// componentFields holds pointers to the fields shared by the component elements, a nil
+// pointer means the element type does not carry that field.
+type componentFields struct {
+	Level             *string
+	ID                *string
+	Head              **Head
+	DID               **DID
+	BiogHist          *[]*BiogHist
+	ScopeContent      *[]*ScopeContent
+	Arrangement       *[]*Arrangement
+	AccessRestrict    *[]*AccessRestrict
+	UseRestrict       *[]*UseRestrict
+	AcqInfo           *[]*AcqInfo
+	CustodHist        *[]*CustodHist
+	Appraisal         *[]*Appraisal
+	Accruals          *[]*Accruals
+	ProcessInfo       *[]*ProcessInfo
+	RelatedMaterial   *[]*RelatedMaterial
+	SeparatedMaterial *[]*SeparatedMaterial
+	OtherFindAID      *[]*OtherFindAID
+	OriginalsLoc      *[]*OriginalsLoc
+	AltFormAvail      *[]*AltFormAvail
+	PhysTech          *[]*PhysTech
+	FilePlan          *[]*FilePlan
+	LegalStatus       *[]*LegalStatus
+	PreferCite        *[]*PreferCite
+	Bibliography      *[]*Bibliography
+	Odd               *[]*Odd
+	ControlAccess     *[]*ControlAccess
+	Relations         *[]*Relations
+	Index             *[]*Index
+	DIDNote           **DIDNote
+	Container         *[]*Container
+}
+
+func (c *Component) fields() *componentFields {
+	switch e := c.Element.(type) {
+	case *C:
+		return &componentFields{
+			Level:             &e.Level,
+			ID:                &e.ID,
+			Head:              &e.Head,
+			DID:               &e.DID,
+			BiogHist:          &e.BiogHist,
+			ScopeContent:      &e.ScopeContent,
+			Arrangement:       &e.Arrangement,
+			AccessRestrict:    &e.AccessRestrict,
+			UseRestrict:       &e.UseRestrict,
+			AcqInfo:           &e.AcqInfo,
+			CustodHist:        &e.CustodHist,
+			Appraisal:         &e.Appraisal,
+			Accruals:          &e.Accruals,
+			ProcessInfo:       &e.ProcessInfo,
+			RelatedMaterial:   &e.RelatedMaterial,
+			SeparatedMaterial: &e.SeparatedMaterial,
+			OtherFindAID:      &e.OtherFindAID,
+			OriginalsLoc:      &e.OriginalsLoc,
+			AltFormAvail:      &e.AltFormAvail,
+			PhysTech:          &e.PhysTech,
+			FilePlan:          &e.FilePlan,
+			LegalStatus:       &e.LegalStatus,
+			PreferCite:        &e.PreferCite,
+			Bibliography:      &e.Bibliography,
+			Odd:               &e.Odd,
+			ControlAccess:     &e.ControlAccess,
+			Relations:         &e.Relations,
+			Index:             &e.Index,
+			DIDNote:           &e.DIDNote,
+			Container:         &e.Container,
+		}
+	case *C01:
+		return &componentFields{
+			Level:             &e.Level,
+			ID:                &e.ID,
+			Head:              &e.Head,
+			DID:               &e.DID,
+			BiogHist:          &e.BiogHist,
+			ScopeContent:      &e.ScopeContent,
+			Arrangement:       &e.Arrangement,
+			AccessRestrict:    &e.AccessRestrict,
+			UseRestrict:       &e.UseRestrict,
+			AcqInfo:           &e.AcqInfo,
+			CustodHist:        &e.CustodHist,
+			Appraisal:         &e.Appraisal,
+			Accruals:          &e.Accruals,
+			ProcessInfo:       &e.ProcessInfo,
+			RelatedMaterial:   &e.RelatedMaterial,
+			SeparatedMaterial: &e.SeparatedMaterial,
+			OtherFindAID:      &e.OtherFindAID,
+			OriginalsLoc:      &e.OriginalsLoc,
+			AltFormAvail:      &e.AltFormAvail,
+			PhysTech:          &e.PhysTech,
+			FilePlan:          &e.FilePlan,
+			LegalStatus:       &e.LegalStatus,
+			PreferCite:        &e.PreferCite,
+			Bibliography:      &e.Bibliography,
+			Odd:               &e.Odd,
+			ControlAccess:     &e.ControlAccess,
+			Relations:         &e.Relations,
+			Index:             &e.Index,
+			DIDNote:           &e.DIDNote,
+			Container:         &e.Container,
+		}
+	case *C02:
+		return &componentFields{
+			Level:             &e.Level,
+			ID:                &e.ID,
+			Head:              &e.Head,
+			DID:               &e.DID,
+			BiogHist:          &e.BiogHist,
+			ScopeContent:      &e.ScopeContent,
+			Arrangement:       &e.Arrangement,
+			AccessRestrict:    &e.AccessRestrict,
+			UseRestrict:       &e.UseRestrict,
+			AcqInfo:           &e.AcqInfo,
+			CustodHist:        &e.CustodHist,
+			Appraisal:         &e.Appraisal,
+			Accruals:          &e.Accruals,
+			ProcessInfo:       &e.ProcessInfo,
+			RelatedMaterial:   &e.RelatedMaterial,
+			SeparatedMaterial: &e.SeparatedMaterial,
+			OtherFindAID:      &e.OtherFindAID,
+			OriginalsLoc:      &e.OriginalsLoc,
+			AltFormAvail:      &e.AltFormAvail,
+			PhysTech:          &e.PhysTech,
+			FilePlan:          &e.FilePlan,
+			LegalStatus:       &e.LegalStatus,
+			PreferCite:        &e.PreferCite,
+			Bibliography:      &e.Bibliography,
+			Odd:               &e.Odd,
+			ControlAccess:     &e.ControlAccess,
+			Relations:         &e.Relations,
+			Index:             &e.Index,
+			DIDNote:           &e.DIDNote,
+			Container:         &e.Container,
+		}
+	case *C03:
+		return &componentFields{
+			Level:             &e.Level,
+			ID:                &e.ID,
+			Head:              &e.Head,
+			DID:               &e.DID,
+			BiogHist:          &e.BiogHist,
+			ScopeContent:      &e.ScopeContent,
+			Arrangement:       &e.Arrangement,
+			AccessRestrict:    &e.AccessRestrict,
+			UseRestrict:       &e.UseRestrict,
+			AcqInfo:           &e.AcqInfo,
+			CustodHist:        &e.CustodHist,
+			Appraisal:         &e.Appraisal,
+			Accruals:          &e.Accruals,
+			ProcessInfo:       &e.ProcessInfo,
+			RelatedMaterial:   &e.RelatedMaterial,
+			SeparatedMaterial: &e.SeparatedMaterial,
+			OtherFindAID:      &e.OtherFindAID,
+			OriginalsLoc:      &e.OriginalsLoc,
+			AltFormAvail:      &e.AltFormAvail,
+			PhysTech:          &e.PhysTech,
+			FilePlan:          &e.FilePlan,
+			LegalStatus:       &e.LegalStatus,
+			PreferCite:        &e.PreferCite,
+			Bibliography:      &e.Bibliography,
+			Odd:               &e.Odd,
+			ControlAccess:     &e.ControlAccess,
+			Relations:         &e.Relations,
+			Index:             &e.Index,
+			DIDNote:           &e.DIDNote,
+			Container:         &e.Container,
+		}
+	case *C04:
+		return &componentFields{
+			Level:             &e.Level,
+			ID:                &e.ID,
+			Head:              &e.Head,
+			DID:               &e.DID,
+			BiogHist:          &e.BiogHist,
+			ScopeContent:      &e.ScopeContent,
+			Arrangement:       &e.Arrangement,
+			AccessRestrict:    &e.AccessRestrict,
+			UseRestrict:       &e.UseRestrict,
+			AcqInfo:           &e.AcqInfo,
+			CustodHist:        &e.CustodHist,
+			Appraisal:         &e.Appraisal,
+			Accruals:          &e.Accruals,
+			ProcessInfo:       &e.ProcessInfo,
+			RelatedMaterial:   &e.RelatedMaterial,
+			SeparatedMaterial: &e.SeparatedMaterial,
+			OtherFindAID:      &e.OtherFindAID,
+			OriginalsLoc:      &e.OriginalsLoc,
+			AltFormAvail:      &e.AltFormAvail,
+			PhysTech:          &e.PhysTech,
+			FilePlan:          &e.FilePlan,
+			LegalStatus:       &e.LegalStatus,
+			PreferCite:        &e.PreferCite,
+			Bibliography:      &e.Bibliography,
+			Odd:               &e.Odd,
+			ControlAccess:     &e.ControlAccess,
+			Relations:         &e.Relations,
+			Index:             &e.Index,
+			DIDNote:           &e.DIDNote,
+			Container:         &e.Container,
+		}
+	}
+	return new(componentFields)
+}
+
+// dscElements returns the top level components of the dscs
+func dscElements(dscs ...*Dsc) []interface{} {
+	elements := []interface{}{}
+	for _, dsc := range dscs {
+		if dsc == nil {
+			continue
+		}
+		for _, c := range dsc.C {
+			elements = append(elements, c)
+		}
+		for _, c := range dsc.C01 {
+			elements = append(elements, c)
+		}
+	}
+	return elements
+}
+
+// childElements returns the components nested directly inside element, those
+// of a nested dsc first as it precedes them in the document
+func childElements(element interface{}) []interface{} {
+	children := []interface{}{}
+	switch e := element.(type) {
+	case *C:
+		children = append(children, dscElements(e.Dsc...)...)
+		for _, child := range e.C {
+			children = append(children, child)
+		}
+	case *C01:
+		children = append(children, dscElements(e.Dsc...)...)
+		for _, child := range e.C02 {
+			children = append(children, child)
+		}
+	case *C02:
+		children = append(children, dscElements(e.Dsc...)...)
+		for _, child := range e.C03 {
+			children = append(children, child)
+		}
+	case *C03:
+		children = append(children, dscElements(e.Dsc...)...)
+		for _, child := range e.C04 {
+			children = append(children, child)
+		}
+	case *C04:
+		children = append(children, dscElements(e.Dsc...)...)
+	}
+	return children
+}
+
+func newComponents(parent *Component, depth int, elements []interface{}) []*Component {
+	components := []*Component{}
+	for _, element := range elements {
+		c := &Component{
+			Element: element,
+			Parent:  parent,
+			Depth:   depth,
+		}
+		c.Children = newComponents(c, depth+1, childElements(element))
+		components = append(components, c)
+	}
+	return components
+}
+
+// Components returns the top level components of the Dsc with their descendants
+func (dsc *Dsc) Components() []*Component {
+	if dsc == nil {
+		return []*Component{}
+	}
+	return newComponents(nil, 1, dscElements(dsc))
+}
+
+// Components returns the top level components of the record's Dsc
+func (ead *EAD3) Components() []*Component {
+	if ead == nil || ead.ArchDesc == nil {
+		return []*Component{}
+	}
+	return ead.ArchDesc.Dsc.Components()
+}
+
+// Walk visits the components and their descendants in document order. If fn
+// returns an error the walk stops and the error is returned.
+func Walk(components []*Component, fn func(*Component) error) error {
+	for _, c := range components {
+		if err := fn(c); err != nil {
+			return err
+		}
+		if err := Walk(c.Children, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Walk visits every component in the record's Dsc in document order
+func (ead *EAD3) Walk(fn func(*Component) error) error {
+	return Walk(ead.Components(), fn)
+}
+
+// Ancestors returns the components containing c starting with the top level component
+func (c *Component) Ancestors() []*Component {
+	ancestors := []*Component{}
+	for p := c.Parent; p != nil; p = p.Parent {
+		ancestors = append([]*Component{p}, ancestors...)
+	}
+	return ancestors
+}
+
+// ID returns the component's id attribute
+func (c *Component) ID() string {
+	if f := c.fields(); f.ID != nil {
+		return *f.ID
+	}
+	return ""
+}
+
+// SetID sets the component's id attribute
+func (c *Component) SetID(id string) {
+	if f := c.fields(); f.ID != nil {
+		*f.ID = id
+	}
+}
+
+// Level returns the component's level attribute (e.g. series, file, item)
+func (c *Component) Level() string {
+	if f := c.fields(); f.Level != nil {
+		return *f.Level
+	}
+	return ""
+}
+
+// DID returns the component's descriptive identification
+func (c *Component) DID() *DID {
+	if f := c.fields(); f.DID != nil {
+		return *f.DID
+	}
+	return nil
+}
+
+// Title returns the component's unit title as plain text
+func (c *Component) Title() string {
+	if did := c.DID(); did != nil && did.UnitTitle != nil {
+		return StripMarkup(did.UnitTitle.Value)
+	}
+	return ""
+}
+
+// ScopeContent returns the component's scope and content notes
+func (c *Component) ScopeContent() []*ScopeContent {
+	if f := c.fields(); f.ScopeContent != nil {
+		return *f.ScopeContent
+	}
+	return nil
+}
+
+// AccessRestrict returns the component's conditions governing access
+func (c *Component) AccessRestrict() []*AccessRestrict {
+	if f := c.fields(); f.AccessRestrict != nil {
+		return *f.AccessRestrict
+	}
+	return nil
+}
+
+// UseRestrict returns the component's conditions governing use
+func (c *Component) UseRestrict() []*UseRestrict {
+	if f := c.fields(); f.UseRestrict != nil {
+		return *f.UseRestrict
+	}
+	return nil
+}
+
+// ControlAccess returns the component's controlled access headings
+func (c *Component) ControlAccess() []*ControlAccess {
+	if f := c.fields(); f.ControlAccess != nil && *f.ControlAccess != nil {
+		return *f.ControlAccess
+	}
+	return []*ControlAccess{}
+}
+
+// Containers returns the containers described in the component's DID followed
+// by any given directly on the component
+func (c *Component) Containers() []*Container {
+	containers := []*Container{}
+	if did := c.DID(); did != nil {
+		containers = append(containers, did.Container...)
+	}
+	if f := c.fields(); f.Container != nil {
+		containers = append(containers, *f.Container...)
+	}
+	return containers
+}
+
+// DAOs returns the digital archival objects described in the component's DID,
+// including those grouped in a daoset
+func (c *Component) DAOs() []*DAO {
+	return c.DID().DAOs()
+}
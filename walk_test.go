@@ -0,0 +1,207 @@
+//
+// walk_test.go tests walking components and reading mixed content.
+//
+// @author: R. S. Doiel, <rsdoiel@caltech.edu>
+//
+// Copyright (c) 2016, Caltech
+// All rights not granted herein are expressly reserved by Caltech.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met:
+//
+// * Redistributions of source code must retain the above copyright notice, this
+//   list of conditions and the following disclaimer.
+//
+// * Redistributions in binary form must reproduce the above copyright notice,
+//   this list of conditions and the following disclaimer in the documentation
+//   and/or other materials provided with the distribution.
+//
+// * Neither the name of epgo nor the names of its
+//   contributors may be used to endorse or promote products derived from
+//   this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+// AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+// IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+// FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+// DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+// SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+// OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+package ead3
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func readTestRecord(t *testing.T, fname string) *EAD3 {
+	src, err := ioutil.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	record := New()
+	if err := xml.Unmarshal(src, &record); err != nil {
+		t.Fatalf("%s, %s", fname, err)
+	}
+	return record
+}
+
+func TestWalk(t *testing.T) {
+	// NOTE: EAD3test.xml uses numbered components, S.0001_valid.xml unnumbered ones
+	for _, fname := range []string{"testsamples/ead3/EAD3test.xml", "testsamples/ead3/S.0001_valid.xml"} {
+		record := readTestRecord(t, fname)
+		count, maxDepth := 0, 0
+		err := record.Walk(func(c *Component) error {
+			count++
+			if c.Depth > maxDepth {
+				maxDepth = c.Depth
+			}
+			if len(c.Ancestors()) != c.Depth-1 {
+				t.Errorf("%s, expected %d ancestors, got %d", fname, c.Depth-1, len(c.Ancestors()))
+			}
+			if c.DID() == nil {
+				t.Errorf("%s, expected a DID for component %q", fname, c.ID())
+			}
+			return nil
+		})
+		if err != nil {
+			t.Errorf("%s, %s", fname, err)
+		}
+		if count == 0 || maxDepth < 2 {
+			t.Errorf("%s, expected nested components, got %d to depth %d", fname, count, maxDepth)
+		}
+	}
+
+	c := &Component{Element: &C01{}}
+	c.SetID("ref1")
+	if c.ID() != "ref1" || c.Element.(*C01).ID != "ref1" {
+		t.Errorf("expected SetID to update the element")
+	}
+}
+
+func TestWalkNestedDsc(t *testing.T) {
+	src := []byte(`<ead>
+	<control><recordid>nested</recordid></control>
+	<archdesc level="collection">
+		<did><unittitle>Nested</unittitle></did>
+		<dsc>
+			<c01 id="s1" level="series">
+				<did><unittitle>Series</unittitle></did>
+				<dsc>
+					<c id="s1f1" level="file">
+						<did><unittitle>Described in a nested dsc</unittitle></did>
+						<c id="s1f1i1" level="item"><did><unittitle>Item</unittitle></did></c>
+					</c>
+				</dsc>
+				<c02 id="s1f2" level="file"><did><unittitle>File</unittitle></did></c02>
+			</c01>
+		</dsc>
+	</archdesc>
+</ead>`)
+	record := New()
+	if err := xml.Unmarshal(src, &record); err != nil {
+		t.Fatalf("%s", err)
+	}
+	ids := []string{}
+	record.Walk(func(c *Component) error {
+		ids = append(ids, fmt.Sprintf("%s/%d", c.ID(), c.Depth))
+		return nil
+	})
+	if expected := "s1/1 s1f1/2 s1f1i1/3 s1f2/2"; strings.Join(ids, " ") != expected {
+		t.Errorf("expected %q, got %q", expected, strings.Join(ids, " "))
+	}
+
+	ours, _ := cloneRecord(record)
+	ours.Components()[0].Children[0].DID().UnitTitle.Value = "Revised in a nested dsc"
+	merged, conflicts, err := Merge(record, ours, record)
+	if err != nil || len(conflicts) != 0 {
+		t.Fatalf("expected a clean merge, got %+v, %v", conflicts, err)
+	}
+	series := merged.ArchDesc.Dsc.C01[0]
+	if len(series.Dsc) != 1 || len(series.Dsc[0].C) != 1 || len(series.C02) != 1 {
+		t.Fatalf("expected the merge to keep the nested dsc, got %+v", series)
+	}
+	if title := series.Dsc[0].C[0].DID.UnitTitle.Value; title != "Revised in a nested dsc" {
+		t.Errorf("expected the revised title, got %q", title)
+	}
+}
+
+func TestStripMarkup(t *testing.T) {
+	testData := map[string]string{
+		"plain": "plain",
+		"<emph render=\"italic\">Tom</emph>'s\n\t diary": "Tom's diary",
+		"one<lb/>two":         "one two",
+		"Smith &amp; Sons":    "Smith & Sons",
+		"broken <emph>markup": "broken markup",
+	}
+	for src, expected := range testData {
+		if result := StripMarkup(src); result != expected {
+			t.Errorf("StripMarkup(%q), expected %q, got %q", src, expected, result)
+		}
+	}
+}
+
+func TestNotes(t *testing.T) {
+	record := readTestRecord(t, "testsamples/ead3/S.0001_valid.xml")
+	notes := record.ArchDesc.Notes()
+	if len(notes) == 0 || notes[0].Name != "bioghist" {
+		t.Fatalf("expected bioghist to be the first note")
+	}
+	for _, note := range notes {
+		if note.Label() == "" {
+			t.Errorf("expected a label for %s", note.Name)
+		}
+	}
+	blocks := map[string]int{}
+	count := func(notes []*Note) {
+		for _, note := range notes {
+			blocks["list"] += len(note.List)
+			blocks[note.Name+"/chronlist"] += len(note.ChronList)
+			blocks[note.Name+"/table"] += len(note.Table)
+		}
+	}
+	count(notes)
+	record.Walk(func(c *Component) error {
+		count(c.Notes())
+		return nil
+	})
+	if blocks["list"] != 4 || blocks["acqinfo/chronlist"] != 1 || blocks["userestrict/table"] != 1 {
+		t.Errorf("expected the lists, the acqinfo chronlist and the userestrict table, got %+v", blocks)
+	}
+
+	// repeated and nested notes
+	record.ArchDesc.Odd = append(record.ArchDesc.Odd, &Odd{
+		ID:   "odd2",
+		Head: &Head{Value: "Provenance"},
+		Odd:  []*Odd{{Head: &Head{Value: "Earlier owners"}, P: []*P{{Value: "Owls"}}}},
+	})
+	notes = record.ArchDesc.Notes()
+	last, nested := notes[len(notes)-2], notes[len(notes)-1]
+	if last.ID != "odd2" || last.Depth != 0 || nested.Label() != "Earlier owners" || nested.Depth != 1 {
+		t.Errorf("expected the nested odd to follow its parent, got %+v and %+v", last, nested)
+	}
+}
+
+func TestComponentNotes(t *testing.T) {
+	record := readTestRecord(t, "testsamples/ead3/S.0001_valid.xml")
+	c := record.Components()[0]
+	names := []string{}
+	for _, note := range c.Notes() {
+		names = append(names, note.Name)
+	}
+	expected := "scopecontent arrangement acqinfo otherfindaid altformavail"
+	if strings.Join(names, " ") != expected {
+		t.Errorf("expected component notes %q, got %q", expected, strings.Join(names, " "))
+	}
+	if ids := record.IDs(); len(ids.Dangling()) != 0 {
+		t.Errorf("expected the otherfindaid pointer to resolve, got %+v", ids.Dangling()[0])
+	}
+}